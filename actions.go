@@ -0,0 +1,104 @@
+package schemas
+
+import (
+	"fmt"
+	"net/url"
+	"sort"
+	"strings"
+
+	"github.com/acorn-io/schemer/data"
+)
+
+// AddResourceAction registers an action that operates on a single resource.
+func (s *Schema) AddResourceAction(name string, action Action) *Schema {
+	if s.ResourceActions == nil {
+		s.ResourceActions = map[string]Action{}
+	}
+	s.ResourceActions[name] = action
+	return s
+}
+
+// AddCollectionAction registers an action that operates on the collection.
+func (s *Schema) AddCollectionAction(name string, action Action) *Schema {
+	if s.CollectionActions == nil {
+		s.CollectionActions = map[string]Action{}
+	}
+	s.CollectionActions[name] = action
+	return s
+}
+
+// AddLink registers a link template. Placeholders of the form {field} are
+// replaced with the value of the field when the link is rendered.
+func (s *Schema) AddLink(name, template string) *Schema {
+	if s.Links == nil {
+		s.Links = map[string]string{}
+	}
+	s.Links[name] = template
+	return s
+}
+
+// Link renders the link template name for obj. Field values are path escaped.
+func (s *Schema) Link(name string, obj data.Object) (string, error) {
+	template, ok := s.Links[name]
+	if !ok {
+		return "", fmt.Errorf("failed to find link %s on schema %s", name, s.ID)
+	}
+
+	var (
+		result strings.Builder
+		rest   = template
+	)
+	for {
+		start := strings.Index(rest, "{")
+		if start < 0 {
+			result.WriteString(rest)
+			break
+		}
+		end := strings.Index(rest[start:], "}")
+		if end < 0 {
+			return "", fmt.Errorf("unterminated placeholder in link %s on schema %s", name, s.ID)
+		}
+		result.WriteString(rest[:start])
+		result.WriteString(url.PathEscape(obj.String(rest[start+1 : start+end])))
+		rest = rest[start+end+1:]
+	}
+
+	return result.String(), nil
+}
+
+func validateActions(schema *Schema, schemas *Schemas) error {
+	check := func(kind string, actions map[string]Action) error {
+		names := make([]string, 0, len(actions))
+		for name := range actions {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+
+		for _, name := range names {
+			action := actions[name]
+			for _, typeName := range []string{action.Input, action.Output} {
+				if typeName == "" || typeName == schema.ID || isBuiltinType(typeName) {
+					continue
+				}
+				if schemas.Schema(typeName) == nil {
+					return fmt.Errorf("failed to find schema %s used by %s action %s on schema %s", typeName, kind, name, schema.ID)
+				}
+			}
+		}
+		return nil
+	}
+
+	if err := check("resource", schema.ResourceActions); err != nil {
+		return err
+	}
+	return check("collection", schema.CollectionActions)
+}
+
+func validateLinks(schema *Schema) error {
+	for name, template := range schema.Links {
+		if strings.Count(template, "{") != strings.Count(template, "}") {
+			return fmt.Errorf("invalid link template %s for link %s on schema %s", template, name, schema.ID)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,55 @@
+package schemas
+
+import (
+	"testing"
+
+	"github.com/acorn-io/schemer/data"
+)
+
+func TestLink(t *testing.T) {
+	schema := (&Schema{ID: "app"}).
+		AddLink("logs", "/v1/namespaces/{namespace}/apps/{name}/logs").
+		AddLink("broken", "/apps/{name")
+
+	link, err := schema.Link("logs", data.Object{"namespace": "default", "name": "a b/c"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if link != "/v1/namespaces/default/apps/a%20b%2Fc/logs" {
+		t.Fatalf("unexpected link %s", link)
+	}
+
+	if _, err := schema.Link("missing", data.Object{}); err == nil {
+		t.Fatal("expected error for a missing link")
+	}
+	if _, err := schema.Link("broken", data.Object{}); err == nil {
+		t.Fatal("expected error for an unterminated placeholder")
+	}
+}
+
+func TestValidateActions(t *testing.T) {
+	s := EmptySchemas()
+	s.MustAddSchema(Schema{ID: "scaleInput"})
+
+	schema := Schema{ID: "app"}
+	schema.AddResourceAction("scale", Action{Input: "scaleInput", Output: "app"})
+	schema.AddCollectionAction("count", Action{Output: "int"})
+	if err := s.AddSchema(schema); err != nil {
+		t.Fatal(err)
+	}
+	if app := s.Schema("app"); len(app.ResourceActions) != 1 || len(app.CollectionActions) != 1 {
+		t.Fatalf("expected actions on the schema, got %v %v", app.ResourceActions, app.CollectionActions)
+	}
+
+	schema = Schema{ID: "job"}
+	schema.AddResourceAction("run", Action{Input: "missing"})
+	if err := s.AddSchema(schema); err == nil {
+		t.Fatal("expected error for an action with a missing input schema")
+	}
+
+	schema = Schema{ID: "job"}
+	schema.AddLink("self", "/jobs/{name")
+	if err := s.AddSchema(schema); err == nil {
+		t.Fatal("expected error for an invalid link template")
+	}
+}
@@ -0,0 +1,152 @@
+// Package admission implements Kubernetes admission webhooks for the schemas
+// of a registry. ValidatingHandler rejects objects that don't match their
+// schema and MutatingHandler sets their defaults.
+// ValidatingWebhookConfiguration and MutatingWebhookConfiguration generate the
+// configurations that register the handlers for the resources of the schemas.
+package admission
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+
+	schemas "github.com/acorn-io/schemer"
+	"github.com/acorn-io/schemer/data"
+	"github.com/acorn-io/schemer/data/convert"
+	"github.com/acorn-io/schemer/kubestore"
+	admissionv1 "k8s.io/api/admission/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// maxRequestSize is the largest AdmissionReview a handler reads, the API
+// server limits objects to 3MiB.
+const maxRequestSize = 8 << 20
+
+// Request is an admission request for an object of Schema. Object and
+// OldObject are mapped to the external form of the schema, OldObject is only
+// set for updates.
+type Request struct {
+	*admissionv1.AdmissionRequest
+	Schema    *schemas.Schema
+	Object    data.Object
+	OldObject data.Object
+}
+
+// SchemaResolver returns the schema of the object of an admission request or
+// nil if the request isn't for a schema of the registry.
+type SchemaResolver func(s *schemas.Schemas, req *admissionv1.AdmissionRequest) *schemas.Schema
+
+// ResolveSchema returns the schema whose resource, see kubestore.SetResource,
+// is the resource of req. It falls back to the schema named after the kind
+// of the object.
+func ResolveSchema(s *schemas.Schemas, req *admissionv1.AdmissionRequest) *schemas.Schema {
+	for _, schema := range s.Schemas() {
+		r, err := kubestore.ResourceFor(schema)
+		if err != nil {
+			continue
+		}
+		gvr := r.GroupVersionResource()
+		if gvr.Group == req.Resource.Group && gvr.Version == req.Resource.Version && gvr.Resource == req.Resource.Resource {
+			return schema
+		}
+	}
+	return s.SchemasByID()[convert.LowerTitle(req.Kind.Kind)]
+}
+
+// decodeReview reads the AdmissionReview of req.
+func decodeReview(req *http.Request) (*admissionv1.AdmissionReview, error) {
+	if req.Method != http.MethodPost {
+		return nil, fmt.Errorf("method %s is not allowed", req.Method)
+	}
+
+	body, err := io.ReadAll(io.LimitReader(req.Body, maxRequestSize))
+	if err != nil {
+		return nil, err
+	}
+	review := &admissionv1.AdmissionReview{}
+	if err := json.Unmarshal(body, review); err != nil {
+		return nil, fmt.Errorf("failed to decode AdmissionReview: %w", err)
+	}
+	if review.Request == nil {
+		return nil, fmt.Errorf("AdmissionReview has no request")
+	}
+	return review, nil
+}
+
+func resolve(s *schemas.Schemas, resolver SchemaResolver, req *admissionv1.AdmissionRequest) *schemas.Schema {
+	if resolver == nil {
+		resolver = ResolveSchema
+	}
+	return resolver(s, req)
+}
+
+// newRequest returns req with the objects mapped to the external form of
+// schema.
+func newRequest(ctx context.Context, schema *schemas.Schema, req *admissionv1.AdmissionRequest) (*Request, error) {
+	var (
+		result = &Request{
+			AdmissionRequest: req,
+			Schema:           schema,
+		}
+		err error
+	)
+	if result.Object, err = decodeObject(ctx, schema, req.Object.Raw); err != nil {
+		return nil, fmt.Errorf("failed to decode object: %w", err)
+	}
+	if result.OldObject, err = decodeObject(ctx, schema, req.OldObject.Raw); err != nil {
+		return nil, fmt.Errorf("failed to decode old object: %w", err)
+	}
+	return result, nil
+}
+
+func decodeObject(ctx context.Context, schema *schemas.Schema, raw []byte) (data.Object, error) {
+	if len(raw) == 0 {
+		return nil, nil
+	}
+	obj, err := data.FromJSON(raw)
+	if err != nil {
+		return nil, err
+	}
+	if schema.Mapper != nil {
+		schemas.FromInternalContext(ctx, schema.Mapper, obj)
+	}
+	return obj, nil
+}
+
+// writeResponse writes review with response and the UID of the request.
+func writeResponse(rw http.ResponseWriter, review *admissionv1.AdmissionReview, response *admissionv1.AdmissionResponse) {
+	response.UID = review.Request.UID
+	result := admissionv1.AdmissionReview{
+		TypeMeta: review.TypeMeta,
+		Response: response,
+	}
+	if result.APIVersion == "" {
+		result.TypeMeta = metav1.TypeMeta{
+			APIVersion: admissionv1.SchemeGroupVersion.String(),
+			Kind:       "AdmissionReview",
+		}
+	}
+
+	b, err := json.Marshal(result)
+	if err != nil {
+		http.Error(rw, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	rw.Header().Set("Content-Type", "application/json")
+	_, _ = rw.Write(b)
+}
+
+func allowed() *admissionv1.AdmissionResponse {
+	return &admissionv1.AdmissionResponse{Allowed: true}
+}
+
+func denied(err *apierrors.StatusError) *admissionv1.AdmissionResponse {
+	status := err.Status()
+	return &admissionv1.AdmissionResponse{
+		Allowed: false,
+		Result:  &status,
+	}
+}
@@ -0,0 +1,103 @@
+package admission
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"slices"
+	"sync"
+
+	schemas "github.com/acorn-io/schemer"
+	"github.com/acorn-io/schemer/data"
+	admissionv1 "k8s.io/api/admission/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+)
+
+// MutatingHandler is an http.Handler for mutating admission webhooks. It sets
+// the defaults of the schema on created and updated objects, runs the mappers
+// added for the schema and responds with a JSON Patch of the changes.
+// Requests for objects without a schema are allowed unchanged.
+type MutatingHandler struct {
+	schemas *schemas.Schemas
+	// Resolve returns the schema of a request, it defaults to ResolveSchema.
+	Resolve SchemaResolver
+
+	lock    sync.RWMutex
+	mappers map[string]schemas.Mappers
+}
+
+func NewMutatingHandler(s *schemas.Schemas) *MutatingHandler {
+	return &MutatingHandler{
+		schemas: s,
+		mappers: map[string]schemas.Mappers{},
+	}
+}
+
+// AddMapper adds a mapper for the objects of the schema with the given ID.
+// The objects are in the stored form, so the mappers are applied with
+// ToInternal after the defaults are set. Like the mappers of a schema, the
+// last added mapper runs first.
+func (h *MutatingHandler) AddMapper(schemaID string, mapper schemas.Mapper) *MutatingHandler {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+	h.mappers[schemaID] = append(h.mappers[schemaID], mapper)
+	return h
+}
+
+func (h *MutatingHandler) ServeHTTP(rw http.ResponseWriter, req *http.Request) {
+	review, err := decodeReview(req)
+	if err != nil {
+		http.Error(rw, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	schema := resolve(h.schemas, h.Resolve, review.Request)
+	if schema == nil || len(review.Request.Object.Raw) == 0 ||
+		(review.Request.Operation != admissionv1.Create && review.Request.Operation != admissionv1.Update) {
+		writeResponse(rw, review, allowed())
+		return
+	}
+	writeResponse(rw, review, h.mutate(req.Context(), schema, review.Request))
+}
+
+func (h *MutatingHandler) mutate(ctx context.Context, schema *schemas.Schema, req *admissionv1.AdmissionRequest) *admissionv1.AdmissionResponse {
+	original, err := data.FromJSON(req.Object.Raw)
+	if err != nil {
+		return denied(apierrors.NewBadRequest("failed to decode object: " + err.Error()))
+	}
+	data.Normalize(original)
+
+	// the object is in the stored form, which the internal schema describes
+	defaults := schema
+	if schema.InternalSchema != nil {
+		defaults = schema.InternalSchema
+	}
+	obj := data.Object(defaults.Defaulter(h.schemas).Default(original.DeepCopy()))
+
+	h.lock.RLock()
+	mappers := slices.Clone(h.mappers[schema.ID])
+	h.lock.RUnlock()
+	if err := mappers.ToInternalContext(ctx, obj); err != nil {
+		return denied(apierrors.NewBadRequest(err.Error()))
+	}
+
+	data.Normalize(obj)
+	ops, err := data.Diff(original, obj)
+	if err != nil {
+		return denied(apierrors.NewInternalError(err))
+	}
+	if len(ops) == 0 {
+		return allowed()
+	}
+	patch, err := json.Marshal(ops)
+	if err != nil {
+		return denied(apierrors.NewInternalError(err))
+	}
+
+	patchType := admissionv1.PatchTypeJSONPatch
+	return &admissionv1.AdmissionResponse{
+		Allowed:   true,
+		Patch:     patch,
+		PatchType: &patchType,
+	}
+}
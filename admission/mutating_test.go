@@ -0,0 +1,64 @@
+package admission
+
+import (
+	"encoding/json"
+	"testing"
+
+	schemas "github.com/acorn-io/schemer"
+	"github.com/acorn-io/schemer/data"
+	admissionv1 "k8s.io/api/admission/v1"
+)
+
+type mutatingSpec struct {
+	Replicas int    `json:"replicas" wrangler:"default=1"`
+	Image    string `json:"image"`
+}
+
+type mutatingApp struct {
+	Spec mutatingSpec `json:"spec"`
+}
+
+type labelMapper struct{}
+
+func (labelMapper) FromInternal(data.Object) {}
+
+func (labelMapper) ToInternal(obj data.Object) error {
+	obj.SetNested("true", "metadata", "labels", "defaulted")
+	return nil
+}
+
+func (labelMapper) ModifySchema(*schemas.Schema, *schemas.Schemas) error { return nil }
+
+func TestMutatingHandler(t *testing.T) {
+	s := schemas.EmptySchemas()
+	if _, err := s.Import(mutatingApp{}); err != nil {
+		t.Fatal(err)
+	}
+	handler := NewMutatingHandler(s)
+	handler.Resolve = func(s *schemas.Schemas, _ *admissionv1.AdmissionRequest) *schemas.Schema {
+		return s.Schema("mutatingApp")
+	}
+	handler.AddMapper("mutatingApp", labelMapper{})
+
+	resp := review(t, handler, `{"spec":{"image":"nginx"}}`)
+	if !resp.Allowed || resp.PatchType == nil {
+		t.Fatalf("expected allowed response with patch, got %v", resp)
+	}
+
+	var ops []data.PatchOp
+	if err := json.Unmarshal(resp.Patch, &ops); err != nil {
+		t.Fatal(err)
+	}
+	obj, err := data.Apply(data.Object{"spec": map[string]interface{}{"image": "nginx"}}, ops)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if obj.Int64("spec", "replicas") != 1 || obj.String("metadata", "labels", "defaulted") != "true" {
+		t.Fatalf("unexpected patched object %v", obj)
+	}
+
+	resp = review(t, handler, `{"metadata":{"labels":{"defaulted":"true"}},"spec":{"replicas":2}}`)
+	if !resp.Allowed || resp.Patch != nil {
+		t.Fatalf("expected no patch, got %s", resp.Patch)
+	}
+}
@@ -0,0 +1,87 @@
+package admission
+
+import (
+	"context"
+	"net/http"
+	"slices"
+	"sync"
+
+	schemas "github.com/acorn-io/schemer"
+	"github.com/acorn-io/schemer/validation"
+	admissionv1 "k8s.io/api/admission/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+// Validator checks an admission request in addition to the schema validation
+// and returns the invalid fields.
+type Validator func(ctx context.Context, req *Request) field.ErrorList
+
+// ValidatingHandler is an http.Handler for validating admission webhooks. It
+// validates created and updated objects against their schema with
+// validation.ValidateObject and runs the validators added for the schema.
+// Requests for objects without a schema are allowed.
+type ValidatingHandler struct {
+	schemas *schemas.Schemas
+	// Resolve returns the schema of a request, it defaults to ResolveSchema.
+	Resolve SchemaResolver
+
+	lock       sync.RWMutex
+	validators map[string][]Validator
+}
+
+func NewValidatingHandler(schemas *schemas.Schemas) *ValidatingHandler {
+	return &ValidatingHandler{
+		schemas:    schemas,
+		validators: map[string][]Validator{},
+	}
+}
+
+// AddValidator adds a validator for the objects of the schema with the given
+// ID. Validators are also called for deletes, Object is nil then.
+func (h *ValidatingHandler) AddValidator(schemaID string, validator Validator) *ValidatingHandler {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+	h.validators[schemaID] = append(h.validators[schemaID], validator)
+	return h
+}
+
+func (h *ValidatingHandler) ServeHTTP(rw http.ResponseWriter, req *http.Request) {
+	review, err := decodeReview(req)
+	if err != nil {
+		http.Error(rw, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	schema := resolve(h.schemas, h.Resolve, review.Request)
+	if schema == nil {
+		writeResponse(rw, review, allowed())
+		return
+	}
+	request, err := newRequest(req.Context(), schema, review.Request)
+	if err != nil {
+		writeResponse(rw, review, denied(apierrors.NewBadRequest(err.Error())))
+		return
+	}
+	writeResponse(rw, review, h.validate(req.Context(), request))
+}
+
+func (h *ValidatingHandler) validate(ctx context.Context, req *Request) *admissionv1.AdmissionResponse {
+	var errs field.ErrorList
+	if req.Object != nil && (req.Operation == admissionv1.Create || req.Operation == admissionv1.Update) {
+		errs = validation.ValidateObject(h.schemas, req.Schema, req.Object)
+	}
+
+	h.lock.RLock()
+	validators := slices.Clone(h.validators[req.Schema.ID])
+	h.lock.RUnlock()
+	for _, validator := range validators {
+		errs = append(errs, validator(ctx, req)...)
+	}
+
+	if len(errs) == 0 {
+		return allowed()
+	}
+	return denied(apierrors.NewInvalid(schema.GroupKind{Group: req.Kind.Group, Kind: req.Kind.Kind}, req.Name, errs))
+}
@@ -0,0 +1,86 @@
+package admission
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	schemas "github.com/acorn-io/schemer"
+	admissionv1 "k8s.io/api/admission/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+type admissionSpec struct {
+	Replicas int    `json:"replicas" wrangler:"min=1"`
+	Image    string `json:"image" wrangler:"required"`
+}
+
+type admissionApp struct {
+	Spec admissionSpec `json:"spec"`
+}
+
+func review(t *testing.T, handler http.Handler, obj string) *admissionv1.AdmissionResponse {
+	body, err := json.Marshal(admissionv1.AdmissionReview{
+		TypeMeta: metav1.TypeMeta{APIVersion: "admission.k8s.io/v1", Kind: "AdmissionReview"},
+		Request: &admissionv1.AdmissionRequest{
+			UID:       "1234",
+			Kind:      metav1.GroupVersionKind{Group: "example.io", Version: "v1", Kind: "AdmissionApp"},
+			Name:      "test",
+			Operation: admissionv1.Create,
+			Object:    runtime.RawExtension{Raw: []byte(obj)},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/validate", bytes.NewReader(body)))
+
+	var result admissionv1.AdmissionReview
+	if err := json.Unmarshal(rec.Body.Bytes(), &result); err != nil {
+		t.Fatal(err, rec.Body.String())
+	}
+	if result.Response == nil || result.Response.UID != "1234" {
+		t.Fatalf("unexpected response %s", rec.Body.String())
+	}
+	return result.Response
+}
+
+func TestValidatingHandler(t *testing.T) {
+	s := schemas.EmptySchemas()
+	if _, err := s.Import(admissionApp{}); err != nil {
+		t.Fatal(err)
+	}
+	handler := NewValidatingHandler(s).AddValidator("admissionApp", func(ctx context.Context, req *Request) field.ErrorList {
+		if req.Object.String("spec", "image") == "latest" {
+			return field.ErrorList{field.Forbidden(field.NewPath("spec", "image"), "latest is not allowed")}
+		}
+		return nil
+	})
+
+	if resp := review(t, handler, `{"spec":{"replicas":2,"image":"nginx"}}`); !resp.Allowed {
+		t.Fatalf("expected valid object to be allowed, got %v", resp.Result)
+	}
+
+	resp := review(t, handler, `{"spec":{"replicas":0}}`)
+	if resp.Allowed {
+		t.Fatal("expected invalid object to be denied")
+	}
+	for _, expected := range []string{"spec.replicas", "spec.image"} {
+		if !strings.Contains(resp.Result.Message, expected) {
+			t.Errorf("expected %s in %q", expected, resp.Result.Message)
+		}
+	}
+
+	resp = review(t, handler, `{"spec":{"replicas":1,"image":"latest"}}`)
+	if resp.Allowed || !strings.Contains(resp.Result.Message, "latest is not allowed") {
+		t.Fatalf("expected custom validator to deny, got %v", resp.Result)
+	}
+}
@@ -0,0 +1,139 @@
+package admission
+
+import (
+	"fmt"
+	"strings"
+
+	schemas "github.com/acorn-io/schemer"
+	"github.com/acorn-io/schemer/kubestore"
+	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// WebhookOptions configure the generated webhook configurations.
+type WebhookOptions struct {
+	// Name is the name of the configuration and, with a ".webhook" suffix if
+	// it has no dots, of the webhook.
+	Name string
+	// ClientConfig is the service or URL of the handler.
+	ClientConfig admissionregistrationv1.WebhookClientConfig
+	// FailurePolicy defaults to Fail.
+	FailurePolicy *admissionregistrationv1.FailurePolicyType
+	// Operations default to create and update.
+	Operations []admissionregistrationv1.OperationType
+}
+
+// ValidatingWebhookConfiguration returns the configuration that sends the
+// admission requests for the resources of the schemas to a ValidatingHandler.
+// The resources are read from the attributes of the schemas, see
+// kubestore.SetResource.
+func ValidatingWebhookConfiguration(opts WebhookOptions, schemas ...*schemas.Schema) (*admissionregistrationv1.ValidatingWebhookConfiguration, error) {
+	rules, err := rules(opts, schemas)
+	if err != nil {
+		return nil, err
+	}
+
+	sideEffects := admissionregistrationv1.SideEffectClassNone
+	return &admissionregistrationv1.ValidatingWebhookConfiguration{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: admissionregistrationv1.SchemeGroupVersion.String(),
+			Kind:       "ValidatingWebhookConfiguration",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name: opts.Name,
+		},
+		Webhooks: []admissionregistrationv1.ValidatingWebhook{
+			{
+				Name:                    webhookName(opts.Name),
+				ClientConfig:            opts.ClientConfig,
+				Rules:                   rules,
+				FailurePolicy:           failurePolicy(opts),
+				SideEffects:             &sideEffects,
+				AdmissionReviewVersions: []string{"v1"},
+			},
+		},
+	}, nil
+}
+
+// MutatingWebhookConfiguration returns the configuration that sends the
+// admission requests for the resources of the schemas to a MutatingHandler,
+// see ValidatingWebhookConfiguration.
+func MutatingWebhookConfiguration(opts WebhookOptions, schemas ...*schemas.Schema) (*admissionregistrationv1.MutatingWebhookConfiguration, error) {
+	rules, err := rules(opts, schemas)
+	if err != nil {
+		return nil, err
+	}
+
+	sideEffects := admissionregistrationv1.SideEffectClassNone
+	reinvocationPolicy := admissionregistrationv1.IfNeededReinvocationPolicy
+	return &admissionregistrationv1.MutatingWebhookConfiguration{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: admissionregistrationv1.SchemeGroupVersion.String(),
+			Kind:       "MutatingWebhookConfiguration",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name: opts.Name,
+		},
+		Webhooks: []admissionregistrationv1.MutatingWebhook{
+			{
+				Name:                    webhookName(opts.Name),
+				ClientConfig:            opts.ClientConfig,
+				Rules:                   rules,
+				FailurePolicy:           failurePolicy(opts),
+				SideEffects:             &sideEffects,
+				ReinvocationPolicy:      &reinvocationPolicy,
+				AdmissionReviewVersions: []string{"v1"},
+			},
+		},
+	}, nil
+}
+
+func rules(opts WebhookOptions, schemas []*schemas.Schema) ([]admissionregistrationv1.RuleWithOperations, error) {
+	operations := opts.Operations
+	if len(operations) == 0 {
+		operations = []admissionregistrationv1.OperationType{
+			admissionregistrationv1.Create,
+			admissionregistrationv1.Update,
+		}
+	}
+
+	var result []admissionregistrationv1.RuleWithOperations
+	for _, schema := range schemas {
+		r, err := kubestore.ResourceFor(schema)
+		if err != nil {
+			return nil, err
+		}
+		scope := admissionregistrationv1.ClusterScope
+		if r.Namespaced {
+			scope = admissionregistrationv1.NamespacedScope
+		}
+		result = append(result, admissionregistrationv1.RuleWithOperations{
+			Operations: operations,
+			Rule: admissionregistrationv1.Rule{
+				APIGroups:   []string{r.GroupVersionKind.Group},
+				APIVersions: []string{r.GroupVersionKind.Version},
+				Resources:   []string{r.Resource},
+				Scope:       &scope,
+			},
+		})
+	}
+	if len(result) == 0 {
+		return nil, fmt.Errorf("webhook %s has no schemas", opts.Name)
+	}
+	return result, nil
+}
+
+func webhookName(name string) string {
+	if strings.Contains(name, ".") {
+		return name
+	}
+	return name + ".webhook"
+}
+
+func failurePolicy(opts WebhookOptions) *admissionregistrationv1.FailurePolicyType {
+	if opts.FailurePolicy != nil {
+		return opts.FailurePolicy
+	}
+	policy := admissionregistrationv1.Fail
+	return &policy
+}
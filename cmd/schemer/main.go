@@ -0,0 +1,278 @@
+// Command schemer generates CRDs, OpenAPI documents and JSON Schemas from Go
+// types at build time.
+//
+//	schemer -format crd -group example.io github.com/example/api/v1.App
+//	schemer -config schemer.yaml
+//
+// Types are given as import path and type name. schemer writes a small
+// program that imports the packages and runs it with "go run" in the current
+// module, so the packages are resolved like in a normal build.
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"go/format"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"text/template"
+
+	"github.com/acorn-io/schemer/generate"
+	"sigs.k8s.io/yaml"
+)
+
+// Config is the format of the -config file.
+type Config struct {
+	Format  generate.Format `json:"format,omitempty"`
+	Output  string          `json:"output,omitempty"`
+	Group   string          `json:"group,omitempty"`
+	Version string          `json:"version,omitempty"`
+	Types   []TypeConfig    `json:"types,omitempty"`
+}
+
+// TypeConfig is a type to generate a document for, Type is the import path
+// and the name of the type, like "github.com/example/api/v1.App".
+type TypeConfig struct {
+	Type       string `json:"type"`
+	Group      string `json:"group,omitempty"`
+	Version    string `json:"version,omitempty"`
+	Kind       string `json:"kind,omitempty"`
+	Plural     string `json:"plural,omitempty"`
+	Namespaced *bool  `json:"namespaced,omitempty"`
+	Status     bool   `json:"status,omitempty"`
+}
+
+func main() {
+	if err := run(os.Args[1:], os.Stdout); err != nil {
+		fmt.Fprintln(os.Stderr, "schemer:", err)
+		os.Exit(1)
+	}
+}
+
+func run(args []string, stdout io.Writer) error {
+	cfg, err := parseArgs(args)
+	if err != nil {
+		return err
+	}
+
+	src, err := program(cfg)
+	if err != nil {
+		return err
+	}
+
+	out := stdout
+	if cfg.Output != "" {
+		if err := os.MkdirAll(filepath.Dir(cfg.Output), 0755); err != nil {
+			return err
+		}
+		f, err := os.Create(cfg.Output)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		out = f
+	}
+	return goRun(src, out)
+}
+
+// parseArgs returns the config of the -config file merged with the flags and
+// the types given as arguments.
+func parseArgs(args []string) (Config, error) {
+	var (
+		cfg        Config
+		configFile string
+		clusterScoped,
+		status bool
+	)
+
+	flags := flag.NewFlagSet("schemer", flag.ContinueOnError)
+	flags.Usage = func() {
+		fmt.Fprintln(flags.Output(), "usage: schemer [flags] [import/path.Type...]")
+		flags.PrintDefaults()
+	}
+	flags.StringVar(&configFile, "config", "", "YAML file with the types and options to generate")
+	flags.StringVar((*string)(&cfg.Format), "format", "", "output format: crd, openapi or jsonschema (default crd)")
+	flags.StringVar(&cfg.Output, "o", "", "file to write to instead of stdout")
+	flags.StringVar(&cfg.Group, "group", "", "API group of the types")
+	flags.StringVar(&cfg.Version, "version", "", "API version of the types")
+	flags.BoolVar(&clusterScoped, "cluster-scoped", false, "generate cluster scoped CRDs")
+	flags.BoolVar(&status, "status", false, "enable the status subresource of CRDs")
+	if err := flags.Parse(args); err != nil {
+		return cfg, err
+	}
+
+	if configFile != "" {
+		fileCfg, err := readConfig(configFile)
+		if err != nil {
+			return cfg, err
+		}
+		cfg = mergeConfig(fileCfg, cfg)
+	}
+	for _, arg := range flags.Args() {
+		namespaced := !clusterScoped
+		cfg.Types = append(cfg.Types, TypeConfig{
+			Type:       arg,
+			Namespaced: &namespaced,
+			Status:     status,
+		})
+	}
+	if len(cfg.Types) == 0 {
+		flags.Usage()
+		return cfg, fmt.Errorf("no types given")
+	}
+	if cfg.Format == "" {
+		cfg.Format = generate.FormatCRD
+	}
+	return cfg, nil
+}
+
+func readConfig(file string) (Config, error) {
+	var cfg Config
+	b, err := os.ReadFile(file)
+	if err != nil {
+		return cfg, err
+	}
+	if err := yaml.UnmarshalStrict(b, &cfg); err != nil {
+		return cfg, fmt.Errorf("failed to read %s: %w", file, err)
+	}
+	return cfg, nil
+}
+
+// mergeConfig returns the file config with the options set by flags.
+func mergeConfig(file, flags Config) Config {
+	if flags.Format != "" {
+		file.Format = flags.Format
+	}
+	if flags.Output != "" {
+		file.Output = flags.Output
+	}
+	if flags.Group != "" {
+		file.Group = flags.Group
+	}
+	if flags.Version != "" {
+		file.Version = flags.Version
+	}
+	return file
+}
+
+type programType struct {
+	Alias      string
+	Name       string
+	Group      string
+	Version    string
+	Kind       string
+	Plural     string
+	Namespaced bool
+	Status     bool
+}
+
+type programData struct {
+	Format  generate.Format
+	Imports map[string]string
+	Types   []programType
+}
+
+var programTemplate = template.Must(template.New("main").Parse(`// Code generated by schemer. DO NOT EDIT.
+
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/acorn-io/schemer/generate"
+{{- range $path, $alias := .Imports }}
+	{{ $alias }} {{ printf "%q" $path }}
+{{- end }}
+)
+
+func main() {
+	err := generate.Write(os.Stdout, {{ printf "%q" .Format }}, []generate.Type{
+{{- range .Types }}
+		{
+			Object:     {{ .Alias }}.{{ .Name }}{},
+			Group:      {{ printf "%q" .Group }},
+			Version:    {{ printf "%q" .Version }},
+			Kind:       {{ printf "%q" .Kind }},
+			Plural:     {{ printf "%q" .Plural }},
+			Namespaced: {{ .Namespaced }},
+			Status:     {{ .Status }},
+		},
+{{- end }}
+	})
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
+`))
+
+// program returns the source of the program that writes the documents.
+func program(cfg Config) ([]byte, error) {
+	data := programData{
+		Format:  cfg.Format,
+		Imports: map[string]string{},
+	}
+	for _, t := range cfg.Types {
+		i := strings.LastIndex(t.Type, ".")
+		if i <= 0 || i == len(t.Type)-1 || strings.Contains(t.Type[i:], "/") {
+			return nil, fmt.Errorf("invalid type %q, expected import/path.Type", t.Type)
+		}
+		path, name := t.Type[:i], t.Type[i+1:]
+
+		alias, ok := data.Imports[path]
+		if !ok {
+			alias = fmt.Sprintf("p%d", len(data.Imports))
+			data.Imports[path] = alias
+		}
+		pt := programType{
+			Alias:      alias,
+			Name:       name,
+			Group:      t.Group,
+			Version:    t.Version,
+			Kind:       t.Kind,
+			Plural:     t.Plural,
+			Namespaced: t.Namespaced == nil || *t.Namespaced,
+			Status:     t.Status,
+		}
+		if pt.Group == "" {
+			pt.Group = cfg.Group
+		}
+		if pt.Version == "" {
+			pt.Version = cfg.Version
+		}
+		data.Types = append(data.Types, pt)
+	}
+
+	buf := &bytes.Buffer{}
+	if err := programTemplate.Execute(buf, data); err != nil {
+		return nil, err
+	}
+	return format.Source(buf.Bytes())
+}
+
+// goRun runs the program in a temporary directory of the current module so
+// the imports resolve against its go.mod.
+func goRun(src []byte, out io.Writer) error {
+	dir, err := os.MkdirTemp(".", ".schemer-")
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(dir)
+
+	if err := os.WriteFile(filepath.Join(dir, "main.go"), src, 0644); err != nil {
+		return err
+	}
+
+	cmd := exec.Command("go", "run", "./"+filepath.Base(dir))
+	cmd.Stdout = out
+	cmd.Stderr = os.Stderr
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("failed to run generator: %w", err)
+	}
+	return nil
+}
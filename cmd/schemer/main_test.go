@@ -0,0 +1,133 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/acorn-io/schemer/generate"
+)
+
+func writeConfig(t *testing.T, content string) string {
+	t.Helper()
+	file := filepath.Join(t.TempDir(), "schemer.yaml")
+	if err := os.WriteFile(file, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+	return file
+}
+
+func TestParseArgs(t *testing.T) {
+	file := writeConfig(t, `format: openapi
+output: api.yaml
+group: example.io
+version: v1
+types:
+- type: github.com/example/api/v1.App
+  kind: Application
+`)
+
+	tests := []struct {
+		name     string
+		args     []string
+		expected Config
+	}{
+		{
+			name: "config file",
+			args: []string{"-config", file},
+			expected: Config{
+				Format:  generate.FormatOpenAPI,
+				Output:  "api.yaml",
+				Group:   "example.io",
+				Version: "v1",
+				Types:   []TypeConfig{{Type: "github.com/example/api/v1.App", Kind: "Application"}},
+			},
+		},
+		{
+			name: "flags override the config file",
+			args: []string{"-config", file, "-format", "jsonschema", "-o", "schema.json", "-group", "other.io", "-cluster-scoped", "-status", "github.com/example/api/v1.Other"},
+			expected: Config{
+				Format:  generate.FormatJSONSchema,
+				Output:  "schema.json",
+				Group:   "other.io",
+				Version: "v1",
+				Types: []TypeConfig{
+					{Type: "github.com/example/api/v1.App", Kind: "Application"},
+					{Type: "github.com/example/api/v1.Other", Namespaced: new(bool), Status: true},
+				},
+			},
+		},
+		{
+			name: "flags only",
+			args: []string{"-version", "v2", "github.com/example/api/v2.App"},
+			expected: Config{
+				Format:  generate.FormatCRD,
+				Version: "v2",
+				Types:   []TypeConfig{{Type: "github.com/example/api/v2.App", Namespaced: &[]bool{true}[0]}},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			cfg, err := parseArgs(test.args)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(cfg, test.expected) {
+				t.Fatalf("expected %+v, got %+v", test.expected, cfg)
+			}
+		})
+	}
+}
+
+func TestParseArgsErrors(t *testing.T) {
+	unknown := writeConfig(t, "types:\n- type: github.com/example/api/v1.App\n  namespace: true\n")
+	if _, err := parseArgs([]string{"-config", unknown}); err == nil || !strings.Contains(err.Error(), `unknown field "namespace"`) {
+		t.Fatalf("expected an unknown field error, got %v", err)
+	}
+
+	empty := writeConfig(t, "group: example.io\n")
+	if _, err := parseArgs([]string{"-config", empty}); err == nil || err.Error() != "no types given" {
+		t.Fatalf("expected no types error, got %v", err)
+	}
+}
+
+func TestProgram(t *testing.T) {
+	cfg := Config{
+		Format:  generate.FormatCRD,
+		Group:   "example.io",
+		Version: "v1",
+		Types: []TypeConfig{
+			{Type: "github.com/example/api/v1.App"},
+			{Type: "github.com/example/api/v1.Other", Group: "other.io", Namespaced: new(bool), Status: true},
+			{Type: "github.com/example/api/v2.App", Version: "v2"},
+		},
+	}
+	src, err := program(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, expected := range []string{
+		`p0 "github.com/example/api/v1"`,
+		`p1 "github.com/example/api/v2"`,
+		"Object:     p0.App{},\n\t\t\tGroup:      \"example.io\",\n\t\t\tVersion:    \"v1\",",
+		"Object:     p0.Other{},\n\t\t\tGroup:      \"other.io\",\n\t\t\tVersion:    \"v1\",",
+		"Namespaced: false,\n\t\t\tStatus:     true,",
+		"Object:     p1.App{},\n\t\t\tGroup:      \"example.io\",\n\t\t\tVersion:    \"v2\",",
+	} {
+		if !strings.Contains(string(src), expected) {
+			t.Errorf("expected %q in\n%s", expected, src)
+		}
+	}
+
+	for _, invalid := range []string{"App", "github.com/example/api/v1.", "github.com/example.io/api"} {
+		cfg.Types = []TypeConfig{{Type: invalid}}
+		if _, err := program(cfg); err == nil {
+			t.Errorf("expected an error for %s", invalid)
+		}
+	}
+}
@@ -11,9 +11,11 @@ import (
 	"time"
 
 	"github.com/acorn-io/schemer/data/convert"
+	"github.com/acorn-io/schemer/internal/tracing"
 	"github.com/acorn-io/schemer/name"
 	"github.com/acorn-io/schemer/openapi"
 	"github.com/sirupsen/logrus"
+	"go.opentelemetry.io/otel/attribute"
 	apiext "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions"
 	apiextv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 	apiextv1beta1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1beta1"
@@ -30,6 +32,16 @@ import (
 
 const CRDKind = "CustomResourceDefinition"
 
+// InstrumentationName is the name of the OpenTelemetry tracer used for the
+// spans of the Factory.
+const InstrumentationName = "github.com/acorn-io/schemer/crd"
+
+const (
+	AttributeCRDName = attribute.Key("schemer.crd.name")
+	AttributeGVK     = attribute.Key("schemer.crd.gvk")
+	AttributeCount   = attribute.Key("schemer.crd.count")
+)
+
 type ApplyFunc func(...runtime.Object) error
 
 type Factory struct {
@@ -445,11 +457,16 @@ func (f *Factory) BatchCreateCRDs(ctx context.Context, crds ...CRD) *Factory {
 	return f
 }
 
-func (f *Factory) CreateCRDs(ctx context.Context, crds ...CRD) (map[schema.GroupVersionKind]*apiextv1.CustomResourceDefinition, error) {
+func (f *Factory) CreateCRDs(ctx context.Context, crds ...CRD) (_ map[schema.GroupVersionKind]*apiextv1.CustomResourceDefinition, err error) {
 	if len(crds) == 0 {
 		return nil, nil
 	}
 
+	ctx, span := tracing.Start(ctx, InstrumentationName, "Factory.CreateCRDs", AttributeCount.Int(len(crds)))
+	defer func() {
+		tracing.End(span, err)
+	}()
+
 	if ok, err := f.ensureAccess(ctx); err != nil {
 		return nil, err
 	} else if !ok {
@@ -490,7 +507,12 @@ func (f *Factory) CreateCRDs(ctx context.Context, crds ...CRD) (map[schema.Group
 	return crdStatus, nil
 }
 
-func (f *Factory) waitCRD(ctx context.Context, crdName string, gvk schema.GroupVersionKind, crdStatus map[schema.GroupVersionKind]*apiextv1.CustomResourceDefinition) error {
+func (f *Factory) waitCRD(ctx context.Context, crdName string, gvk schema.GroupVersionKind, crdStatus map[schema.GroupVersionKind]*apiextv1.CustomResourceDefinition) (err error) {
+	ctx, span := tracing.Start(ctx, InstrumentationName, "Factory.waitCRD", AttributeCRDName.String(crdName), AttributeGVK.String(gvk.String()))
+	defer func() {
+		tracing.End(span, err)
+	}()
+
 	logrus.Infof("Waiting for CRD %s to become available", crdName)
 	defer logrus.Infof("Done waiting for CRD %s to become available", crdName)
 
@@ -524,7 +546,12 @@ func (f *Factory) waitCRD(ctx context.Context, crdName string, gvk schema.GroupV
 	})
 }
 
-func (f *Factory) createCRD(ctx context.Context, crdDef CRD, ready map[string]*apiextv1.CustomResourceDefinition) (*apiextv1.CustomResourceDefinition, error) {
+func (f *Factory) createCRD(ctx context.Context, crdDef CRD, ready map[string]*apiextv1.CustomResourceDefinition) (_ *apiextv1.CustomResourceDefinition, err error) {
+	ctx, span := tracing.Start(ctx, InstrumentationName, "Factory.createCRD", AttributeGVK.String(crdDef.GVK.String()))
+	defer func() {
+		tracing.End(span, err)
+	}()
+
 	crd, err := crdDef.ToCustomResourceDefinition()
 	if err != nil {
 		return nil, err
@@ -534,6 +561,7 @@ func (f *Factory) createCRD(ctx context.Context, crdDef CRD, ready map[string]*a
 	if err != nil {
 		return nil, err
 	}
+	span.SetAttributes(AttributeCRDName.String(meta.GetName()))
 
 	logrus.Infof("Applying CRD %s", meta.GetName())
 	if err := f.apply(crd); err != nil {
@@ -0,0 +1,84 @@
+package crd
+
+import (
+	"context"
+	"testing"
+
+	"go.opentelemetry.io/otel"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	apiextv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	"k8s.io/apiextensions-apiserver/pkg/client/clientset/clientset/fake"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+func TestCreateCRDsSpans(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+	previous := otel.GetTracerProvider()
+	otel.SetTracerProvider(provider)
+	defer otel.SetTracerProvider(previous)
+
+	client := fake.NewSimpleClientset()
+	f := &Factory{
+		CRDClient: client,
+		apply: func(objs ...runtime.Object) error {
+			for _, obj := range objs {
+				crd := &apiextv1.CustomResourceDefinition{}
+				if err := runtime.DefaultUnstructuredConverter.FromUnstructured(obj.(*unstructured.Unstructured).Object, crd); err != nil {
+					return err
+				}
+				crd.Status.Conditions = append(crd.Status.Conditions, apiextv1.CustomResourceDefinitionCondition{
+					Type:   apiextv1.Established,
+					Status: apiextv1.ConditionTrue,
+				})
+				if _, err := client.ApiextensionsV1().CustomResourceDefinitions().Create(context.Background(), crd, metav1.CreateOptions{}); err != nil {
+					return err
+				}
+			}
+			return nil
+		},
+	}
+
+	ctx, parent := provider.Tracer("test").Start(context.Background(), "request")
+	crds, err := f.CreateCRDs(ctx, NamespacedType("App.example.com/v1"))
+	parent.End()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(crds) != 1 {
+		t.Fatalf("expected one CRD, got %v", crds)
+	}
+
+	spans := map[string]sdktrace.ReadOnlySpan{}
+	for _, span := range recorder.Ended() {
+		spans[span.Name()] = span
+	}
+
+	create, ok := spans["Factory.CreateCRDs"]
+	if !ok {
+		t.Fatalf("missing span Factory.CreateCRDs, got %v", spans)
+	}
+	if create.Parent().SpanID() != parent.SpanContext().SpanID() {
+		t.Errorf("expected Factory.CreateCRDs to be a child of the request")
+	}
+
+	createCRD, ok := spans["Factory.createCRD"]
+	if !ok {
+		t.Fatalf("missing span Factory.createCRD, got %v", spans)
+	}
+	if createCRD.Parent().SpanID() != create.SpanContext().SpanID() {
+		t.Errorf("expected Factory.createCRD to be a child of Factory.CreateCRDs")
+	}
+	var name string
+	for _, attr := range createCRD.Attributes() {
+		if attr.Key == AttributeCRDName {
+			name = attr.Value.AsString()
+		}
+	}
+	if name != "apps.example.com" {
+		t.Errorf("expected CRD name apps.example.com, got %q", name)
+	}
+}
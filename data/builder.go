@@ -0,0 +1,55 @@
+package data
+
+import "fmt"
+
+// Builder constructs nested objects using the path syntax of SetPath. The
+// first error is kept and returned by Build.
+type Builder struct {
+	obj Object
+	err error
+}
+
+func New() *Builder {
+	return &Builder{
+		obj: Object{},
+	}
+}
+
+func (b *Builder) Set(path string, value interface{}) *Builder {
+	if b.err == nil {
+		b.err = b.obj.SetPath(path, value)
+	}
+	return b
+}
+
+// AddToSlice appends values to the slice at path, creating it if needed.
+func (b *Builder) AddToSlice(path string, values ...interface{}) *Builder {
+	if b.err != nil {
+		return b
+	}
+	b.err = Transform(b.obj, path, func(v interface{}) (interface{}, error) {
+		if v == nil {
+			return append([]interface{}{}, values...), nil
+		}
+		items, ok := v.([]interface{})
+		if !ok {
+			return nil, fmt.Errorf("value is a %T, not a slice", v)
+		}
+		return append(items, values...), nil
+	})
+	return b
+}
+
+// Build returns the object or the first error.
+func (b *Builder) Build() (Object, error) {
+	return b.obj, b.err
+}
+
+// Object returns the object and panics if any operation failed, it's meant
+// for tests and static objects.
+func (b *Builder) Object() Object {
+	if b.err != nil {
+		panic(b.err)
+	}
+	return b.obj
+}
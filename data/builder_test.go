@@ -0,0 +1,50 @@
+package data
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestBuilder(t *testing.T) {
+	obj, err := New().
+		Set("metadata.name", "app").
+		Set("spec.containers[0].image", "nginx").
+		AddToSlice("spec.containers[0].args", "-v").
+		AddToSlice("spec.containers[0].args", "-x", "-y").
+		Build()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := Object{
+		"metadata": map[string]interface{}{"name": "app"},
+		"spec": map[string]interface{}{
+			"containers": []interface{}{
+				map[string]interface{}{
+					"image": "nginx",
+					"args":  []interface{}{"-v", "-x", "-y"},
+				},
+			},
+		},
+	}
+	if !reflect.DeepEqual(obj, expected) {
+		t.Fatalf("expected %v, got %v", expected, obj)
+	}
+}
+
+func TestBuilderError(t *testing.T) {
+	b := New().
+		Set("name", "app").
+		AddToSlice("name", "x").
+		Set("other", "value")
+	if _, err := b.Build(); err == nil {
+		t.Fatal("expected error for appending to a string")
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Fatal("expected Object to panic")
+		}
+	}()
+	New().Set("a..b", "x").Object()
+}
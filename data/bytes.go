@@ -0,0 +1,30 @@
+package data
+
+import (
+	"encoding/base64"
+	"fmt"
+	"strings"
+
+	"github.com/acorn-io/schemer/data/convert"
+)
+
+// Bytes returns the nested value as bytes. Strings are decoded as base64, so
+// values set with SetBytes or decoded from JSON read back as the original
+// bytes.
+func (o Object) Bytes(names ...string) ([]byte, error) {
+	b, err := convert.ToBytes(GetValueN(o, names...))
+	if err != nil {
+		return nil, fmt.Errorf("field %s: %w", strings.Join(names, "."), err)
+	}
+	return b, nil
+}
+
+// SetBytes stores value base64 encoded at the nested keys, the same form
+// encoding/json produces for []byte.
+func (o Object) SetBytes(value []byte, names ...string) {
+	if value == nil {
+		o.SetNested(nil, names...)
+		return
+	}
+	o.SetNested(base64.StdEncoding.EncodeToString(value), names...)
+}
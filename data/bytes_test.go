@@ -0,0 +1,55 @@
+package data
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+)
+
+func TestBytes(t *testing.T) {
+	value := []byte{0, 1, 0xfe, 0xff, '\n'}
+
+	obj := Object{}
+	obj.SetBytes(value, "data", "key")
+	if b, err := obj.Bytes("data", "key"); err != nil || !bytes.Equal(b, value) {
+		t.Fatalf("expected %v, got %v %v", value, b, err)
+	}
+
+	// round trip through JSON, the way a Secret's data arrives
+	content, err := json.Marshal(struct {
+		Data map[string][]byte `json:"data"`
+	}{Data: map[string][]byte{"key": value}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	obj, err = FromJSON(content)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if b, err := obj.Bytes("data", "key"); err != nil || !bytes.Equal(b, value) {
+		t.Fatalf("expected %v, got %v %v", value, b, err)
+	}
+
+	obj.SetNested(value, "raw")
+	if b, err := obj.Bytes("raw"); err != nil || !bytes.Equal(b, value) {
+		t.Fatalf("expected []byte to be returned as is, got %v %v", b, err)
+	}
+
+	obj.SetBytes(nil, "data", "key")
+	if b, err := obj.Bytes("data", "key"); err != nil || b != nil {
+		t.Fatalf("expected nil, got %v %v", b, err)
+	}
+	if b, err := obj.Bytes("missing"); err != nil || b != nil {
+		t.Fatalf("expected nil, got %v %v", b, err)
+	}
+}
+
+func TestBytesInvalid(t *testing.T) {
+	obj := Object{"data": map[string]interface{}{"key": "not base64!", "num": int64(1)}}
+	if _, err := obj.Bytes("data", "key"); err == nil {
+		t.Fatal("expected error for invalid base64")
+	}
+	if _, err := obj.Bytes("data", "num"); err == nil {
+		t.Fatal("expected error for a number")
+	}
+}
@@ -0,0 +1,74 @@
+package convert
+
+import (
+	"strings"
+	"sync/atomic"
+)
+
+// BoolValues are the strings accepted as true and false, compared case
+// insensitively.
+type BoolValues struct {
+	True  []string
+	False []string
+}
+
+// DefaultBoolValues are the values ToBool accepts unless SetBoolValues is
+// called.
+var DefaultBoolValues = BoolValues{
+	True:  []string{"true", "t", "yes", "y"},
+	False: []string{"false", "f", "no", "n"},
+}
+
+var boolValues atomic.Pointer[BoolValues]
+
+// SetBoolValues replaces the values accepted by ToBool and ToBoolE for the
+// whole process, for example to also accept "on" and "enabled".
+func SetBoolValues(values BoolValues) {
+	boolValues.Store(&values)
+}
+
+func currentBoolValues() BoolValues {
+	if values := boolValues.Load(); values != nil {
+		return *values
+	}
+	return DefaultBoolValues
+}
+
+// ToBool converts value using the values, returning false for anything that
+// is not one of the true values.
+func (b BoolValues) ToBool(value interface{}) bool {
+	result, _ := b.ToBoolE(value)
+	return result
+}
+
+// ToBoolE converts value using the values and fails if value is neither one
+// of the true nor one of the false values. Empty values are false.
+func (b BoolValues) ToBoolE(value interface{}) (bool, error) {
+	value = Singular(value)
+
+	if v, ok := value.(bool); ok {
+		return v, nil
+	}
+
+	str := strings.TrimSpace(ToString(value))
+	if str == "" {
+		return false, nil
+	}
+	for _, v := range b.True {
+		if strings.EqualFold(str, v) {
+			return true, nil
+		}
+	}
+	for _, v := range b.False {
+		if strings.EqualFold(str, v) {
+			return false, nil
+		}
+	}
+	return false, conversionError(value, "bool", nil)
+}
+
+// ToBoolE converts value like ToBool but fails for values that are not
+// accepted as true or false.
+func ToBoolE(value interface{}) (bool, error) {
+	return currentBoolValues().ToBoolE(value)
+}
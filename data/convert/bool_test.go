@@ -0,0 +1,76 @@
+package convert
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestToBoolE(t *testing.T) {
+	tests := []struct {
+		value    interface{}
+		expected bool
+		err      bool
+	}{
+		{value: true, expected: true},
+		{value: "TRUE", expected: true},
+		{value: " y ", expected: true},
+		{value: []interface{}{"yes"}, expected: true},
+		{value: "f"},
+		{value: "No"},
+		{value: ""},
+		{value: nil},
+		{value: "on", err: true},
+		{value: "1", err: true},
+	}
+
+	for _, tt := range tests {
+		b, err := ToBoolE(tt.value)
+		if tt.err {
+			var convErr *ConversionError
+			if !errors.As(err, &convErr) {
+				t.Errorf("%#v: expected a conversion error, got %v", tt.value, err)
+			}
+		} else if err != nil || b != tt.expected {
+			t.Errorf("%#v: expected %v, got %v %v", tt.value, tt.expected, b, err)
+		}
+		if ToBool(tt.value) != tt.expected {
+			t.Errorf("%#v: expected ToBool to return %v", tt.value, tt.expected)
+		}
+	}
+}
+
+func TestBoolValues(t *testing.T) {
+	values := BoolValues{
+		True:  []string{"on", "enabled", "ja"},
+		False: []string{"off", "disabled", "nein"},
+	}
+
+	for value, expected := range map[string]bool{"ON": true, "Enabled": true, "ja": true, "off": false, "nein": false} {
+		if b, err := values.ToBoolE(value); err != nil || b != expected {
+			t.Errorf("%s: expected %v, got %v %v", value, expected, b, err)
+		}
+	}
+	if _, err := values.ToBoolE("yes"); err == nil {
+		t.Error("expected the default values to be replaced")
+	}
+	if values.ToBool("maybe") {
+		t.Error("expected unknown values to be false")
+	}
+
+	// the per call values don't change the package defaults
+	if _, err := ToBoolE("on"); err == nil {
+		t.Error("expected the default values to be unchanged")
+	}
+}
+
+func TestSetBoolValues(t *testing.T) {
+	defer SetBoolValues(DefaultBoolValues)
+
+	SetBoolValues(BoolValues{True: []string{"1"}, False: []string{"0"}})
+	if !ToBool("1") {
+		t.Fatal("expected 1 to be true")
+	}
+	if _, err := ToBoolE("yes"); err == nil {
+		t.Fatal("expected yes to be rejected")
+	}
+}
@@ -0,0 +1,191 @@
+package convert
+
+import (
+	"strings"
+	"unicode"
+)
+
+// DefaultAcronyms are the acronyms known to the package level case
+// conversion functions.
+var DefaultAcronyms = []string{
+	"API", "CIDR", "CPU", "CRD", "DNS", "HTTP", "HTTPS", "ID", "IP", "JSON",
+	"TCP", "TLS", "UDP", "UID", "URI", "URL", "UUID", "YAML",
+}
+
+// CaseConverter converts between camel, snake and kebab case. Words that are
+// known acronyms, or their plural with a lower case "s", are kept together
+// and written in upper case in camel case, like "podCIDRs" or "userID".
+type CaseConverter struct {
+	// acronyms maps the lower case form to the upper case form
+	acronyms map[string]string
+}
+
+// DefaultCaseConverter knows the DefaultAcronyms.
+var DefaultCaseConverter = NewCaseConverter(DefaultAcronyms...)
+
+// NewCaseConverter returns a CaseConverter for the given acronyms.
+func NewCaseConverter(acronyms ...string) *CaseConverter {
+	c := &CaseConverter{
+		acronyms: map[string]string{},
+	}
+	for _, acronym := range acronyms {
+		c.acronyms[strings.ToLower(acronym)] = strings.ToUpper(acronym)
+	}
+	return c
+}
+
+// ToCamel converts s to lower camel case, like "podCIDR".
+func ToCamel(s string) string {
+	return DefaultCaseConverter.ToCamel(s)
+}
+
+// ToPascal converts s to upper camel case, like "PodCIDR".
+func ToPascal(s string) string {
+	return DefaultCaseConverter.ToPascal(s)
+}
+
+// ToSnake converts s to snake case, like "pod_cidr".
+func ToSnake(s string) string {
+	return DefaultCaseConverter.ToSnake(s)
+}
+
+// ToKebab converts s to kebab case, like "pod-cidr".
+func ToKebab(s string) string {
+	return DefaultCaseConverter.ToKebab(s)
+}
+
+func (c *CaseConverter) ToCamel(s string) string {
+	var b strings.Builder
+	for i, word := range c.Words(s) {
+		if i == 0 {
+			b.WriteString(strings.ToLower(word))
+		} else {
+			b.WriteString(c.title(word))
+		}
+	}
+	return b.String()
+}
+
+func (c *CaseConverter) ToPascal(s string) string {
+	var b strings.Builder
+	for _, word := range c.Words(s) {
+		b.WriteString(c.title(word))
+	}
+	return b.String()
+}
+
+func (c *CaseConverter) ToSnake(s string) string {
+	return strings.ToLower(strings.Join(c.Words(s), "_"))
+}
+
+func (c *CaseConverter) ToKebab(s string) string {
+	return strings.ToLower(strings.Join(c.Words(s), "-"))
+}
+
+// Words splits s into words at separators like "_", "-", "." and spaces and
+// at changes of case.
+func (c *CaseConverter) Words(s string) []string {
+	var (
+		words []string
+		runes = []rune(s)
+		start = 0
+	)
+
+	add := func(end int) {
+		if end > start {
+			word := string(runes[start:end])
+			if parts, ok := c.splitAcronyms(word); ok {
+				words = append(words, parts...)
+			} else {
+				words = append(words, word)
+			}
+		}
+		start = end
+	}
+
+	for i := 0; i < len(runes); i++ {
+		r := runes[i]
+		if r == '_' || r == '-' || r == '.' || unicode.IsSpace(r) {
+			add(i)
+			start = i + 1
+			continue
+		}
+		if i == start || !unicode.IsUpper(r) {
+			continue
+		}
+
+		prev := runes[i-1]
+		if !unicode.IsUpper(prev) {
+			// "podCidr" splits before "C"
+			add(i)
+			continue
+		}
+		if i+1 < len(runes) && unicode.IsLower(runes[i+1]) {
+			// at the end of an upper case run like "HTTPServer" the last
+			// upper case letter starts the next word, unless the run is a
+			// known acronym followed by a plural "s" or a lower case word
+			run := string(runes[start : i+1])
+			if c.isAcronym(run) {
+				if c.isPlural(runes, i+1) {
+					add(i + 2)
+					i++
+				} else if _, ok := c.acronyms[strings.ToLower(run[:len(run)-1])]; !ok {
+					add(i + 1)
+				} else {
+					add(i)
+				}
+				continue
+			}
+			add(i)
+		}
+	}
+	add(len(runes))
+	return words
+}
+
+// splitAcronyms splits an upper case word made of several acronyms, like
+// "HTTPSURL".
+func (c *CaseConverter) splitAcronyms(word string) ([]string, bool) {
+	if word == "" {
+		return nil, true
+	}
+	if strings.ToUpper(word) != word || c.isAcronym(word) {
+		return nil, false
+	}
+	for i := len(word) - 1; i > 0; i-- {
+		if !c.isAcronym(word[:i]) {
+			continue
+		}
+		if c.isAcronym(word[i:]) {
+			return []string{word[:i], word[i:]}, true
+		}
+		if rest, ok := c.splitAcronyms(word[i:]); ok {
+			return append([]string{word[:i]}, rest...), true
+		}
+	}
+	return nil, false
+}
+
+// isPlural returns true if the rune at i is an "s" ending the word.
+func (c *CaseConverter) isPlural(runes []rune, i int) bool {
+	if runes[i] != 's' {
+		return false
+	}
+	return i+1 == len(runes) || !unicode.IsLower(runes[i+1])
+}
+
+func (c *CaseConverter) isAcronym(word string) bool {
+	_, ok := c.acronyms[strings.ToLower(word)]
+	return ok
+}
+
+func (c *CaseConverter) title(word string) string {
+	lower := strings.ToLower(word)
+	if acronym, ok := c.acronyms[lower]; ok {
+		return acronym
+	}
+	if acronym, ok := c.acronyms[strings.TrimSuffix(lower, "s")]; ok && strings.HasSuffix(lower, "s") {
+		return acronym + "s"
+	}
+	return Capitalize(lower)
+}
@@ -1,10 +1,11 @@
 package convert
 
 import (
-	"bytes"
+	"encoding/base64"
 	"encoding/json"
 	"errors"
 	"fmt"
+	"reflect"
 	"strconv"
 	"strings"
 	"time"
@@ -33,10 +34,16 @@ func ToStringNoTrim(value interface{}) string {
 	if t, ok := value.(time.Time); ok {
 		return t.Format(time.RFC3339)
 	}
+	if b, ok := value.([]byte); ok {
+		return string(b)
+	}
 	single := Singular(value)
 	if single == nil {
 		return ""
 	}
+	if str, ok := formatNumber(single); ok {
+		return str
+	}
 	return fmt.Sprint(single)
 }
 
@@ -44,6 +51,24 @@ func ToString(value interface{}) string {
 	return strings.TrimSpace(ToStringNoTrim(value))
 }
 
+// ToBytes returns []byte values as is and decodes strings as base64, the
+// way encoding/json represents byte slices.
+func ToBytes(value interface{}) ([]byte, error) {
+	switch t := value.(type) {
+	case nil:
+		return nil, nil
+	case []byte:
+		return t, nil
+	case string:
+		b, err := base64.StdEncoding.DecodeString(t)
+		if err != nil {
+			return nil, conversionError(value, "bytes", err)
+		}
+		return b, nil
+	}
+	return nil, conversionError(value, "bytes", nil)
+}
+
 func ToTimestamp(value interface{}) (int64, error) {
 	str := ToString(value)
 	if str == "" {
@@ -56,38 +81,16 @@ func ToTimestamp(value interface{}) (int64, error) {
 	return t.UnixNano() / 1000000, nil
 }
 
+// ToBool returns true if value is one of the true values set with
+// SetBoolValues, see DefaultBoolValues.
 func ToBool(value interface{}) bool {
-	value = Singular(value)
-
-	b, ok := value.(bool)
-	if ok {
-		return b
-	}
-
-	str := strings.ToLower(ToString(value))
-	return str == "true" || str == "t" || str == "yes" || str == "y"
+	return currentBoolValues().ToBool(value)
 }
 
+// ToNumber converts value to an int64, failing if it doesn't fit. Use
+// ToNumberWithOverflow to clamp values instead.
 func ToNumber(value interface{}) (int64, error) {
-	value = Singular(value)
-
-	i, ok := value.(int64)
-	if ok {
-		return i, nil
-	}
-	f, ok := value.(float64)
-	if ok {
-		return int64(f), nil
-	}
-	if n, ok := value.(json.Number); ok {
-		i, err := n.Int64()
-		if err == nil {
-			return i, nil
-		}
-		f, err := n.Float64()
-		return int64(f), err
-	}
-	return strconv.ParseInt(ToString(value), 10, 64)
+	return ToNumberWithOverflow(value, OverflowError)
 }
 
 func ToFloat(value interface{}) (float64, error) {
@@ -109,9 +112,16 @@ func ToFloat(value interface{}) (float64, error) {
 			return float64(i), nil
 		}
 		f, err := n.Float64()
-		return float64(f), err
+		if err != nil {
+			return 0, conversionError(value, "float64", errors.Unwrap(err))
+		}
+		return f, nil
 	}
-	return strconv.ParseFloat(ToString(value), 64)
+	f, err := strconv.ParseFloat(ToString(value), 64)
+	if err != nil {
+		return 0, conversionError(value, "float64", errors.Unwrap(err))
+	}
+	return f, nil
 }
 
 func Capitalize(s string) string {
@@ -167,25 +177,19 @@ func ToStringSlice(data interface{}) []string {
 	return nil
 }
 
+// EncodeToMap converts a struct to a map using reflection, producing the same
+// shape as a round trip through encoding/json with numbers as json.Number.
 func EncodeToMap(obj interface{}) (map[string]interface{}, error) {
-	if m, ok := obj.(map[string]interface{}); ok {
-		return m, nil
-	}
-
 	if unstr, ok := obj.(*unstructured.Unstructured); ok {
 		return unstr.Object, nil
 	}
-
-	b, err := json.Marshal(obj)
-	if err != nil {
-		return nil, err
-	}
-	result := map[string]interface{}{}
-	dec := json.NewDecoder(bytes.NewBuffer(b))
-	dec.UseNumber()
-	return result, dec.Decode(&result)
+	return EncodeToMapWithOptions(obj, EncodeOptions{})
 }
 
+// ToArgKey converts a field name to a command line flag, like
+// "--disable-open-api-validation" for "disableOpenAPIValidation". Unlike
+// ToKebab, a leading acronym is not split from the following word, so
+// existing flags like "--httpport" for "HTTPPort" keep their names.
 func ToArgKey(str string) string {
 	var (
 		result []rune
@@ -227,10 +231,51 @@ func ToArgKey(str string) string {
 	return "--" + string(result)
 }
 
+// ToObj converts data into into, which must be a pointer, like a round trip
+// through encoding/json. Maps, slices and scalars are set directly using
+// cached struct metadata and the converters added with Register are applied.
 func ToObj(data interface{}, into interface{}) error {
-	bytes, err := json.Marshal(data)
-	if err != nil {
-		return err
+	return ToObjWithOptions(data, into, DecodeOptions{})
+}
+
+// DecodeOptions configure Decode and ToObjWithOptions.
+type DecodeOptions struct {
+	// MaxDepth limits how deep data can be nested, DefaultMaxDepth if zero.
+	MaxDepth int
+	// DisallowUnknownFields fails if data has a field the target struct has
+	// no field for.
+	DisallowUnknownFields bool
+	// Defaults sets default values in the data before it's decoded, see
+	// schemer.Schema.Defaulter.
+	Defaults Defaulter
+}
+
+// Defaulter sets default values in generic data. Default must not modify
+// data but return a copy if it sets any values.
+type Defaulter interface {
+	Default(data map[string]interface{}) map[string]interface{}
+}
+
+// Decode converts data to a T like ToObj. Errors of nested fields are
+// ConversionErrors with the path of the field, like "spec.ports[0].port".
+func Decode[T any](data interface{}, opts DecodeOptions) (T, error) {
+	var result T
+	err := ToObjWithOptions(data, &result, opts)
+	return result, err
+}
+
+// ToObjWithOptions is ToObj with options.
+func ToObjWithOptions(data interface{}, into interface{}, opts DecodeOptions) error {
+	v := reflect.ValueOf(into)
+	if v.Kind() != reflect.Ptr || v.IsNil() {
+		return fmt.Errorf("can not decode into non-pointer %T", into)
+	}
+	if m, ok := data.(map[string]interface{}); ok && opts.Defaults != nil {
+		data = opts.Defaults.Default(m)
+	}
+	d := &decoder{
+		guard:  depthGuard{max: opts.MaxDepth},
+		strict: opts.DisallowUnknownFields,
 	}
-	return json.Unmarshal(bytes, into)
+	return d.decode(data, v.Elem())
 }
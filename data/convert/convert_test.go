@@ -1,6 +1,9 @@
 package convert
 
 import (
+	"encoding/json"
+	"errors"
+	"math"
 	"testing"
 )
 
@@ -37,6 +40,22 @@ func TestArgKey(t *testing.T) {
 			input:  "skipCRDs",
 			output: "--skip-crds",
 		},
+		{
+			input:  "HTTPPort",
+			output: "--httpport",
+		},
+		{
+			input:  "APIServer",
+			output: "--apiserver",
+		},
+		{
+			input:  "URLPath",
+			output: "--urlpath",
+		},
+		{
+			input:  "ABCDef",
+			output: "--abcdef",
+		},
 	}
 
 	for _, data := range data {
@@ -45,3 +64,66 @@ func TestArgKey(t *testing.T) {
 		}
 	}
 }
+
+func TestToNumberOverflow(t *testing.T) {
+	value := json.Number("123456789012345678901234567890")
+	if _, err := ToNumber(value); err == nil {
+		t.Fatal("expected overflow error")
+	}
+	i, err := ToNumberWithOverflow(value, OverflowSaturate)
+	if err != nil || i != math.MaxInt64 {
+		t.Fatal("expected", int64(math.MaxInt64), "got", i, err)
+	}
+	b, err := ToBigInt(value)
+	if err != nil || b.String() != value.String() {
+		t.Fatal("expected", value, "got", b, err)
+	}
+}
+
+func TestConversionError(t *testing.T) {
+	_, err := ToSliceOf[int8]([]interface{}{1, 1000})
+	var convErr *ConversionError
+	if !errors.As(err, &convErr) {
+		t.Fatalf("expected ConversionError, got %v", err)
+	}
+	if convErr.Path != "[1]" || convErr.To != "int8" || convErr.Value != 1000 {
+		t.Fatalf("unexpected error %#v", convErr)
+	}
+	if err.Error() != "[1]: can not convert 1000 (int) to int8: out of range" {
+		t.Fatalf("unexpected message %q", err)
+	}
+}
+
+func TestToStringNumbers(t *testing.T) {
+	for value, expected := range map[interface{}]string{
+		1e21:                   "1000000000000000000000",
+		1e-7:                   "0.0000001",
+		float32(0.1):           "0.1",
+		json.Number("1.50e3"):  "1500",
+		json.Number("-0.0"):    "0",
+		json.Number("1234567"): "1234567",
+	} {
+		if actual := ToString(value); actual != expected {
+			t.Errorf("expected %s for %v, got %s", expected, value, actual)
+		}
+	}
+}
+
+func TestCaseConversion(t *testing.T) {
+	for _, data := range []struct {
+		input, camel, snake string
+	}{
+		{input: "PodCIDRs", camel: "podCIDRs", snake: "pod_cidrs"},
+		{input: "user_id", camel: "userID", snake: "user_id"},
+		{input: "HTTPServer", camel: "httpServer", snake: "http_server"},
+		{input: "getHTTPSURL", camel: "getHTTPSURL", snake: "get_https_url"},
+		{input: "IPv4Address", camel: "ipV4Address", snake: "ip_v4_address"},
+	} {
+		if actual := ToCamel(data.input); actual != data.camel {
+			t.Errorf("expected %s, got %s", data.camel, actual)
+		}
+		if actual := ToSnake(data.input); actual != data.snake {
+			t.Errorf("expected %s, got %s", data.snake, actual)
+		}
+	}
+}
@@ -0,0 +1,87 @@
+package convert
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+)
+
+// DefaultMaxDepth is the maximum nesting of values converted by EncodeToMap
+// and ToObj unless a different limit is set in their options.
+const DefaultMaxDepth = 1000
+
+// startDetectingCycles is the depth after which containers are tracked to
+// detect cycles. Shallow values are not tracked because it's expensive and
+// cycles are only a concern if the depth keeps growing.
+const startDetectingCycles = 100
+
+var (
+	// ErrMaxDepth is returned if a value is nested deeper than allowed.
+	ErrMaxDepth = errors.New("maximum depth exceeded")
+	// ErrCycle is returned if a value contains itself.
+	ErrCycle = errors.New("cycle detected")
+)
+
+type visitKey struct {
+	ptr    uintptr
+	length int
+	t      reflect.Type
+}
+
+// depthGuard limits the depth of recursive conversions and detects cycles.
+type depthGuard struct {
+	max     int
+	depth   int
+	visited map[visitKey]bool
+}
+
+// enter must be called before converting the contents of v and leave after,
+// if enter didn't fail.
+func (g *depthGuard) enter(v reflect.Value) error {
+	g.depth++
+	if g.depth > startDetectingCycles {
+		if key, ok := trackable(v); ok {
+			if g.visited[key] {
+				g.depth--
+				return fmt.Errorf("%w at %s", ErrCycle, v.Type())
+			}
+			if g.visited == nil {
+				g.visited = map[visitKey]bool{}
+			}
+			g.visited[key] = true
+		}
+	}
+
+	limit := g.max
+	if limit <= 0 {
+		limit = DefaultMaxDepth
+	}
+	if g.depth > limit {
+		g.leave(v)
+		return fmt.Errorf("%w, values can be nested %d levels deep", ErrMaxDepth, limit)
+	}
+	return nil
+}
+
+func (g *depthGuard) leave(v reflect.Value) {
+	if g.depth > startDetectingCycles {
+		if key, ok := trackable(v); ok {
+			delete(g.visited, key)
+		}
+	}
+	g.depth--
+}
+
+func trackable(v reflect.Value) (visitKey, bool) {
+	switch v.Kind() {
+	case reflect.Ptr, reflect.Map:
+		if !v.IsNil() {
+			return visitKey{ptr: v.Pointer(), t: v.Type()}, true
+		}
+	case reflect.Slice:
+		if !v.IsNil() {
+			return visitKey{ptr: v.Pointer(), length: v.Len(), t: v.Type()}, true
+		}
+	}
+	return visitKey{}, false
+}
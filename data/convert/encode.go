@@ -0,0 +1,636 @@
+package convert
+
+import (
+	"bytes"
+	"encoding"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"math"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+var (
+	jsonMarshalerType = reflect.TypeOf((*json.Marshaler)(nil)).Elem()
+	textMarshalerType = reflect.TypeOf((*encoding.TextMarshaler)(nil)).Elem()
+)
+
+// OmitEmpty controls which empty struct fields are left out when encoding.
+type OmitEmpty int
+
+const (
+	// OmitEmptyTag leaves out empty fields tagged with omitempty.
+	OmitEmptyTag OmitEmpty = iota
+	// OmitEmptyAlways leaves out all empty fields.
+	OmitEmptyAlways
+	// OmitEmptyNever keeps all fields, even if tagged with omitempty.
+	OmitEmptyNever
+)
+
+// NilPointers controls how struct fields holding a nil pointer or interface
+// are encoded.
+type NilPointers int
+
+const (
+	// NilAsNull encodes nil fields as an explicit nil value, like null in
+	// JSON, so merge patches created from the result clear the field.
+	NilAsNull NilPointers = iota
+	// NilOmit leaves nil fields out, so merge patches created from the result
+	// leave the field unchanged.
+	NilOmit
+)
+
+// Inline controls which struct fields have their fields encoded into the
+// parent.
+type Inline int
+
+const (
+	// InlineTag inlines embedded structs without a name and fields with the
+	// ",inline" or ",squash" options of tags other than json.
+	InlineTag Inline = iota
+	// InlineEmbedded only inlines embedded structs without a name, like
+	// encoding/json.
+	InlineEmbedded
+	// InlineAlways also honors ",inline" and ",squash" on json tags, as used
+	// by Kubernetes types.
+	InlineAlways
+)
+
+// EncoderFunc encodes a value of the type it's registered for into a value
+// made of maps, slices and primitives.
+type EncoderFunc func(value interface{}) (interface{}, error)
+
+type EncodeOptions struct {
+	// TagNames are the struct tags used to name fields, the first one
+	// present on a field wins. Defaults to DefaultTagNames.
+	TagNames []string
+	// OmitEmpty selects the empty fields to leave out.
+	OmitEmpty OmitEmpty
+	// Encoders override the encoding of specific types.
+	Encoders map[reflect.Type]EncoderFunc
+	// NewMap allocates the maps of the result, for example from a pool.
+	NewMap func(size int) map[string]interface{}
+	// Inline selects the fields that are inlined.
+	Inline Inline
+	// NilPointers selects how nil pointer fields are encoded. Fields
+	// tagged with omitempty are always left out when nil.
+	NilPointers NilPointers
+	// OmitZero leaves out fields holding the zero value of their type, other
+	// than maps and slices.
+	OmitZero bool
+	// OmitEmptyCollections leaves out fields holding nil or empty maps and
+	// slices.
+	OmitEmptyCollections bool
+	// UnexportedTag is a struct tag that includes unexported fields in the
+	// result, named by the tag. Marshalers and encoders are not used for
+	// the values of unexported fields.
+	UnexportedTag string
+	// MaxDepth limits how deep values can be nested, DefaultMaxDepth if
+	// zero. Pointers, maps, slices and structs each count as one level.
+	MaxDepth int
+	// IgnoreStringTag encodes fields tagged with ",string" with their
+	// normal type instead of as a string.
+	IgnoreStringTag bool
+}
+
+type encoder struct {
+	opts EncodeOptions
+	// tagsKey is the key of opts.TagNames in the field cache
+	tagsKey string
+	guard   depthGuard
+}
+
+// EncodeToMapWithTags converts a struct to a map like EncodeToMap but names
+// the fields using the first of tagNames present on each field, so types
+// tagged for yaml or mapstructure can be converted. Types implementing
+// json.Marshaler or encoding.TextMarshaler are encoded with those.
+func EncodeToMapWithTags(obj interface{}, tagNames ...string) (map[string]interface{}, error) {
+	return EncodeToMapWithOptions(obj, EncodeOptions{
+		TagNames: tagNames,
+	})
+}
+
+// EncodeToMapWithOptions converts a struct to a map using reflection, see
+// EncodeOptions.
+func EncodeToMapWithOptions(obj interface{}, opts EncodeOptions) (map[string]interface{}, error) {
+	if m, ok := obj.(map[string]interface{}); ok {
+		return m, nil
+	}
+
+	e := &encoder{
+		opts:    opts,
+		tagsKey: strings.Join(opts.TagNames, ","),
+		guard:   depthGuard{max: opts.MaxDepth},
+	}
+	v, err := e.value(reflect.ValueOf(obj))
+	if err != nil {
+		return nil, err
+	}
+	if v == nil {
+		return nil, nil
+	}
+	m, ok := v.(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("can not encode %T to a map", obj)
+	}
+	return m, nil
+}
+
+func (e *encoder) value(v reflect.Value) (interface{}, error) {
+	if !v.IsValid() {
+		return nil, nil
+	}
+
+	if (v.Kind() == reflect.Ptr || v.Kind() == reflect.Interface) && v.IsNil() {
+		return nil, nil
+	}
+
+	if v.CanInterface() {
+		if result, ok, err := e.marshal(v); ok {
+			return result, err
+		}
+	}
+
+	switch v.Kind() {
+	case reflect.Ptr, reflect.Map, reflect.Slice, reflect.Array, reflect.Struct:
+		if err := e.guard.enter(v); err != nil {
+			return nil, err
+		}
+		defer e.guard.leave(v)
+	}
+
+	switch v.Kind() {
+	case reflect.Ptr, reflect.Interface:
+		return e.value(v.Elem())
+	case reflect.Struct:
+		result := e.newMap(v.NumField())
+		return result, e.structFields(v, result)
+	case reflect.Map:
+		if v.IsNil() {
+			return nil, nil
+		}
+		result := e.newMap(v.Len())
+		iter := v.MapRange()
+		for iter.Next() {
+			key, err := encodeKey(iter.Key())
+			if err != nil {
+				return nil, err
+			}
+			value, err := e.value(iter.Value())
+			if err != nil {
+				return nil, err
+			}
+			result[key] = value
+		}
+		return result, nil
+	case reflect.Slice:
+		if v.IsNil() {
+			return nil, nil
+		}
+		if v.Type().Elem().Kind() == reflect.Uint8 {
+			return base64.StdEncoding.EncodeToString(v.Bytes()), nil
+		}
+		fallthrough
+	case reflect.Array:
+		result := make([]interface{}, v.Len())
+		for i := 0; i < v.Len(); i++ {
+			item, err := e.value(v.Index(i))
+			if err != nil {
+				return nil, err
+			}
+			result[i] = item
+		}
+		return result, nil
+	case reflect.String:
+		return v.String(), nil
+	case reflect.Bool:
+		return v.Bool(), nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return json.Number(strconv.FormatInt(v.Int(), 10)), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return json.Number(strconv.FormatUint(v.Uint(), 10)), nil
+	case reflect.Float32, reflect.Float64:
+		return FloatToNumber(v.Float(), v.Type().Bits())
+	}
+
+	return nil, fmt.Errorf("can not encode value of type %s", v.Type())
+}
+
+// structField is the cached metadata of a struct field, see cachedFields.
+type structField struct {
+	index     int
+	name      string
+	tag       Tag
+	inline    bool
+	anonymous bool
+	exported  bool
+	// quotable is set if the ",string" option applies to the field type.
+	quotable bool
+}
+
+type fieldCacheKey struct {
+	t          reflect.Type
+	tags       string
+	unexported string
+}
+
+var fieldCache sync.Map
+
+// cachedFields parses the tags of the fields of t once per type and set of tag
+// names.
+func cachedFields(t reflect.Type, tagNames []string) []structField {
+	return cachedFieldsWith(t, tagNames, strings.Join(tagNames, ","), "")
+}
+
+// cachedFieldsWith is cachedFields that also returns the unexported fields
+// tagged with unexportedTag. tagsKey is tagNames joined with commas.
+func cachedFieldsWith(t reflect.Type, tagNames []string, tagsKey, unexportedTag string) []structField {
+	key := fieldCacheKey{t: t, tags: tagsKey, unexported: unexportedTag}
+	if fields, ok := fieldCache.Load(key); ok {
+		return fields.([]structField)
+	}
+
+	actual, _ := fieldCache.LoadOrStore(key, parseFields(t, tagNames, unexportedTag))
+	return actual.([]structField)
+}
+
+// parseFields reads the metadata of the fields of t, see cachedFieldsWith.
+func parseFields(t reflect.Type, tagNames []string, unexportedTag string) []structField {
+	var fields []structField
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if field.PkgPath != "" && !field.Anonymous {
+			if _, ok := field.Tag.Lookup(unexportedTag); ok && unexportedTag != "" {
+				if tag := ParseTag(field, unexportedTag); !tag.Skip && tag.Name != "" {
+					fields = append(fields, structField{
+						index: i,
+						name:  tag.Name,
+						tag:   tag,
+					})
+				}
+			}
+			continue
+		}
+
+		tag := ParseTag(field, tagNames...)
+		if tag.Skip {
+			continue
+		}
+
+		name := tag.Name
+		if name == "" {
+			name = field.Name
+		}
+		fields = append(fields, structField{
+			index:     i,
+			name:      name,
+			tag:       tag,
+			inline:    tag.Inline || (field.Anonymous && tag.Name == ""),
+			anonymous: field.Anonymous && tag.Name == "",
+			exported:  field.PkgPath == "",
+			quotable:  tag.String && isQuotable(field.Type),
+		})
+	}
+	return fields
+}
+
+// isQuotable returns true for the types the ",string" option applies to.
+func isQuotable(t reflect.Type) bool {
+	if t.Kind() == reflect.Ptr && t.Name() == "" {
+		t = t.Elem()
+	}
+	switch t.Kind() {
+	case reflect.Bool, reflect.String,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr,
+		reflect.Float32, reflect.Float64:
+		return true
+	}
+	return false
+}
+
+func (e *encoder) inline(field structField) bool {
+	switch e.opts.Inline {
+	case InlineEmbedded:
+		return field.anonymous
+	case InlineAlways:
+		return field.inline || field.tag.HasOption("inline") || field.tag.HasOption("squash")
+	}
+	return field.inline
+}
+
+// marshal encodes v with the encoders and marshalers that apply to its type.
+func (e *encoder) marshal(v reflect.Value) (interface{}, bool, error) {
+	if fn, ok := e.opts.Encoders[v.Type()]; ok {
+		result, err := fn(v.Interface())
+		return result, true, err
+	}
+	if fn, ok := lookupEncoder(v.Type()); ok {
+		out, err := fn(v.Interface())
+		if err != nil {
+			return nil, true, err
+		}
+		result, err := e.value(reflect.ValueOf(out))
+		return result, true, err
+	}
+
+	if v.Type().Implements(jsonMarshalerType) {
+		result, err := encodeJSON(v.Interface())
+		return result, true, err
+	}
+	if v.Kind() != reflect.Ptr && v.CanAddr() && reflect.PtrTo(v.Type()).Implements(jsonMarshalerType) {
+		result, err := encodeJSON(v.Addr().Interface())
+		return result, true, err
+	}
+	if v.Type().Implements(textMarshalerType) {
+		text, err := v.Interface().(encoding.TextMarshaler).MarshalText()
+		return string(text), true, err
+	}
+	return nil, false, nil
+}
+
+// inlinedField is a field of a struct type or of one of the structs inlined
+// into it, path holds the field indexes from the outer struct.
+type inlinedField struct {
+	structField
+	path   []int
+	goName string
+}
+
+type inlinedFieldsKey struct {
+	fieldCacheKey
+	inline Inline
+}
+
+var inlinedFieldCache sync.Map
+
+// inlinedFields returns the fields encoded for structs of type t. Like
+// encoding/json, of the fields sharing a name the shallowest one wins, a
+// field named by a tag beats one that isn't, and the name is dropped if
+// that leaves more than one field.
+func (e *encoder) inlinedFields(t reflect.Type) []inlinedField {
+	key := inlinedFieldsKey{
+		fieldCacheKey: fieldCacheKey{t: t, tags: e.tagsKey, unexported: e.opts.UnexportedTag},
+		inline:        e.opts.Inline,
+	}
+	if fields, ok := inlinedFieldCache.Load(key); ok {
+		return fields.([]inlinedField)
+	}
+
+	actual, _ := inlinedFieldCache.LoadOrStore(key, e.parseInlinedFields(t))
+	return actual.([]inlinedField)
+}
+
+func (e *encoder) parseInlinedFields(t reflect.Type) []inlinedField {
+	type inlined struct {
+		t     reflect.Type
+		path  []int
+		count int
+	}
+
+	var (
+		fields  []inlinedField
+		current []*inlined
+		next    = []*inlined{{t: t, count: 1}}
+		visited = map[reflect.Type]bool{}
+	)
+	for len(next) > 0 {
+		current, next = next, nil
+		nextByType := map[reflect.Type]*inlined{}
+
+		for _, c := range current {
+			if visited[c.t] {
+				continue
+			}
+			visited[c.t] = true
+
+			for _, field := range cachedFieldsWith(c.t, e.opts.TagNames, e.tagsKey, e.opts.UnexportedTag) {
+				path := append(slices.Clip(c.path), field.index)
+				if e.inline(field) {
+					fieldType := c.t.Field(field.index).Type
+					for fieldType.Kind() == reflect.Ptr {
+						fieldType = fieldType.Elem()
+					}
+					if fieldType.Kind() == reflect.Struct {
+						if n, ok := nextByType[fieldType]; ok {
+							n.count++
+						} else {
+							nextByType[fieldType] = &inlined{t: fieldType, path: path, count: 1}
+							next = append(next, nextByType[fieldType])
+						}
+						continue
+					}
+					if !field.exported {
+						continue
+					}
+				}
+
+				fields = append(fields, inlinedField{
+					structField: field,
+					path:        path,
+					goName:      c.t.Field(field.index).Name,
+				})
+				if c.count > 1 {
+					// a struct inlined twice at the same depth hides its fields
+					fields = append(fields, fields[len(fields)-1])
+				}
+			}
+		}
+	}
+
+	slices.SortStableFunc(fields, func(a, b inlinedField) int {
+		if a.name != b.name {
+			return strings.Compare(a.name, b.name)
+		}
+		if len(a.path) != len(b.path) {
+			return len(a.path) - len(b.path)
+		}
+		if tagged(a) != tagged(b) {
+			if tagged(a) {
+				return -1
+			}
+			return 1
+		}
+		return slices.Compare(a.path, b.path)
+	})
+
+	var result []inlinedField
+	for i := 0; i < len(fields); {
+		j := i + 1
+		for j < len(fields) && fields[j].name == fields[i].name {
+			j++
+		}
+		if j-i == 1 || len(fields[i].path) != len(fields[i+1].path) || tagged(fields[i]) != tagged(fields[i+1]) {
+			result = append(result, fields[i])
+		}
+		i = j
+	}
+
+	slices.SortFunc(result, func(a, b inlinedField) int {
+		return slices.Compare(a.path, b.path)
+	})
+	return result
+}
+
+func tagged(f inlinedField) bool {
+	return f.tag.Name != ""
+}
+
+// fieldByPath returns the field of v at path, ok is false if an inlined
+// struct on the way is a nil pointer.
+func fieldByPath(v reflect.Value, path []int) (reflect.Value, bool) {
+	for i, index := range path {
+		if i > 0 {
+			for v.Kind() == reflect.Ptr {
+				if v.IsNil() {
+					return reflect.Value{}, false
+				}
+				v = v.Elem()
+			}
+		}
+		v = v.Field(index)
+	}
+	return v, true
+}
+
+// structFields encodes the fields of v into result, including the fields of
+// inlined structs, see inlinedFields.
+func (e *encoder) structFields(v reflect.Value, result map[string]interface{}) error {
+	for _, field := range e.inlinedFields(v.Type()) {
+		fieldValue, ok := fieldByPath(v, field.path)
+		if !ok {
+			continue
+		}
+
+		if e.omit(field.tag, fieldValue) {
+			continue
+		}
+		if e.opts.NilPointers == NilOmit && isNil(fieldValue) {
+			continue
+		}
+
+		value, err := e.value(fieldValue)
+		if err != nil {
+			return fmt.Errorf("field %s: %w", field.goName, err)
+		}
+		if field.quotable && !e.opts.IgnoreStringTag {
+			value, err = quote(value)
+			if err != nil {
+				return fmt.Errorf("field %s: %w", field.goName, err)
+			}
+		}
+		result[field.name] = value
+	}
+	return nil
+}
+
+// quote encodes value for the ",string" option.
+func quote(value interface{}) (interface{}, error) {
+	switch v := value.(type) {
+	case json.Number:
+		return string(v), nil
+	case bool:
+		return strconv.FormatBool(v), nil
+	case string:
+		b, err := json.Marshal(v)
+		return string(b), err
+	}
+	return value, nil
+}
+
+func (e *encoder) newMap(size int) map[string]interface{} {
+	if e.opts.NewMap != nil {
+		return e.opts.NewMap(size)
+	}
+	return make(map[string]interface{}, size)
+}
+
+func (e *encoder) omit(tag Tag, v reflect.Value) bool {
+	isCollection := v.Kind() == reflect.Map || v.Kind() == reflect.Slice
+	if e.opts.OmitEmptyCollections && isCollection && v.Len() == 0 {
+		return true
+	}
+	if e.opts.OmitZero && !isCollection && v.IsZero() {
+		return true
+	}
+
+	switch e.opts.OmitEmpty {
+	case OmitEmptyAlways:
+		return isEmptyValue(v)
+	case OmitEmptyNever:
+		return false
+	}
+	return tag.OmitEmpty && isEmptyValue(v)
+}
+
+// FloatToNumber formats f as a json.Number. Integral values are written
+// without exponent, so large integers that were decoded as floats are encoded
+// as integers again.
+func FloatToNumber(f float64, bitSize int) (json.Number, error) {
+	if math.IsNaN(f) || math.IsInf(f, 0) {
+		return "", fmt.Errorf("unsupported number %v", f)
+	}
+	if f == math.Trunc(f) {
+		return json.Number(strconv.FormatFloat(f, 'f', -1, bitSize)), nil
+	}
+	var value interface{} = f
+	if bitSize == 32 {
+		value = float32(f)
+	}
+	b, err := json.Marshal(value)
+	return json.Number(b), err
+}
+
+func encodeKey(key reflect.Value) (string, error) {
+	if key.Kind() == reflect.String {
+		return key.String(), nil
+	}
+	if key.Type().Implements(textMarshalerType) {
+		text, err := key.Interface().(encoding.TextMarshaler).MarshalText()
+		return string(text), err
+	}
+	switch key.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(key.Int(), 10), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return strconv.FormatUint(key.Uint(), 10), nil
+	}
+	return "", fmt.Errorf("unsupported map key type %s", key.Type())
+}
+
+func encodeJSON(obj interface{}) (interface{}, error) {
+	b, err := json.Marshal(obj)
+	if err != nil {
+		return nil, err
+	}
+	var result interface{}
+	dec := json.NewDecoder(bytes.NewReader(b))
+	dec.UseNumber()
+	return result, dec.Decode(&result)
+}
+
+func isNil(v reflect.Value) bool {
+	return (v.Kind() == reflect.Ptr || v.Kind() == reflect.Interface) && v.IsNil()
+}
+
+func isEmptyValue(v reflect.Value) bool {
+	switch v.Kind() {
+	case reflect.Array, reflect.Map, reflect.Slice, reflect.String:
+		return v.Len() == 0
+	case reflect.Bool:
+		return !v.Bool()
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return v.Int() == 0
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return v.Uint() == 0
+	case reflect.Float32, reflect.Float64:
+		return v.Float() == 0
+	case reflect.Interface, reflect.Ptr:
+		return v.IsNil()
+	}
+	return false
+}
@@ -0,0 +1,377 @@
+package convert
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"math"
+	"reflect"
+	"testing"
+)
+
+type benchmarkItem struct {
+	Name   string            `json:"name"`
+	Labels map[string]string `json:"labels,omitempty"`
+	Ports  []int             `json:"ports"`
+}
+
+type benchmarkStruct struct {
+	benchmarkItem `json:",inline"`
+	ID            int64            `json:"id"`
+	Enabled       bool             `json:"enabled,omitempty"`
+	Ratio         float64          `json:"ratio"`
+	Items         []benchmarkItem  `json:"items"`
+	Child         *benchmarkStruct `json:"child,omitempty"`
+	Ignored       string           `json:"-"`
+}
+
+var benchmarkValue = &benchmarkStruct{
+	benchmarkItem: benchmarkItem{
+		Name:   "test",
+		Labels: map[string]string{"app": "test"},
+		Ports:  []int{80, 443},
+	},
+	ID:    math.MaxInt64,
+	Ratio: 0.5,
+	Items: []benchmarkItem{
+		{Name: "a", Ports: []int{1}},
+		{Name: "b", Labels: map[string]string{"c": "d"}},
+	},
+	Child: &benchmarkStruct{ID: 1},
+}
+
+func encodeToMapJSON(obj interface{}) (map[string]interface{}, error) {
+	b, err := json.Marshal(obj)
+	if err != nil {
+		return nil, err
+	}
+	result := map[string]interface{}{}
+	dec := json.NewDecoder(bytes.NewBuffer(b))
+	dec.UseNumber()
+	return result, dec.Decode(&result)
+}
+
+func TestEncodeToMapMatchesJSON(t *testing.T) {
+	expected, err := encodeToMapJSON(benchmarkValue)
+	if err != nil {
+		t.Fatal(err)
+	}
+	actual, err := EncodeToMap(benchmarkValue)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(expected, actual) {
+		t.Fatalf("expected %v, got %v", expected, actual)
+	}
+	if actual["id"] != json.Number("9223372036854775807") {
+		t.Fatalf("lost precision: %v", actual["id"])
+	}
+}
+
+func BenchmarkEncodeToMap(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		if _, err := EncodeToMap(benchmarkValue); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkEncodeToMapJSON(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		if _, err := encodeToMapJSON(benchmarkValue); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+type taggedEmbedded struct {
+	Name    string `json:"name"`
+	Enabled bool   `json:"enabled,string"`
+}
+
+type taggedStruct struct {
+	*taggedEmbedded
+	Name  string   `json:"name"`
+	Count int64    `json:"count,string"`
+	Label string   `json:"label,string"`
+	Ratio *float64 `json:"ratio,string,omitempty"`
+}
+
+func TestEncodeToMapTagOptions(t *testing.T) {
+	for _, obj := range []taggedStruct{
+		{},
+		{
+			taggedEmbedded: &taggedEmbedded{Name: "inner", Enabled: true},
+			Name:           "outer",
+			Count:          math.MaxInt64,
+			Label:          "x",
+		},
+	} {
+		expected, err := encodeToMapJSON(obj)
+		if err != nil {
+			t.Fatal(err)
+		}
+		actual, err := EncodeToMap(obj)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(expected, actual) {
+			t.Fatalf("expected %v, got %v", expected, actual)
+		}
+	}
+
+	actual, err := EncodeToMapWithOptions(taggedStruct{Count: 1}, EncodeOptions{IgnoreStringTag: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if actual["count"] != json.Number("1") {
+		t.Fatalf("expected number, got %#v", actual["count"])
+	}
+}
+
+type conflictA struct {
+	X string `json:"x"`
+	A string `json:"a"`
+}
+
+type conflictB struct {
+	X string `json:"x"`
+}
+
+type conflictUntagged struct {
+	X string
+}
+
+type conflictTagged struct {
+	Y string `json:"X"`
+}
+
+func TestEncodeToMapInlineConflicts(t *testing.T) {
+	type sameDepth struct {
+		*conflictA
+		conflictB
+	}
+	type taggedWins struct {
+		conflictUntagged
+		conflictTagged
+	}
+	type wrapB1 struct{ conflictB }
+	type wrapB2 struct{ conflictB }
+	type embeddedTwice struct {
+		wrapB1
+		wrapB2
+	}
+
+	for _, obj := range []interface{}{
+		sameDepth{conflictA: &conflictA{X: "a", A: "a"}, conflictB: conflictB{X: "b"}},
+		sameDepth{conflictB: conflictB{X: "b"}},
+		taggedWins{conflictUntagged{X: "untagged"}, conflictTagged{Y: "tagged"}},
+		embeddedTwice{wrapB1{conflictB{X: "1"}}, wrapB2{conflictB{X: "2"}}},
+	} {
+		expected, err := encodeToMapJSON(obj)
+		if err != nil {
+			t.Fatal(err)
+		}
+		actual, err := EncodeToMap(obj)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(expected, actual) {
+			t.Fatalf("%T: expected %v, got %v", obj, expected, actual)
+		}
+	}
+
+	actual, err := EncodeToMap(sameDepth{conflictB: conflictB{X: "b"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := actual["x"]; ok {
+		t.Fatalf("expected conflicting field to be dropped, got %v", actual)
+	}
+}
+
+func TestEncodeToMapDashName(t *testing.T) {
+	type dashStruct struct {
+		Dash    string `json:"-,"`
+		Skipped string `json:"-"`
+	}
+
+	obj := dashStruct{Dash: "a", Skipped: "b"}
+	expected, err := encodeToMapJSON(obj)
+	if err != nil {
+		t.Fatal(err)
+	}
+	actual, err := EncodeToMap(obj)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(expected, actual) {
+		t.Fatalf("expected %v, got %v", expected, actual)
+	}
+	if actual["-"] != "a" {
+		t.Fatalf("expected field named -, got %v", actual)
+	}
+}
+
+func TestEncodeToMapNilPointers(t *testing.T) {
+	type nilStruct struct {
+		Ptr   *int        `json:"ptr"`
+		Iface interface{} `json:"iface"`
+		Value int         `json:"value"`
+	}
+
+	m, err := EncodeToMap(nilStruct{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if v, ok := m["ptr"]; !ok || v != nil {
+		t.Fatalf("expected explicit nil for ptr, got %v", m)
+	}
+
+	m, err = EncodeToMapWithOptions(nilStruct{}, EncodeOptions{NilPointers: NilOmit})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(m, map[string]interface{}{"value": json.Number("0")}) {
+		t.Fatalf("expected nil fields to be left out, got %v", m)
+	}
+}
+
+func TestEncodeToMapOutputOptions(t *testing.T) {
+	type outputStruct struct {
+		Name    string            `json:"name"`
+		Count   int               `json:"count"`
+		Labels  map[string]string `json:"labels"`
+		Items   []string          `json:"items"`
+		private string            `export:"private"`
+		ignored string
+	}
+	obj := outputStruct{Labels: map[string]string{}, private: "x", ignored: "y"}
+
+	m, err := EncodeToMapWithOptions(obj, EncodeOptions{OmitZero: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(m, map[string]interface{}{"labels": map[string]interface{}{}, "items": nil}) {
+		t.Fatalf("unexpected result %v", m)
+	}
+
+	m, err = EncodeToMapWithOptions(obj, EncodeOptions{OmitEmptyCollections: true, UnexportedTag: "export"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(m, map[string]interface{}{"name": "", "count": json.Number("0"), "private": "x"}) {
+		t.Fatalf("unexpected result %v", m)
+	}
+}
+
+func TestToObjMatchesJSON(t *testing.T) {
+	m, err := EncodeToMap(benchmarkValue)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var expected, actual benchmarkStruct
+	if err := toObjJSON(m, &expected); err != nil {
+		t.Fatal(err)
+	}
+	if err := ToObj(m, &actual); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(expected, actual) {
+		t.Fatalf("expected %+v, got %+v", expected, actual)
+	}
+}
+
+func toObjJSON(data interface{}, into interface{}) error {
+	b, err := json.Marshal(data)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(b, into)
+}
+
+func BenchmarkToObj(b *testing.B) {
+	m, err := EncodeToMap(benchmarkValue)
+	if err != nil {
+		b.Fatal(err)
+	}
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		var result benchmarkStruct
+		if err := ToObj(m, &result); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkToObjJSON(b *testing.B) {
+	m, err := EncodeToMap(benchmarkValue)
+	if err != nil {
+		b.Fatal(err)
+	}
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		var result benchmarkStruct
+		if err := toObjJSON(m, &result); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkStructFieldsCached(b *testing.B) {
+	t := reflect.TypeOf(benchmarkStruct{})
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		cachedFields(t, DefaultTagNames)
+	}
+}
+
+func BenchmarkStructFieldsUncached(b *testing.B) {
+	t := reflect.TypeOf(benchmarkStruct{})
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		parseFields(t, DefaultTagNames, "")
+	}
+}
+
+type cyclicNode struct {
+	Name string      `json:"name"`
+	Next *cyclicNode `json:"next,omitempty"`
+}
+
+func TestDepthLimits(t *testing.T) {
+	node := &cyclicNode{Name: "a"}
+	node.Next = node
+	if _, err := EncodeToMap(node); !errors.Is(err, ErrCycle) {
+		t.Fatalf("expected ErrCycle, got %v", err)
+	}
+
+	cyclic := map[string]interface{}{"name": "a"}
+	cyclic["next"] = cyclic
+	if err := ToObj(cyclic, &cyclicNode{}); !errors.Is(err, ErrCycle) {
+		t.Fatalf("expected ErrCycle, got %v", err)
+	}
+
+	deep := map[string]interface{}{"name": "leaf"}
+	for i := 0; i < 20; i++ {
+		deep = map[string]interface{}{"next": deep}
+	}
+	if err := ToObjWithOptions(deep, &cyclicNode{}, DecodeOptions{MaxDepth: 10}); !errors.Is(err, ErrMaxDepth) {
+		t.Fatalf("expected ErrMaxDepth, got %v", err)
+	}
+	if err := ToObj(deep, &cyclicNode{}); err != nil {
+		t.Fatal(err)
+	}
+	chain := &cyclicNode{Name: "leaf"}
+	for i := 0; i < 20; i++ {
+		chain = &cyclicNode{Next: chain}
+	}
+	if _, err := EncodeToMapWithOptions(chain, EncodeOptions{MaxDepth: 10}); !errors.Is(err, ErrMaxDepth) {
+		t.Fatalf("expected ErrMaxDepth, got %v", err)
+	}
+}
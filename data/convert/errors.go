@@ -0,0 +1,99 @@
+package convert
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+)
+
+// maxErrorValueLength is the length at which values are truncated in
+// ConversionError messages.
+const maxErrorValueLength = 64
+
+// ErrUnknownField is the reason of ConversionErrors for fields that are not
+// known to the target struct, see DecodeOptions.DisallowUnknownFields.
+var ErrUnknownField = errors.New("unknown field")
+
+// ConversionError describes a value that could not be converted.
+type ConversionError struct {
+	// Value is the value that failed to convert.
+	Value interface{}
+	// To is the type the value was converted to.
+	To string
+	// Path is the path of the value in the converted data, like
+	// "spec.ports[0].port", empty for top level values.
+	Path string
+	// Err is the reason, if there is a more specific one.
+	Err error
+}
+
+func (e *ConversionError) Error() string {
+	var b strings.Builder
+	if e.Path != "" {
+		b.WriteString(e.Path)
+		b.WriteString(": ")
+	}
+	fmt.Fprintf(&b, "can not convert %s (%T) to %s", truncate(e.Value), e.Value, e.To)
+	if e.Err != nil {
+		b.WriteString(": ")
+		b.WriteString(e.Err.Error())
+	}
+	return b.String()
+}
+
+func (e *ConversionError) Unwrap() error {
+	return e.Err
+}
+
+func conversionError(value interface{}, to string, err error) error {
+	var convErr *ConversionError
+	if errors.As(err, &convErr) && convErr.Path == "" {
+		// keep only the reason of the inner conversion
+		err = convErr.Err
+	}
+	return &ConversionError{
+		Value: value,
+		To:    to,
+		Err:   err,
+	}
+}
+
+// withPath prefixes the path of a ConversionError with path, other errors are
+// prefixed in their message.
+func withPath(err error, path string) error {
+	var convErr *ConversionError
+	if !errors.As(err, &convErr) {
+		return fmt.Errorf("%s: %w", path, err)
+	}
+	result := *convErr
+	switch {
+	case result.Path == "":
+		result.Path = path
+	case strings.HasPrefix(result.Path, "["):
+		result.Path = path + result.Path
+	default:
+		result.Path = path + "." + result.Path
+	}
+	return &result
+}
+
+func truncate(value interface{}) string {
+	var str string
+	if s, ok := value.(string); ok {
+		str = s
+	} else {
+		str = fmt.Sprint(value)
+	}
+	if len(str) > maxErrorValueLength {
+		cut := maxErrorValueLength
+		for cut > 0 && !utf8.RuneStart(str[cut]) {
+			cut--
+		}
+		str = str[:cut] + "..."
+	}
+	if _, ok := value.(string); ok {
+		return fmt.Sprintf("%q", str)
+	}
+	return str
+}
@@ -0,0 +1,64 @@
+package convert
+
+import (
+	"encoding/json"
+	"math"
+	"math/big"
+	"strconv"
+)
+
+// FormatFloat formats f in its shortest decimal form without exponent, so
+// 1e21 is "1000000000000000000000" and 1e-7 is "0.0000001". The result doesn't
+// depend on the locale of the environment.
+func FormatFloat(f float64, bitSize int) string {
+	switch {
+	case math.IsNaN(f):
+		return "NaN"
+	case math.IsInf(f, 1):
+		return "+Inf"
+	case math.IsInf(f, -1):
+		return "-Inf"
+	case f == 0:
+		// no "-0"
+		return "0"
+	}
+	return strconv.FormatFloat(f, 'f', -1, bitSize)
+}
+
+// FormatJSONNumber returns n in the form FormatFloat produces, so "1.50e3" is
+// "1500". Invalid numbers are returned as is.
+func FormatJSONNumber(n json.Number) string {
+	if i, err := strconv.ParseInt(string(n), 10, 64); err == nil {
+		return strconv.FormatInt(i, 10)
+	}
+	f, _, err := big.ParseFloat(string(n), 10, 256, big.ToNearestEven)
+	if err != nil {
+		return string(n)
+	}
+	if f.Sign() == 0 {
+		return "0"
+	}
+	return f.Text('f', -1)
+}
+
+// formatNumber formats the number types canonically, it returns false for
+// other values.
+func formatNumber(value interface{}) (string, bool) {
+	switch v := value.(type) {
+	case float64:
+		return FormatFloat(v, 64), true
+	case float32:
+		return FormatFloat(float64(v), 32), true
+	case json.Number:
+		return FormatJSONNumber(v), true
+	case *big.Float:
+		if v == nil {
+			return "", false
+		}
+		if v.Sign() == 0 {
+			return "0", true
+		}
+		return v.Text('f', -1), true
+	}
+	return "", false
+}
@@ -0,0 +1,141 @@
+package convert
+
+import (
+	"encoding/json"
+	"errors"
+	"math/big"
+	"reflect"
+	"strconv"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+// To converts v to T using the converter of this package for T, like ToString
+// for strings and ToDuration for time.Duration. Types without a converter are
+// decoded with ToObj.
+func To[T any](v interface{}) (T, error) {
+	var result T
+	if t, ok := v.(T); ok && v != nil {
+		return t, nil
+	}
+
+	var (
+		out interface{}
+		err error
+	)
+	switch any(result).(type) {
+	case string:
+		out = ToString(v)
+	case bool:
+		out, err = ToBoolE(v)
+	case int64:
+		out, err = ToNumber(v)
+	case float64:
+		out, err = ToFloat(v)
+	case json.Number:
+		out, err = ToJSONNumber(v)
+	case []byte:
+		out, err = ToBytes(v)
+	case time.Time:
+		out, err = ToTime(v)
+	case time.Duration:
+		out, err = ToDuration(v)
+	case resource.Quantity:
+		out, err = ToQuantity(v)
+	case *big.Int:
+		out, err = ToBigInt(v)
+	case *big.Float:
+		out, err = ToBigFloat(v)
+	case []string:
+		out = ToStringSlice(v)
+	case map[string]interface{}:
+		out, err = EncodeToMap(v)
+	default:
+		return result, toKind(v, reflect.ValueOf(&result).Elem())
+	}
+	if err != nil {
+		return result, err
+	}
+	if out != nil {
+		result = out.(T)
+	}
+	return result, nil
+}
+
+// ToSliceOf converts every item of the slice v to T, see To. A value that is
+// not a slice is converted to a slice of one item.
+func ToSliceOf[T any](v interface{}) ([]T, error) {
+	if v == nil {
+		return nil, nil
+	}
+
+	items := reflect.ValueOf(v)
+	if items.Kind() != reflect.Slice && items.Kind() != reflect.Array {
+		item, err := To[T](v)
+		if err != nil {
+			return nil, err
+		}
+		return []T{item}, nil
+	}
+
+	result := make([]T, items.Len())
+	for i := range result {
+		item, err := To[T](items.Index(i).Interface())
+		if err != nil {
+			return nil, withPath(err, "["+strconv.Itoa(i)+"]")
+		}
+		result[i] = item
+	}
+	return result, nil
+}
+
+// toKind converts v to named types based on their kind, like a type Mode
+// string, and decodes everything else with ToObj.
+func toKind(v interface{}, result reflect.Value) error {
+	t := result.Type()
+	switch t.Kind() {
+	case reflect.String:
+		result.SetString(ToString(v))
+		return nil
+	case reflect.Bool:
+		b, err := ToBoolE(v)
+		result.SetBool(b)
+		return err
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		i, err := ToNumber(v)
+		if err != nil {
+			return err
+		}
+		if result.OverflowInt(i) {
+			return conversionError(v, t.String(), errors.New("out of range"))
+		}
+		result.SetInt(i)
+		return nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		i, err := ToBigInt(v)
+		if err != nil {
+			return err
+		}
+		if i.Sign() < 0 || !i.IsUint64() || result.OverflowUint(i.Uint64()) {
+			return conversionError(v, t.String(), errors.New("out of range"))
+		}
+		result.SetUint(i.Uint64())
+		return nil
+	case reflect.Float32, reflect.Float64:
+		f, err := ToFloat(v)
+		if err != nil {
+			return err
+		}
+		if result.OverflowFloat(f) {
+			return conversionError(v, t.String(), errors.New("out of range"))
+		}
+		result.SetFloat(f)
+		return nil
+	}
+
+	if v == nil {
+		return nil
+	}
+	return ToObj(v, result.Addr().Interface())
+}
@@ -0,0 +1,38 @@
+package convert
+
+import (
+	"reflect"
+	"testing"
+)
+
+type genericMode string
+
+type genericPoint struct {
+	X int `json:"x"`
+}
+
+func TestTo(t *testing.T) {
+	if s, err := To[string](5); err != nil || s != "5" {
+		t.Fatal("expected 5, got", s, err)
+	}
+	if m, err := To[genericMode]("auto"); err != nil || m != "auto" {
+		t.Fatal("expected auto, got", m, err)
+	}
+	if _, err := To[int8](300); err == nil {
+		t.Fatal("expected overflow error")
+	}
+	p, err := To[*genericPoint](map[string]interface{}{"x": 3})
+	if err != nil || p.X != 3 {
+		t.Fatal("expected x 3, got", p, err)
+	}
+}
+
+func TestToSliceOf(t *testing.T) {
+	ints, err := ToSliceOf[int]([]interface{}{"1", 2, 3.0})
+	if err != nil || !reflect.DeepEqual(ints, []int{1, 2, 3}) {
+		t.Fatal("expected [1 2 3], got", ints, err)
+	}
+	if _, err := ToSliceOf[int]([]string{"1", "x"}); err == nil {
+		t.Fatal("expected error")
+	}
+}
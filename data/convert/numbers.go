@@ -0,0 +1,169 @@
+package convert
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"math/big"
+	"strings"
+)
+
+// OverflowMode selects what happens when a number doesn't fit into the target
+// type.
+type OverflowMode int
+
+const (
+	// OverflowError fails the conversion.
+	OverflowError OverflowMode = iota
+	// OverflowSaturate clamps the value to the closest representable number.
+	OverflowSaturate
+)
+
+var (
+	minInt64 = big.NewInt(math.MinInt64)
+	maxInt64 = big.NewInt(math.MaxInt64)
+)
+
+// ToNumberWithOverflow converts value to an int64 like ToNumber, handling
+// values outside of the int64 range according to overflow. Fractions are
+// truncated.
+func ToNumberWithOverflow(value interface{}, overflow OverflowMode) (int64, error) {
+	value = Singular(value)
+
+	switch v := value.(type) {
+	case int64:
+		return v, nil
+	case int:
+		return int64(v), nil
+	case int32:
+		return int64(v), nil
+	case json.Number:
+		if i, err := v.Int64(); err == nil {
+			return i, nil
+		}
+	}
+
+	i, err := ToBigInt(value)
+	if err != nil {
+		return 0, conversionError(value, "int64", err)
+	}
+	if i.IsInt64() {
+		return i.Int64(), nil
+	}
+	if overflow == OverflowSaturate {
+		if i.Sign() < 0 {
+			return math.MinInt64, nil
+		}
+		return math.MaxInt64, nil
+	}
+	return 0, conversionError(value, "int64", fmt.Errorf("must be between %s and %s", minInt64, maxInt64))
+}
+
+// ToBigInt converts numbers, json.Number and numeric strings of any size to a
+// big.Int. Fractions are truncated.
+func ToBigInt(value interface{}) (*big.Int, error) {
+	value = Singular(value)
+
+	switch v := value.(type) {
+	case *big.Int:
+		return new(big.Int).Set(v), nil
+	case big.Int:
+		return new(big.Int).Set(&v), nil
+	case uint64:
+		return new(big.Int).SetUint64(v), nil
+	case uint:
+		return new(big.Int).SetUint64(uint64(v)), nil
+	}
+
+	if i, ok := new(big.Int).SetString(strings.TrimSpace(ToString(value)), 10); ok {
+		return i, nil
+	}
+
+	f, err := ToBigFloat(value)
+	if err != nil {
+		return nil, err
+	}
+	if f.IsInf() {
+		return nil, conversionError(value, "integer", nil)
+	}
+	i, _ := f.Int(nil)
+	return i, nil
+}
+
+// ToBigFloat converts numbers, json.Number and numeric strings to a big.Float
+// without going through float64, so no precision is lost.
+func ToBigFloat(value interface{}) (*big.Float, error) {
+	value = Singular(value)
+
+	switch v := value.(type) {
+	case *big.Float:
+		return new(big.Float).Copy(v), nil
+	case big.Float:
+		return new(big.Float).Copy(&v), nil
+	case *big.Int:
+		return new(big.Float).SetInt(v), nil
+	case big.Int:
+		return new(big.Float).SetInt(&v), nil
+	case float64:
+		if math.IsNaN(v) {
+			return nil, conversionError(value, "number", nil)
+		}
+		return big.NewFloat(v), nil
+	case float32:
+		if math.IsNaN(float64(v)) {
+			return nil, conversionError(value, "number", nil)
+		}
+		return big.NewFloat(float64(v)), nil
+	}
+
+	str := strings.TrimSpace(ToString(value))
+	f, _, err := big.ParseFloat(str, 10, 256, big.ToNearestEven)
+	if err != nil {
+		return nil, conversionError(value, "number", err)
+	}
+	return f, nil
+}
+
+// ToJSONNumber converts numbers, including big.Int and big.Float, to a
+// json.Number holding the exact value.
+func ToJSONNumber(value interface{}) (json.Number, error) {
+	value = Singular(value)
+
+	switch v := value.(type) {
+	case json.Number:
+		return v, nil
+	case float64:
+		return FloatToNumber(v, 64)
+	case float32:
+		return FloatToNumber(float64(v), 32)
+	case *big.Int:
+		return json.Number(v.String()), nil
+	case big.Int:
+		return json.Number(v.String()), nil
+	case *big.Float:
+		return bigFloatToNumber(v)
+	case big.Float:
+		return bigFloatToNumber(&v)
+	}
+
+	str := strings.TrimSpace(ToString(value))
+	if i, ok := new(big.Int).SetString(str, 10); ok {
+		return json.Number(i.String()), nil
+	}
+	f, err := ToBigFloat(str)
+	if err != nil {
+		return "", err
+	}
+	return bigFloatToNumber(f)
+}
+
+func bigFloatToNumber(f *big.Float) (json.Number, error) {
+	if f.IsInf() {
+		return "", fmt.Errorf("unsupported number %s", f)
+	}
+	if f.IsInt() {
+		i, _ := f.Int(nil)
+		return json.Number(i.String()), nil
+	}
+	return json.Number(f.Text('g', -1)), nil
+}
@@ -0,0 +1,67 @@
+package convert
+
+import (
+	"encoding/json"
+	"math"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+// QuantityPattern is the pattern of the string form of a resource.Quantity, as
+// used in the OpenAPI schemas of Kubernetes types.
+const QuantityPattern = `^(\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))(([KMGTPE]i)|[numkMGTPE]|([eE](\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))))?$`
+
+// ToQuantity converts strings like "500m" or "1Gi" and numbers to a
+// resource.Quantity.
+func ToQuantity(value interface{}) (resource.Quantity, error) {
+	value = Singular(value)
+
+	switch v := value.(type) {
+	case resource.Quantity:
+		return v, nil
+	case *resource.Quantity:
+		if v == nil {
+			return resource.Quantity{}, nil
+		}
+		return v.DeepCopy(), nil
+	case int64:
+		return *resource.NewQuantity(v, resource.DecimalSI), nil
+	case int:
+		return *resource.NewQuantity(int64(v), resource.DecimalSI), nil
+	case int32:
+		return *resource.NewQuantity(int64(v), resource.DecimalSI), nil
+	case float64:
+		if math.IsNaN(v) || math.IsInf(v, 0) {
+			return resource.Quantity{}, conversionError(value, "quantity", nil)
+		}
+		n, err := FloatToNumber(v, 64)
+		if err != nil {
+			return resource.Quantity{}, err
+		}
+		value = n
+	case json.Number:
+	case string:
+	case nil:
+		return resource.Quantity{}, nil
+	default:
+		return resource.Quantity{}, conversionError(value, "quantity", nil)
+	}
+
+	str := strings.TrimSpace(ToString(value))
+	q, err := resource.ParseQuantity(str)
+	if err != nil {
+		return resource.Quantity{}, conversionError(value, "quantity", err)
+	}
+	return q, nil
+}
+
+// FormatQuantity returns the canonical string form of value, for example
+// "1Gi" for "1024Mi".
+func FormatQuantity(value interface{}) (string, error) {
+	q, err := ToQuantity(value)
+	if err != nil {
+		return "", err
+	}
+	return q.String(), nil
+}
@@ -0,0 +1,67 @@
+package convert
+
+import (
+	"encoding/json"
+	"errors"
+	"math"
+	"regexp"
+	"testing"
+
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+func TestToQuantity(t *testing.T) {
+	tests := []struct {
+		value    interface{}
+		expected string
+	}{
+		{value: "500m", expected: "500m"},
+		{value: " 1024Mi ", expected: "1Gi"},
+		{value: "1.5", expected: "1500m"},
+		{value: "2e3", expected: "2e3"},
+		{value: int64(2), expected: "2"},
+		{value: 3, expected: "3"},
+		{value: 0.25, expected: "250m"},
+		{value: json.Number("1000"), expected: "1k"},
+		{value: resource.MustParse("2Gi"), expected: "2Gi"},
+		{value: []interface{}{"100m"}, expected: "100m"},
+		{value: nil, expected: "0"},
+	}
+
+	for _, tt := range tests {
+		s, err := FormatQuantity(tt.value)
+		if err != nil {
+			t.Errorf("%#v: %v", tt.value, err)
+		} else if s != tt.expected {
+			t.Errorf("%#v: expected %s, got %s", tt.value, tt.expected, s)
+		}
+	}
+
+	q, err := ToQuantity("1Gi")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if q.Value() != 1<<30 {
+		t.Fatalf("expected 1Gi, got %d", q.Value())
+	}
+}
+
+func TestToQuantityInvalid(t *testing.T) {
+	for _, value := range []interface{}{"1GB", "lots", "", math.NaN(), true} {
+		_, err := ToQuantity(value)
+		var convErr *ConversionError
+		if !errors.As(err, &convErr) {
+			t.Errorf("%#v: expected a conversion error, got %v", value, err)
+		}
+	}
+}
+
+func TestQuantityPattern(t *testing.T) {
+	pattern := regexp.MustCompile(QuantityPattern)
+	for _, s := range []string{"500m", "1Gi", "+1.5", ".5", "1e3", "-2E-3", "1GB", "1 Gi", "lots", "1.2.3"} {
+		_, err := resource.ParseQuantity(s)
+		if pattern.MatchString(s) != (err == nil) {
+			t.Errorf("%q: expected the pattern to agree with ParseQuantity (%v)", s, err)
+		}
+	}
+}
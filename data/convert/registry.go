@@ -0,0 +1,412 @@
+package convert
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+)
+
+// ConverterFunc converts a value of the type it's registered for.
+type ConverterFunc func(value interface{}) (interface{}, error)
+
+type converterKey struct {
+	from, to reflect.Type
+}
+
+type converterRegistry struct {
+	order      []converterKey
+	converters map[converterKey]ConverterFunc
+	// encoders are the converters of each type to one of the generic types,
+	// in the order they were registered.
+	encoders map[reflect.Type][]ConverterFunc
+}
+
+var (
+	registryLock sync.Mutex
+	registry     atomic.Pointer[converterRegistry]
+
+	genericTypes = map[reflect.Type]bool{
+		reflect.TypeOf(""):                       true,
+		reflect.TypeOf(false):                    true,
+		reflect.TypeOf(int64(0)):                 true,
+		reflect.TypeOf(float64(0)):               true,
+		reflect.TypeOf(json.Number("")):          true,
+		reflect.TypeOf(map[string]interface{}{}): true,
+		reflect.TypeOf([]interface{}{}):          true,
+	}
+)
+
+// Register teaches EncodeToMap and ToObj how to convert values of type from to
+// type to. Converters to string, bool, int64, float64, json.Number,
+// map[string]interface{} or []interface{} are used by EncodeToMap, the first
+// one registered wins. ToObj uses a converter when the data holds a value of
+// type from where the target has a value of type to. Registering the same
+// pair again replaces the converter.
+func Register(from, to reflect.Type, fn ConverterFunc) {
+	registryLock.Lock()
+	defer registryLock.Unlock()
+
+	key := converterKey{from: from, to: to}
+	next := &converterRegistry{
+		converters: map[converterKey]ConverterFunc{},
+		encoders:   map[reflect.Type][]ConverterFunc{},
+	}
+	if current := registry.Load(); current != nil {
+		next.order = slices.Clone(current.order)
+		maps.Copy(next.converters, current.converters)
+	}
+	if _, ok := next.converters[key]; !ok {
+		next.order = append(next.order, key)
+	}
+	next.converters[key] = fn
+
+	for _, key := range next.order {
+		if genericTypes[key.to] {
+			next.encoders[key.from] = append(next.encoders[key.from], next.converters[key])
+		}
+	}
+	registry.Store(next)
+}
+
+func lookupConverter(from, to reflect.Type) (ConverterFunc, bool) {
+	r := registry.Load()
+	if r == nil {
+		return nil, false
+	}
+	fn, ok := r.converters[converterKey{from: from, to: to}]
+	return fn, ok
+}
+
+func lookupEncoder(t reflect.Type) (ConverterFunc, bool) {
+	r := registry.Load()
+	if r == nil {
+		return nil, false
+	}
+	encoders := r.encoders[t]
+	if len(encoders) == 0 {
+		return nil, false
+	}
+	return encoders[0], true
+}
+
+var genericMapType = reflect.TypeOf(map[string]interface{}{})
+
+// decode sets v from data, applying registered converters on the way. Structs,
+// maps, slices and scalars are set directly using the cached field metadata,
+// everything else is decoded with encoding/json.
+// decoder sets Go values from generic data, see ToObj.
+type decoder struct {
+	guard  depthGuard
+	strict bool
+}
+
+func (d *decoder) decode(data interface{}, v reflect.Value) error {
+	if data == nil {
+		switch v.Kind() {
+		case reflect.Ptr, reflect.Map, reflect.Slice, reflect.Interface:
+			v.Set(reflect.Zero(v.Type()))
+		}
+		return nil
+	}
+
+	dataType := reflect.TypeOf(data)
+	if fn, ok := lookupConverter(dataType, v.Type()); ok {
+		return setConverted(fn, data, v)
+	}
+	if v.Kind() == reflect.Ptr {
+		if fn, ok := lookupConverter(dataType, v.Type().Elem()); ok {
+			if v.IsNil() {
+				v.Set(reflect.New(v.Type().Elem()))
+			}
+			return setConverted(fn, data, v.Elem())
+		}
+	}
+
+	if v.Type().Implements(jsonUnmarshalerType) || reflect.PtrTo(v.Type()).Implements(jsonUnmarshalerType) {
+		return decodeJSON(data, v)
+	}
+
+	switch data.(type) {
+	case map[string]interface{}, []interface{}:
+		if v.Kind() == reflect.Ptr {
+			// the data is entered when decoding into the element
+			break
+		}
+		dataValue := reflect.ValueOf(data)
+		if err := d.guard.enter(dataValue); err != nil {
+			return err
+		}
+		defer d.guard.leave(dataValue)
+	}
+
+	switch v.Kind() {
+	case reflect.Ptr:
+		if v.IsNil() {
+			v.Set(reflect.New(v.Type().Elem()))
+		}
+		return d.decode(data, v.Elem())
+	case reflect.Struct:
+		m, ok := toGenericMap(data)
+		if !ok {
+			break
+		}
+		_, err := d.decodeStruct(m, v)
+		if err == nil && d.strict {
+			err = unknownField(m, v.Type())
+		}
+		return err
+	case reflect.Map:
+		m, ok := toGenericMap(data)
+		if !ok || v.Type().Key().Kind() != reflect.String {
+			break
+		}
+		if v.IsNil() {
+			v.Set(reflect.MakeMapWithSize(v.Type(), len(m)))
+		}
+		var firstErr error
+		for key, value := range m {
+			elem := reflect.New(v.Type().Elem()).Elem()
+			if err := d.decode(value, elem); err != nil && firstErr == nil {
+				firstErr = withPath(err, key)
+			}
+			v.SetMapIndex(reflect.ValueOf(key).Convert(v.Type().Key()), elem)
+		}
+		return firstErr
+	case reflect.Slice:
+		items, ok := data.([]interface{})
+		if !ok {
+			break
+		}
+		slice := reflect.MakeSlice(v.Type(), len(items), len(items))
+		var firstErr error
+		for i, item := range items {
+			if err := d.decode(item, slice.Index(i)); err != nil && firstErr == nil {
+				firstErr = withPath(err, "["+strconv.Itoa(i)+"]")
+			}
+		}
+		v.Set(slice)
+		return firstErr
+	default:
+		if decodeScalar(data, v) {
+			return nil
+		}
+	}
+
+	return decodeJSON(data, v)
+}
+
+func toGenericMap(data interface{}) (map[string]interface{}, bool) {
+	if m, ok := data.(map[string]interface{}); ok {
+		return m, true
+	}
+	rv := reflect.ValueOf(data)
+	if rv.Kind() == reflect.Map && rv.Type().ConvertibleTo(genericMapType) {
+		return rv.Convert(genericMapType).Interface().(map[string]interface{}), true
+	}
+	return nil, false
+}
+
+// decodeScalar sets v if data has a type that maps directly to the kind of
+// v. It returns false for everything else, which is left to encoding/json so
+// it reports the errors.
+func decodeScalar(data interface{}, v reflect.Value) bool {
+	switch v.Kind() {
+	case reflect.String:
+		s, ok := data.(string)
+		if ok {
+			v.SetString(s)
+		}
+		return ok
+	case reflect.Bool:
+		b, ok := data.(bool)
+		if ok {
+			v.SetBool(b)
+		}
+		return ok
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		var i int64
+		switch n := data.(type) {
+		case json.Number:
+			parsed, err := strconv.ParseInt(string(n), 10, 64)
+			if err != nil {
+				return false
+			}
+			i = parsed
+		case int64:
+			i = n
+		case int:
+			i = int64(n)
+		default:
+			return false
+		}
+		if v.OverflowInt(i) {
+			return false
+		}
+		v.SetInt(i)
+		return true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		n, ok := data.(json.Number)
+		if !ok {
+			return false
+		}
+		u, err := strconv.ParseUint(string(n), 10, 64)
+		if err != nil || v.OverflowUint(u) {
+			return false
+		}
+		v.SetUint(u)
+		return true
+	case reflect.Float32, reflect.Float64:
+		var f float64
+		switch n := data.(type) {
+		case json.Number:
+			parsed, err := strconv.ParseFloat(string(n), v.Type().Bits())
+			if err != nil {
+				return false
+			}
+			f = parsed
+		case float64:
+			f = n
+		case int64:
+			f = float64(n)
+		default:
+			return false
+		}
+		if v.OverflowFloat(f) {
+			return false
+		}
+		v.SetFloat(f)
+		return true
+	}
+	return false
+}
+
+// decodeStruct sets the fields of v from m and returns true if m had a value
+// for any of them. Like encoding/json it continues after errors and returns
+// the first one.
+func (d *decoder) decodeStruct(m map[string]interface{}, v reflect.Value) (bool, error) {
+	var (
+		matched  bool
+		firstErr error
+	)
+	setErr := func(err error) {
+		if firstErr == nil {
+			firstErr = err
+		}
+	}
+	for _, field := range cachedFields(v.Type(), DefaultTagNames) {
+		fieldValue := v.Field(field.index)
+		if field.inline {
+			embedded := fieldValue.Type()
+			if embedded.Kind() == reflect.Ptr {
+				embedded = embedded.Elem()
+			}
+			if embedded.Kind() == reflect.Struct {
+				if fieldValue.Kind() != reflect.Ptr {
+					ok, err := d.decodeStruct(m, fieldValue)
+					if err != nil {
+						setErr(err)
+					}
+					matched = matched || ok
+					continue
+				}
+				if !fieldValue.CanSet() {
+					continue
+				}
+				target := fieldValue
+				if fieldValue.IsNil() {
+					target = reflect.New(embedded)
+				}
+				// embedded pointers are only allocated if any of their
+				// fields are set, like encoding/json does
+				ok, err := d.decodeStruct(m, target.Elem())
+				if err != nil {
+					setErr(err)
+				}
+				if ok && fieldValue.IsNil() {
+					fieldValue.Set(target)
+				}
+				matched = matched || ok
+				continue
+			}
+			if !field.exported {
+				continue
+			}
+		}
+
+		value, ok := m[field.name]
+		if !ok {
+			for key, v := range m {
+				if strings.EqualFold(key, field.name) {
+					value, ok = v, true
+					break
+				}
+			}
+		}
+		if !ok {
+			continue
+		}
+		matched = true
+		if s, isString := value.(string); isString && field.quotable {
+			dec := json.NewDecoder(strings.NewReader(s))
+			dec.UseNumber()
+			if err := dec.Decode(&value); err != nil {
+				setErr(withPath(conversionError(value, fieldValue.Type().String(), err), field.name))
+				continue
+			}
+		}
+		if err := d.decode(value, fieldValue); err != nil {
+			setErr(withPath(err, field.name))
+		}
+	}
+	return matched, firstErr
+}
+
+func setConverted(fn ConverterFunc, data interface{}, v reflect.Value) error {
+	out, err := fn(data)
+	if err != nil {
+		return err
+	}
+	result := reflect.ValueOf(out)
+	if !result.IsValid() {
+		v.Set(reflect.Zero(v.Type()))
+		return nil
+	}
+	if !result.Type().AssignableTo(v.Type()) {
+		return fmt.Errorf("converter from %T returned %T, expected %s", data, out, v.Type())
+	}
+	v.Set(result)
+	return nil
+}
+
+// unknownField returns an error for the first key of m that t has no field
+// for.
+func unknownField(m map[string]interface{}, t reflect.Type) error {
+	fields := map[string]reflect.Type{}
+	strictFields(t, fields)
+	for _, key := range sortedKeys(m) {
+		if _, ok := lookupField(fields, key); !ok {
+			return &ConversionError{Value: m[key], To: t.String(), Path: joinPath("", key), Err: ErrUnknownField}
+		}
+	}
+	return nil
+}
+
+func decodeJSON(data interface{}, v reflect.Value) error {
+	b, err := json.Marshal(data)
+	if err != nil {
+		return err
+	}
+	err = json.Unmarshal(b, v.Addr().Interface())
+	var typeErr *json.UnmarshalTypeError
+	if errors.As(err, &typeErr) && typeErr.Field == "" {
+		return conversionError(data, v.Type().String(), fmt.Errorf("expected %s, got %s", expectedKind(typeErr.Type), typeErr.Value))
+	}
+	return err
+}
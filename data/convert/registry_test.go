@@ -0,0 +1,106 @@
+package convert
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+type registryID struct {
+	value string
+}
+
+type registryObject struct {
+	ID   registryID   `json:"id"`
+	Refs []registryID `json:"refs"`
+	Name string       `json:"name"`
+}
+
+func TestRegister(t *testing.T) {
+	Register(reflect.TypeOf(registryID{}), reflect.TypeOf(""), func(value interface{}) (interface{}, error) {
+		return "id:" + value.(registryID).value, nil
+	})
+	Register(reflect.TypeOf(""), reflect.TypeOf(registryID{}), func(value interface{}) (interface{}, error) {
+		return registryID{value: strings.TrimPrefix(value.(string), "id:")}, nil
+	})
+
+	obj := registryObject{
+		ID:   registryID{value: "a"},
+		Refs: []registryID{{value: "b"}},
+		Name: "test",
+	}
+	m, err := EncodeToMap(obj)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if m["id"] != "id:a" || !reflect.DeepEqual(m["refs"], []interface{}{"id:b"}) {
+		t.Fatalf("unexpected encoding %v", m)
+	}
+
+	var result registryObject
+	if err := ToObj(m, &result); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(result, obj) {
+		t.Fatalf("expected %+v, got %+v", obj, result)
+	}
+}
+
+type decodeSpec struct {
+	Replicas int          `json:"replicas"`
+	Ports    []decodePort `json:"ports"`
+}
+
+type decodePort struct {
+	Port int32 `json:"port"`
+}
+
+type replicasDefaulter struct{}
+
+func (replicasDefaulter) Default(data map[string]interface{}) map[string]interface{} {
+	if _, ok := data["replicas"]; ok {
+		return data
+	}
+	result := map[string]interface{}{"replicas": int64(1)}
+	for k, v := range data {
+		result[k] = v
+	}
+	return result
+}
+
+func TestDecode(t *testing.T) {
+	data := map[string]interface{}{
+		"ports": []interface{}{map[string]interface{}{"port": int64(80)}},
+	}
+	spec, err := Decode[decodeSpec](data, DecodeOptions{Defaults: replicasDefaulter{}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if spec.Replicas != 1 || len(spec.Ports) != 1 || spec.Ports[0].Port != 80 {
+		t.Fatalf("unexpected result %+v", spec)
+	}
+	if _, ok := data["replicas"]; ok {
+		t.Fatal("defaults modified the input")
+	}
+
+	data = map[string]interface{}{
+		"ports": []interface{}{map[string]interface{}{"port": "http"}},
+	}
+	_, err = Decode[decodeSpec](data, DecodeOptions{})
+	var convErr *ConversionError
+	if !errors.As(err, &convErr) || convErr.Path != "ports[0].port" {
+		t.Fatalf("expected error for ports[0].port, got %v", err)
+	}
+
+	data = map[string]interface{}{
+		"ports": []interface{}{map[string]interface{}{"port": int64(80), "protocol": "TCP"}},
+	}
+	if _, err := Decode[decodeSpec](data, DecodeOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	_, err = Decode[decodeSpec](data, DecodeOptions{DisallowUnknownFields: true})
+	if !errors.Is(err, ErrUnknownField) || !errors.As(err, &convErr) || convErr.Path != "ports[0].protocol" {
+		t.Fatalf("expected unknown field ports[0].protocol, got %v", err)
+	}
+}
@@ -0,0 +1,156 @@
+package convert
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"unicode"
+)
+
+// SplitOptions configure how ToStringSliceWith splits strings.
+type SplitOptions struct {
+	// Separators are the characters that separate items, "," if empty. Use
+	// " \t\n" to split on whitespace.
+	Separators string
+	// Quotes are the characters that can quote items, so they can contain
+	// separators. The quotes are removed from the item.
+	Quotes string
+	// Escape makes the next character literal, inside and outside of
+	// quotes. Zero disables escaping.
+	Escape rune
+	// TrimSpace removes whitespace around unquoted parts of items.
+	TrimSpace bool
+	// KeepEmpty keeps empty items, which are dropped otherwise.
+	KeepEmpty bool
+}
+
+// DefaultSplitOptions split on commas, with single and double quotes and
+// backslash escapes.
+var DefaultSplitOptions = SplitOptions{
+	Separators: ",",
+	Quotes:     `"'`,
+	Escape:     '\\',
+	TrimSpace:  true,
+}
+
+// Split splits s into items according to opts.
+func Split(s string, opts SplitOptions) ([]string, error) {
+	separators := opts.Separators
+	if separators == "" {
+		separators = ","
+	}
+
+	var (
+		result  []string
+		item    strings.Builder
+		quote   rune
+		quoted  bool
+		escaped bool
+		// pending is whitespace that is only kept if more content follows
+		pending strings.Builder
+	)
+
+	write := func(r rune) {
+		if pending.Len() > 0 {
+			item.WriteString(pending.String())
+			pending.Reset()
+		}
+		item.WriteRune(r)
+	}
+	flush := func() {
+		pending.Reset()
+		if item.Len() > 0 || quoted || opts.KeepEmpty {
+			result = append(result, item.String())
+		}
+		item.Reset()
+		quoted = false
+	}
+
+	for _, r := range s {
+		switch {
+		case escaped:
+			write(r)
+			escaped = false
+		case opts.Escape != 0 && r == opts.Escape:
+			escaped = true
+		case quote != 0:
+			if r == quote {
+				quote = 0
+			} else {
+				write(r)
+			}
+		case strings.ContainsRune(opts.Quotes, r):
+			quote = r
+			quoted = true
+			if pending.Len() > 0 && item.Len() > 0 {
+				item.WriteString(pending.String())
+			}
+			pending.Reset()
+		case strings.ContainsRune(separators, r):
+			flush()
+		case opts.TrimSpace && unicode.IsSpace(r):
+			if item.Len() > 0 || quoted {
+				pending.WriteRune(r)
+			}
+		default:
+			write(r)
+		}
+	}
+
+	if escaped {
+		return nil, errors.New("trailing escape character")
+	}
+	if quote != 0 {
+		return nil, errors.New("unterminated quote")
+	}
+	flush()
+	return result, nil
+}
+
+// ToStringSliceWith converts data to a slice of strings. Strings are split
+// with Split, the items of slices are converted with ToString, flattening
+// nested slices.
+func ToStringSliceWith(data interface{}, opts SplitOptions) ([]string, error) {
+	switch v := data.(type) {
+	case nil:
+		return nil, nil
+	case string:
+		result, err := Split(v, opts)
+		if err != nil {
+			return nil, conversionError(v, "[]string", err)
+		}
+		return result, nil
+	case []byte:
+		return ToStringSliceWith(string(v), opts)
+	}
+
+	rv := reflect.ValueOf(data)
+	if rv.Kind() != reflect.Slice && rv.Kind() != reflect.Array {
+		return []string{ToString(data)}, nil
+	}
+
+	var result []string
+	appendItems(rv, opts.KeepEmpty, &result)
+	return result, nil
+}
+
+func appendItems(rv reflect.Value, keepEmpty bool, result *[]string) {
+	for i := 0; i < rv.Len(); i++ {
+		item := rv.Index(i)
+		for item.Kind() == reflect.Interface && !item.IsNil() {
+			item = item.Elem()
+		}
+		if (item.Kind() == reflect.Slice && item.Type().Elem().Kind() != reflect.Uint8) || item.Kind() == reflect.Array {
+			appendItems(item, keepEmpty, result)
+			continue
+		}
+
+		var str string
+		if item.IsValid() && !isNil(item) {
+			str = ToString(item.Interface())
+		}
+		if str != "" || keepEmpty {
+			*result = append(*result, str)
+		}
+	}
+}
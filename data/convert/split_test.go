@@ -0,0 +1,37 @@
+package convert
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestSplit(t *testing.T) {
+	for input, expected := range map[string][]string{
+		`a, b ,c`:                 {"a", "b", "c"},
+		`a,"b,c", 'd e' , x\,y`:   {"a", "b,c", "d e", "x,y"},
+		`"",a,,`:                  {"", "a"},
+		`key="quoted value",next`: {"key=quoted value", "next"},
+	} {
+		actual, err := Split(input, DefaultSplitOptions)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(actual, expected) {
+			t.Errorf("expected %q for %s, got %q", expected, input, actual)
+		}
+	}
+
+	if _, err := Split(`"open`, DefaultSplitOptions); err == nil {
+		t.Error("expected error for unterminated quote")
+	}
+}
+
+func TestToStringSliceWith(t *testing.T) {
+	actual, err := ToStringSliceWith([]interface{}{"a", 1, nil, true, []string{"b"}}, DefaultSplitOptions)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if expected := []string{"a", "1", "true", "b"}; !reflect.DeepEqual(actual, expected) {
+		t.Fatalf("expected %q, got %q", expected, actual)
+	}
+}
@@ -0,0 +1,94 @@
+package convert
+
+import (
+	"encoding"
+	"encoding/json"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+var (
+	jsonUnmarshalerType = reflect.TypeOf((*json.Unmarshaler)(nil)).Elem()
+	textUnmarshalerType = reflect.TypeOf((*encoding.TextUnmarshaler)(nil)).Elem()
+)
+
+// ToObjStrict converts data into the struct into like ToObj, but fails if data
+// contains fields that into has no field for or values of the wrong type. It
+// is ToObjWithOptions with DisallowUnknownFields, the error is a
+// ConversionError with the path of the field, like "spec.ports[0].port".
+func ToObjStrict(data interface{}, into interface{}) error {
+	return ToObjWithOptions(data, into, DecodeOptions{DisallowUnknownFields: true})
+}
+
+// strictFields collects the JSON names of the fields of t, including the
+// fields of embedded structs.
+func strictFields(t reflect.Type, fields map[string]reflect.Type) {
+	for _, field := range cachedFields(t, DefaultTagNames) {
+		fieldType := t.Field(field.index).Type
+		if field.inline {
+			embedded := fieldType
+			for embedded.Kind() == reflect.Ptr {
+				embedded = embedded.Elem()
+			}
+			if embedded.Kind() == reflect.Struct {
+				strictFields(embedded, fields)
+				continue
+			}
+			if !field.exported {
+				continue
+			}
+		}
+		if field.quotable {
+			fieldType = reflect.TypeOf("")
+		}
+		if _, ok := fields[field.name]; !ok {
+			fields[field.name] = fieldType
+		}
+	}
+}
+
+// lookupField finds the field for key, falling back to a case-insensitive
+// match like encoding/json does.
+func lookupField(fields map[string]reflect.Type, key string) (reflect.Type, bool) {
+	if t, ok := fields[key]; ok {
+		return t, true
+	}
+	for name, t := range fields {
+		if strings.EqualFold(name, key) {
+			return t, true
+		}
+	}
+	return nil, false
+}
+
+func expectedKind(t reflect.Type) string {
+	switch t.Kind() {
+	case reflect.Struct, reflect.Map:
+		return "object"
+	case reflect.Slice, reflect.Array:
+		return "array"
+	case reflect.Bool:
+		return "bool"
+	case reflect.String:
+		return "string"
+	}
+	return "number"
+}
+
+func joinPath(path, key string) string {
+	key = strings.ReplaceAll(key, ".", `\.`)
+	if path == "" {
+		return key
+	}
+	return path + "." + key
+}
+
+func sortedKeys(m map[string]interface{}) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,67 @@
+package convert
+
+import (
+	"errors"
+	"testing"
+)
+
+type strictPort struct {
+	Port int32 `json:"port"`
+}
+
+type strictSpec struct {
+	Name  string       `json:"name"`
+	Ports []strictPort `json:"ports"`
+}
+
+func TestToObjStrict(t *testing.T) {
+	var spec strictSpec
+	err := ToObjStrict(map[string]interface{}{
+		"name":  "test",
+		"ports": []interface{}{map[string]interface{}{"port": 80}},
+	}, &spec)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if spec.Name != "test" || len(spec.Ports) != 1 || spec.Ports[0].Port != 80 {
+		t.Fatalf("unexpected result %+v", spec)
+	}
+
+	tests := []struct {
+		name string
+		data map[string]interface{}
+		path string
+		err  error
+	}{
+		{
+			name: "wrong type",
+			data: map[string]interface{}{
+				"name":  1,
+				"ports": []interface{}{map[string]interface{}{"port": 80}},
+			},
+			path: "name",
+		},
+		{
+			name: "unknown field",
+			data: map[string]interface{}{
+				"name":  "test",
+				"ports": []interface{}{map[string]interface{}{"port": 80, "protocol": "tcp"}},
+			},
+			path: "ports[0].protocol",
+			err:  ErrUnknownField,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var spec strictSpec
+			err := ToObjStrict(tt.data, &spec)
+			var convErr *ConversionError
+			if !errors.As(err, &convErr) || convErr.Path != tt.path {
+				t.Fatalf("expected ConversionError for %s, got %v", tt.path, err)
+			}
+			if tt.err != nil && !errors.Is(err, tt.err) {
+				t.Errorf("expected %v, got %v", tt.err, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,76 @@
+package convert
+
+import (
+	"reflect"
+	"strings"
+)
+
+var DefaultTagNames = []string{"json"}
+
+// Tag is the parsed struct tag of a field.
+type Tag struct {
+	// Name is the name from the tag, empty if the tag has no name.
+	Name string
+	// TagName is the key of the tag that was used, empty if the field has
+	// none of the requested tags.
+	TagName   string
+	Skip      bool
+	OmitEmpty bool
+	// Inline is set for the ",inline" and ",squash" options of tags other
+	// than json, which doesn't support inlining named fields.
+	Inline bool
+	// String is set for the ",string" option, which encodes numbers and
+	// booleans as strings.
+	String bool
+	// Options are all options of the tag.
+	Options []string
+}
+
+// HasOption returns true if the tag has the option name.
+func (t Tag) HasOption(name string) bool {
+	for _, opt := range t.Options {
+		if opt == name {
+			return true
+		}
+	}
+	return false
+}
+
+// ParseTag reads the first of tagNames present on f. If tagNames is empty
+// DefaultTagNames is used.
+func ParseTag(f reflect.StructField, tagNames ...string) Tag {
+	if len(tagNames) == 0 {
+		tagNames = DefaultTagNames
+	}
+
+	for _, tagName := range tagNames {
+		value, ok := f.Tag.Lookup(tagName)
+		if !ok {
+			continue
+		}
+
+		// "-," names the field "-" like encoding/json
+		name, opts, hasOpts := strings.Cut(value, ",")
+		tag := Tag{
+			Name:    name,
+			TagName: tagName,
+			Skip:    name == "-" && !hasOpts,
+		}
+		if opts != "" {
+			tag.Options = strings.Split(opts, ",")
+		}
+		for _, opt := range tag.Options {
+			switch opt {
+			case "omitempty":
+				tag.OmitEmpty = true
+			case "inline", "squash":
+				tag.Inline = tagName != "json"
+			case "string":
+				tag.String = true
+			}
+		}
+		return tag
+	}
+
+	return Tag{}
+}
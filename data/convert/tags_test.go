@@ -0,0 +1,70 @@
+package convert
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+	"time"
+)
+
+type multiTagInner struct {
+	Port int `mapstructure:"port"`
+}
+
+type multiTagStruct struct {
+	Name    string        `yaml:"name" json:"jsonName"`
+	Count   int           `json:"count,omitempty"`
+	Skipped string        `yaml:"-"`
+	Inner   multiTagInner `mapstructure:",squash"`
+	Timeout time.Duration `yaml:"timeout"`
+	Untag   string
+}
+
+func TestParseTag(t *testing.T) {
+	field, _ := reflect.TypeOf(multiTagStruct{}).FieldByName("Name")
+	if tag := ParseTag(field); tag.Name != "jsonName" || tag.TagName != "json" {
+		t.Fatalf("expected the json tag by default, got %+v", tag)
+	}
+	if tag := ParseTag(field, "yaml", "json"); tag.Name != "name" || tag.TagName != "yaml" {
+		t.Fatalf("expected the yaml tag first, got %+v", tag)
+	}
+
+	field, _ = reflect.TypeOf(multiTagStruct{}).FieldByName("Inner")
+	if tag := ParseTag(field, "mapstructure"); !tag.Inline || tag.Name != "" {
+		t.Fatalf("expected squash to inline, got %+v", tag)
+	}
+
+	field, _ = reflect.TypeOf(multiTagStruct{}).FieldByName("Count")
+	if tag := ParseTag(field); !tag.OmitEmpty || !tag.HasOption("omitempty") || tag.HasOption("string") {
+		t.Fatalf("unexpected options %+v", tag)
+	}
+
+	field, _ = reflect.TypeOf(multiTagStruct{}).FieldByName("Untag")
+	if tag := ParseTag(field, "yaml"); !reflect.DeepEqual(tag, Tag{}) {
+		t.Fatalf("expected no tag, got %+v", tag)
+	}
+}
+
+func TestEncodeToMapWithTags(t *testing.T) {
+	obj := multiTagStruct{
+		Name:    "test",
+		Skipped: "skipped",
+		Inner:   multiTagInner{Port: 80},
+		Timeout: time.Second,
+		Untag:   "value",
+	}
+
+	actual, err := EncodeToMapWithTags(obj, "yaml", "mapstructure", "json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := map[string]interface{}{
+		"name":    "test",
+		"port":    json.Number("80"),
+		"timeout": json.Number("1000000000"),
+		"Untag":   "value",
+	}
+	if !reflect.DeepEqual(actual, expected) {
+		t.Fatalf("expected %#v, got %#v", expected, actual)
+	}
+}
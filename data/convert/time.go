@@ -0,0 +1,107 @@
+package convert
+
+import (
+	"errors"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ToTime converts value to a time.Time. Strings are parsed as RFC3339, numbers
+// and numeric strings are Unix timestamps in seconds.
+func ToTime(value interface{}) (time.Time, error) {
+	value = Singular(value)
+
+	switch v := value.(type) {
+	case time.Time:
+		return v, nil
+	case *time.Time:
+		if v == nil {
+			return time.Time{}, nil
+		}
+		return *v, nil
+	case metav1.Time:
+		return v.Time, nil
+	case *metav1.Time:
+		if v == nil {
+			return time.Time{}, nil
+		}
+		return v.Time, nil
+	case nil:
+		return time.Time{}, nil
+	}
+
+	str := strings.TrimSpace(ToString(value))
+	if str == "" {
+		return time.Time{}, nil
+	}
+	if t, err := time.Parse(time.RFC3339Nano, str); err == nil {
+		return t, nil
+	}
+	seconds, err := strconv.ParseFloat(str, 64)
+	if err != nil || math.IsNaN(seconds) || math.IsInf(seconds, 0) {
+		return time.Time{}, conversionError(value, "time", errors.New("expected RFC3339 or a Unix timestamp"))
+	}
+	whole, frac := math.Modf(seconds)
+	return time.Unix(int64(whole), int64(frac*float64(time.Second))).UTC(), nil
+}
+
+// ToMetaTime converts value to a metav1.Time, see ToTime.
+func ToMetaTime(value interface{}) (metav1.Time, error) {
+	t, err := ToTime(value)
+	return metav1.NewTime(t), err
+}
+
+// FormatTime is the inverse of ToTime, it returns t as RFC3339 in UTC or an
+// empty string for the zero time.
+func FormatTime(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.UTC().Format(time.RFC3339Nano)
+}
+
+// ToDuration converts value to a time.Duration. Strings are parsed as Go
+// durations like "1m30s", numbers and numeric strings are seconds.
+func ToDuration(value interface{}) (time.Duration, error) {
+	value = Singular(value)
+
+	switch v := value.(type) {
+	case time.Duration:
+		return v, nil
+	case metav1.Duration:
+		return v.Duration, nil
+	case *metav1.Duration:
+		if v == nil {
+			return 0, nil
+		}
+		return v.Duration, nil
+	case nil:
+		return 0, nil
+	}
+
+	str := strings.TrimSpace(ToString(value))
+	if str == "" {
+		return 0, nil
+	}
+	if d, err := time.ParseDuration(str); err == nil {
+		return d, nil
+	}
+	seconds, err := strconv.ParseFloat(str, 64)
+	if err != nil || math.IsNaN(seconds) {
+		return 0, conversionError(value, "duration", errors.New("expected a duration like 1m30s or seconds"))
+	}
+	if math.Abs(seconds) > math.MaxInt64/float64(time.Second) {
+		return 0, conversionError(value, "duration", errors.New("out of range"))
+	}
+	return time.Duration(seconds * float64(time.Second)), nil
+}
+
+// FormatDuration is the inverse of ToDuration, it returns d as a Go duration
+// string.
+func FormatDuration(d time.Duration) string {
+	return d.String()
+}
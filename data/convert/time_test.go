@@ -0,0 +1,116 @@
+package convert
+
+import (
+	"encoding/json"
+	"errors"
+	"math"
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestToTime(t *testing.T) {
+	expected := time.Date(2023, 11, 14, 22, 13, 20, 0, time.UTC)
+	tests := []struct {
+		name     string
+		value    interface{}
+		expected time.Time
+	}{
+		{name: "RFC3339", value: "2023-11-14T22:13:20Z", expected: expected},
+		{name: "RFC3339 offset", value: "2023-11-14T23:13:20+01:00", expected: expected},
+		{name: "RFC3339 fractional", value: "2023-11-14T22:13:20.25Z", expected: expected.Add(250 * time.Millisecond)},
+		{name: "Unix seconds", value: int64(1700000000), expected: expected},
+		{name: "Unix seconds string", value: "1700000000", expected: expected},
+		{name: "Unix seconds json.Number", value: json.Number("1700000000"), expected: expected},
+		{name: "Unix fractional seconds", value: 1700000000.5, expected: expected.Add(500 * time.Millisecond)},
+		// numbers are always seconds, milliseconds are not guessed
+		{name: "Unix milliseconds", value: int64(1700000000000), expected: time.Unix(1700000000000, 0).UTC()},
+		{name: "time.Time", value: expected, expected: expected},
+		{name: "metav1.Time", value: metav1.NewTime(expected), expected: expected},
+		{name: "slice", value: []interface{}{"2023-11-14T22:13:20Z"}, expected: expected},
+		{name: "empty", value: ""},
+		{name: "nil", value: nil},
+		{name: "nil pointer", value: (*metav1.Time)(nil)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			v, err := ToTime(tt.value)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !v.Equal(tt.expected) {
+				t.Fatalf("expected %v, got %v", tt.expected, v)
+			}
+		})
+	}
+}
+
+func TestToTimeInvalid(t *testing.T) {
+	for _, value := range []interface{}{"yesterday", "2023-11-14", "2023-11-14 22:13:20", "NaN", math.Inf(1), true} {
+		_, err := ToTime(value)
+		var convErr *ConversionError
+		if !errors.As(err, &convErr) {
+			t.Errorf("%#v: expected a conversion error, got %v", value, err)
+		}
+	}
+}
+
+func TestFormatTime(t *testing.T) {
+	if s := FormatTime(time.Time{}); s != "" {
+		t.Fatalf("expected an empty string, got %q", s)
+	}
+
+	local := time.Date(2023, 11, 14, 23, 13, 20, 250000000, time.FixedZone("CET", 3600))
+	s := FormatTime(local)
+	if s != "2023-11-14T22:13:20.25Z" {
+		t.Fatalf("expected UTC, got %q", s)
+	}
+	if v, err := ToTime(s); err != nil || !v.Equal(local) {
+		t.Fatalf("expected %v, got %v %v", local, v, err)
+	}
+
+	m, err := ToMetaTime(s)
+	if err != nil || !m.Time.Equal(local) {
+		t.Fatalf("expected %v, got %v %v", local, m, err)
+	}
+}
+
+func TestToDuration(t *testing.T) {
+	tests := []struct {
+		value    interface{}
+		expected time.Duration
+	}{
+		{value: "1m30s", expected: 90 * time.Second},
+		{value: "-1.5h", expected: -90 * time.Minute},
+		{value: "90", expected: 90 * time.Second},
+		{value: int64(90), expected: 90 * time.Second},
+		{value: json.Number("0.5"), expected: 500 * time.Millisecond},
+		{value: 1.5, expected: 1500 * time.Millisecond},
+		{value: metav1.Duration{Duration: time.Minute}, expected: time.Minute},
+		{value: time.Hour, expected: time.Hour},
+		{value: ""},
+		{value: nil},
+	}
+
+	for _, tt := range tests {
+		d, err := ToDuration(tt.value)
+		if err != nil {
+			t.Errorf("%#v: %v", tt.value, err)
+		} else if d != tt.expected {
+			t.Errorf("%#v: expected %v, got %v", tt.value, tt.expected, d)
+		}
+		if d, err := ToDuration(FormatDuration(tt.expected)); err != nil || d != tt.expected {
+			t.Errorf("%v: expected a round trip, got %v %v", tt.expected, d, err)
+		}
+	}
+
+	for _, value := range []interface{}{"soon", "1x", "NaN", json.Number("1e300"), math.Inf(-1)} {
+		_, err := ToDuration(value)
+		var convErr *ConversionError
+		if !errors.As(err, &convErr) {
+			t.Errorf("%#v: expected a conversion error, got %v", value, err)
+		}
+	}
+}
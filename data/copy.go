@@ -0,0 +1,102 @@
+package data
+
+// DeepCopy returns a copy of o that shares no maps or slices with o.
+func (o Object) DeepCopy() Object {
+	if o == nil {
+		return nil
+	}
+	return copyValue(o).(Object)
+}
+
+// DeepCopy returns a copy of l that shares no maps or slices with l.
+func (l List) DeepCopy() List {
+	if l == nil {
+		return nil
+	}
+	return copyValue(l).(List)
+}
+
+// DeepCopyValue copies maps and slices nested in v, as found in decoded JSON
+// and YAML, the types are preserved. Other values are returned as is.
+func DeepCopyValue(v interface{}) interface{} {
+	return copyValue(v)
+}
+
+func copyValue(v interface{}) interface{} {
+	switch t := v.(type) {
+	case map[string]interface{}:
+		if t == nil {
+			return t
+		}
+		result := make(map[string]interface{}, len(t))
+		for k, v := range t {
+			result[k] = copyValue(v)
+		}
+		return result
+	case Object:
+		if t == nil {
+			return t
+		}
+		result := make(Object, len(t))
+		for k, v := range t {
+			result[k] = copyValue(v)
+		}
+		return result
+	case map[string]string:
+		if t == nil {
+			return t
+		}
+		result := make(map[string]string, len(t))
+		for k, v := range t {
+			result[k] = v
+		}
+		return result
+	case []interface{}:
+		if t == nil {
+			return t
+		}
+		result := make([]interface{}, len(t))
+		for i, v := range t {
+			result[i] = copyValue(v)
+		}
+		return result
+	case []map[string]interface{}:
+		if t == nil {
+			return t
+		}
+		result := make([]map[string]interface{}, len(t))
+		for i, v := range t {
+			result[i], _ = copyValue(v).(map[string]interface{})
+		}
+		return result
+	case List:
+		if t == nil {
+			return t
+		}
+		result := make(List, len(t))
+		for i, v := range t {
+			result[i], _ = copyValue(v).(Object)
+		}
+		return result
+	case []Object:
+		if t == nil {
+			return t
+		}
+		result := make([]Object, len(t))
+		for i, v := range t {
+			result[i], _ = copyValue(v).(Object)
+		}
+		return result
+	case []string:
+		if t == nil {
+			return t
+		}
+		return append([]string{}, t...)
+	case []byte:
+		if t == nil {
+			return t
+		}
+		return append([]byte{}, t...)
+	}
+	return v
+}
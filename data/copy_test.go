@@ -0,0 +1,66 @@
+package data
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestDeepCopy(t *testing.T) {
+	obj := Object{
+		"map":     map[string]interface{}{"a": []interface{}{"b"}},
+		"object":  Object{"c": "d"},
+		"strings": map[string]string{"e": "f"},
+		"list":    []interface{}{map[string]interface{}{"g": "h"}},
+		"maps":    []map[string]interface{}{{"i": "j"}},
+		"objects": []Object{{"k": "l"}},
+		"slice":   []string{"m"},
+		"bytes":   []byte("n"),
+		"nilMap":  map[string]interface{}(nil),
+		"number":  int64(1),
+	}
+
+	result := obj.DeepCopy()
+	if !reflect.DeepEqual(result, obj) {
+		t.Fatalf("expected %v, got %v", obj, result)
+	}
+
+	// changing the copy must not change the original
+	result["map"].(map[string]interface{})["a"].([]interface{})[0] = "x"
+	result["object"].(Object)["c"] = "x"
+	result["strings"].(map[string]string)["e"] = "x"
+	result["list"].([]interface{})[0].(map[string]interface{})["g"] = "x"
+	result["maps"].([]map[string]interface{})[0]["i"] = "x"
+	result["objects"].([]Object)[0]["k"] = "x"
+	result["slice"].([]string)[0] = "x"
+	result["bytes"].([]byte)[0] = 'x'
+
+	expected := Object{
+		"map":     map[string]interface{}{"a": []interface{}{"b"}},
+		"object":  Object{"c": "d"},
+		"strings": map[string]string{"e": "f"},
+		"list":    []interface{}{map[string]interface{}{"g": "h"}},
+		"maps":    []map[string]interface{}{{"i": "j"}},
+		"objects": []Object{{"k": "l"}},
+		"slice":   []string{"m"},
+		"bytes":   []byte("n"),
+		"nilMap":  map[string]interface{}(nil),
+		"number":  int64(1),
+	}
+	if !reflect.DeepEqual(obj, expected) {
+		t.Fatalf("expected the original to be unchanged, got %v", obj)
+	}
+
+	var nilObject Object
+	if nilObject.DeepCopy() != nil {
+		t.Fatal("expected nil for a nil object")
+	}
+}
+
+func TestListDeepCopy(t *testing.T) {
+	list := List{{"a": map[string]interface{}{"b": "c"}}}
+	result := list.DeepCopy()
+	result[0]["a"].(map[string]interface{})["b"] = "x"
+	if list[0].String("a", "b") != "c" {
+		t.Fatalf("expected the original to be unchanged, got %v", list)
+	}
+}
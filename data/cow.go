@@ -0,0 +1,194 @@
+package data
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// COWObject is a copy-on-write view of a shared Object. Reads return the
+// shared values, while Set and Remove copy only the maps and slices along the
+// modified path the first time they are touched, so the shared object is
+// never modified.
+type COWObject struct {
+	obj map[string]interface{}
+	// owned holds the maps and slices copied by this COWObject by address.
+	// The values are kept so a copy that was removed again can not be freed
+	// and its address reused by a value passed to Set.
+	owned map[uintptr]interface{}
+}
+
+func NewCOW(obj Object) *COWObject {
+	if obj == nil {
+		obj = Object{}
+	}
+	return &COWObject{
+		obj:   obj,
+		owned: map[uintptr]interface{}{},
+	}
+}
+
+// Get returns the value at path, see Object.GetPath. The value may be shared
+// and must not be modified.
+func (c *COWObject) Get(path string) (interface{}, bool) {
+	return Object(c.obj).GetPath(path)
+}
+
+// Object returns the current state. Unmodified branches are shared with the
+// original object, so the result must be treated as read-only.
+func (c *COWObject) Object() Object {
+	return c.obj
+}
+
+// Set sets value at path, see Object.SetPath.
+func (c *COWObject) Set(path string, value interface{}) error {
+	parts, err := parsePath(path)
+	if err != nil {
+		return err
+	}
+	if parts[0].isIndex {
+		return fmt.Errorf("invalid path %q: must start with a key", path)
+	}
+
+	result, err := c.set(c.obj, parts, value)
+	if err != nil {
+		return fmt.Errorf("failed to set %s: %w", path, err)
+	}
+	c.obj = result.(map[string]interface{})
+	return nil
+}
+
+// Remove removes the value at path and returns it, see Object.RemovePath.
+func (c *COWObject) Remove(path string) (interface{}, bool) {
+	if _, ok := c.Get(path); !ok {
+		return nil, false
+	}
+
+	parts, err := parsePath(path)
+	if err != nil {
+		return nil, false
+	}
+
+	// copy the parents of the value, then remove it from the copies
+	c.obj = c.own(c.obj).(map[string]interface{})
+	var (
+		current    interface{} = c.obj
+		parent     interface{}
+		parentPart pathPart
+	)
+	for _, part := range parts[:len(parts)-1] {
+		child := c.own(getChild(current, part))
+		setChild(current, part, child)
+		parent, parentPart, current = current, part, child
+	}
+
+	last := parts[len(parts)-1]
+	if !last.isIndex {
+		m, _ := asMap(current)
+		v := m[last.key]
+		delete(m, last.key)
+		return v, true
+	}
+
+	items := current.([]interface{})
+	v := items[last.index]
+	setChild(parent, parentPart, append(items[:last.index], items[last.index+1:]...))
+	return v, true
+}
+
+func getChild(v interface{}, part pathPart) interface{} {
+	if part.isIndex {
+		return v.([]interface{})[part.index]
+	}
+	m, _ := asMap(v)
+	return m[part.key]
+}
+
+func (c *COWObject) set(current interface{}, parts []pathPart, value interface{}) (interface{}, error) {
+	if len(parts) == 0 {
+		return value, nil
+	}
+
+	part := parts[0]
+	if part.isIndex {
+		if current == nil {
+			current = []interface{}{}
+		}
+		if _, ok := current.([]interface{}); !ok {
+			return nil, fmt.Errorf("%s is indexed but is not a slice", part)
+		}
+		items := c.own(current).([]interface{})
+		switch {
+		case part.index < len(items):
+			v, err := c.set(items[part.index], parts[1:], value)
+			if err != nil {
+				return nil, err
+			}
+			items[part.index] = v
+		case part.index == len(items):
+			v, err := c.set(nil, parts[1:], value)
+			if err != nil {
+				return nil, err
+			}
+			items = append(items, v)
+			c.owned[reflect.ValueOf(items).Pointer()] = items
+		default:
+			return nil, fmt.Errorf("index %s is out of range", part)
+		}
+		return items, nil
+	}
+
+	if current == nil {
+		current = map[string]interface{}{}
+	}
+	if _, ok := asMap(current); !ok {
+		return nil, fmt.Errorf("can not set key %s on a value that is not an object", part)
+	}
+	m, _ := asMap(c.own(current))
+	v, err := c.set(m[part.key], parts[1:], value)
+	if err != nil {
+		return nil, err
+	}
+	m[part.key] = v
+	return m, nil
+}
+
+// own returns a shallow copy of the map or slice v, unless v was created by
+// this COWObject already.
+func (c *COWObject) own(v interface{}) interface{} {
+	var ptr uintptr
+	switch t := v.(type) {
+	case map[string]interface{}, Object:
+		ptr = reflect.ValueOf(t).Pointer()
+	case []interface{}:
+		if cap(t) == 0 {
+			return []interface{}{}
+		}
+		ptr = reflect.ValueOf(t).Pointer()
+	default:
+		return v
+	}
+
+	if _, ok := c.owned[ptr]; ok {
+		return v
+	}
+
+	var result interface{}
+	switch t := v.(type) {
+	case map[string]interface{}:
+		m := make(map[string]interface{}, len(t))
+		for k, v := range t {
+			m[k] = v
+		}
+		result = m
+	case Object:
+		m := make(map[string]interface{}, len(t))
+		for k, v := range t {
+			m[k] = v
+		}
+		result = m
+	case []interface{}:
+		result = append([]interface{}{}, t...)
+	}
+	c.owned[reflect.ValueOf(result).Pointer()] = result
+	return result
+}
@@ -0,0 +1,139 @@
+package data
+
+import (
+	"reflect"
+	"runtime"
+	"testing"
+)
+
+func sharedObject() Object {
+	return Object{
+		"metadata": map[string]interface{}{
+			"name":   "app",
+			"labels": map[string]interface{}{"app": "web"},
+		},
+		"spec": Object{
+			"containers": []interface{}{
+				map[string]interface{}{"name": "a"},
+				map[string]interface{}{"name": "b"},
+			},
+		},
+	}
+}
+
+func TestCOWDoesNotModifyShared(t *testing.T) {
+	shared := sharedObject()
+	cow := NewCOW(shared)
+
+	for path, value := range map[string]interface{}{
+		"metadata.labels.tier":     "frontend",
+		"spec.containers[0].image": "nginx",
+		"spec.containers[2].name":  "c",
+		"status.ready":             true,
+	} {
+		if err := cow.Set(path, value); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if _, ok := cow.Remove("spec.containers[1]"); !ok {
+		t.Fatal("expected spec.containers[1] to be removed")
+	}
+	if _, ok := cow.Remove("metadata.name"); !ok {
+		t.Fatal("expected metadata.name to be removed")
+	}
+
+	if !reflect.DeepEqual(shared, sharedObject()) {
+		t.Fatalf("shared object was modified: %v", shared)
+	}
+
+	expected := Object{
+		"metadata": map[string]interface{}{
+			"labels": map[string]interface{}{"app": "web", "tier": "frontend"},
+		},
+		"spec": map[string]interface{}{
+			"containers": []interface{}{
+				map[string]interface{}{"name": "a", "image": "nginx"},
+				map[string]interface{}{"name": "c"},
+			},
+		},
+		"status": map[string]interface{}{"ready": true},
+	}
+	if !reflect.DeepEqual(cow.Object(), expected) {
+		t.Fatalf("expected %v, got %v", expected, cow.Object())
+	}
+}
+
+func TestCOWCopiesOnce(t *testing.T) {
+	shared := sharedObject()
+	cow := NewCOW(shared)
+
+	if err := cow.Set("metadata.labels.a", "1"); err != nil {
+		t.Fatal(err)
+	}
+	labels, _ := cow.Get("metadata.labels")
+	if err := cow.Set("metadata.labels.b", "2"); err != nil {
+		t.Fatal(err)
+	}
+	again, _ := cow.Get("metadata.labels")
+	if reflect.ValueOf(labels).Pointer() != reflect.ValueOf(again).Pointer() {
+		t.Fatal("expected an owned map to be modified in place")
+	}
+
+	// untouched branches stay shared
+	spec, _ := cow.Get("spec")
+	if reflect.ValueOf(spec).Pointer() != reflect.ValueOf(shared["spec"]).Pointer() {
+		t.Fatal("expected spec to be shared")
+	}
+}
+
+func TestCOWDoesNotModifySetValues(t *testing.T) {
+	cow := NewCOW(nil)
+
+	value := map[string]interface{}{"a": "1"}
+	if err := cow.Set("value", value); err != nil {
+		t.Fatal(err)
+	}
+	if err := cow.Set("value.b", "2"); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(value, map[string]interface{}{"a": "1"}) {
+		t.Fatalf("value passed to Set was modified: %v", value)
+	}
+
+	// values created by the COWObject and removed again must not make values
+	// set later look owned
+	for i := 0; i < 100; i++ {
+		if err := cow.Set("tmp.key", i); err != nil {
+			t.Fatal(err)
+		}
+		cow.Remove("tmp")
+		runtime.GC()
+		value := map[string]interface{}{}
+		if err := cow.Set("next", value); err != nil {
+			t.Fatal(err)
+		}
+		if err := cow.Set("next.key", i); err != nil {
+			t.Fatal(err)
+		}
+		if len(value) != 0 {
+			t.Fatalf("value passed to Set was modified: %v", value)
+		}
+	}
+}
+
+func TestCOWErrors(t *testing.T) {
+	cow := NewCOW(sharedObject())
+	for path, expected := range map[string]string{
+		"[0]":                     `invalid path "[0]": must start with a key`,
+		"metadata.name.first":     "failed to set metadata.name.first: can not set key first on a value that is not an object",
+		"metadata.labels[0]":      "failed to set metadata.labels[0]: [0] is indexed but is not a slice",
+		"spec.containers[5].name": "failed to set spec.containers[5].name: index [5] is out of range",
+	} {
+		if err := cow.Set(path, "x"); err == nil || err.Error() != expected {
+			t.Errorf("expected error %q for %s, got %v", expected, path, err)
+		}
+	}
+	if _, ok := cow.Remove("missing.key"); ok {
+		t.Error("expected nothing to be removed")
+	}
+}
@@ -4,7 +4,7 @@ import (
 	"github.com/acorn-io/schemer/data/convert"
 )
 
-type List []map[string]interface{}
+type List []Object
 
 type Object map[string]interface{}
 
@@ -14,7 +14,7 @@ func (o Object) Map(names ...string) Object {
 	return m
 }
 
-func (o Object) Slice(names ...string) (result []Object) {
+func (o Object) Slice(names ...string) (result List) {
 	v := GetValueN(o, names...)
 	for _, item := range convert.ToInterfaceSlice(v) {
 		result = append(result, convert.ToMapInterface(item))
@@ -46,10 +46,29 @@ func (o Object) Set(key string, obj interface{}) {
 	o[key] = obj
 }
 
+// SetNested sets obj at the nested keys, missing parents are created.
 func (o Object) SetNested(obj interface{}, key ...string) {
 	PutValue(o, obj, key...)
 }
 
+// SetNestedSlice sets a copy of value at the nested keys, like SetNested.
+func (o Object) SetNestedSlice(value []interface{}, key ...string) {
+	PutValue(o, copyValue(value), key...)
+}
+
+// SetNestedStringSlice sets value at the nested keys as a []interface{}, the
+// form the slice has after decoding, so the other accessors can read it.
+func (o Object) SetNestedStringSlice(value []string, key ...string) {
+	var items []interface{}
+	if value != nil {
+		items = make([]interface{}, len(value))
+		for i, v := range value {
+			items[i] = v
+		}
+	}
+	PutValue(o, items, key...)
+}
+
 func (o Object) Bool(key ...string) bool {
 	return convert.ToBool(GetValueN(o, key...))
 }
@@ -0,0 +1,168 @@
+package data
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+)
+
+// Decoder reads Objects one at a time from a stream of JSON, so large
+// documents can be processed without holding them in memory. The stream can
+// be a sequence of objects, like NDJSON or a watch stream, or a JSON array of
+// objects. With Items set, the objects in the array under that key of each
+// top level object are returned instead, like the items of a Kubernetes list.
+type Decoder struct {
+	// Items is the key of the array of objects in each top level object that
+	// is streamed instead of the top level objects.
+	Items string
+
+	dec *json.Decoder
+	// inArray is set while reading the elements of an array
+	inArray bool
+	// inObject is set while reading the remaining keys of an object after its
+	// items array
+	inObject bool
+}
+
+// NewDecoder returns a Decoder reading from r.
+func NewDecoder(r io.Reader) *Decoder {
+	dec := json.NewDecoder(r)
+	dec.UseNumber()
+	return &Decoder{
+		dec: dec,
+	}
+}
+
+// Decode returns the next Object of the stream, or io.EOF at the end of it.
+// The signature matches the next function of Schemas.MapList.
+func (d *Decoder) Decode() (Object, error) {
+	for {
+		if d.inArray {
+			if d.dec.More() {
+				return d.decodeObject()
+			}
+			if err := d.expectDelim(']'); err != nil {
+				return nil, err
+			}
+			d.inArray = false
+			continue
+		}
+
+		if d.inObject {
+			if err := d.skipToItems(); err != nil {
+				return nil, err
+			}
+			continue
+		}
+
+		token, err := d.dec.Token()
+		if err != nil {
+			return nil, err
+		}
+		switch token {
+		case json.Delim('['):
+			d.inArray = true
+		case json.Delim('{'):
+			if d.Items == "" {
+				return d.decodeRest()
+			}
+			d.inObject = true
+		case nil:
+			// skip null values in the stream
+		default:
+			return nil, fmt.Errorf("expected object or array, got %v", token)
+		}
+	}
+}
+
+// DecodeEach calls fn for each Object in the stream until the end of it or fn
+// returns an error.
+func (d *Decoder) DecodeEach(fn func(Object) error) error {
+	for {
+		obj, err := d.Decode()
+		if errors.Is(err, io.EOF) {
+			return nil
+		} else if err != nil {
+			return err
+		}
+		if err := fn(obj); err != nil {
+			return err
+		}
+	}
+}
+
+// skipToItems reads the keys of the current object, discarding their values,
+// until it reaches the Items array or the end of the object.
+func (d *Decoder) skipToItems() error {
+	for d.dec.More() {
+		token, err := d.dec.Token()
+		if err != nil {
+			return err
+		}
+		if token == d.Items {
+			token, err := d.dec.Token()
+			if err != nil {
+				return err
+			}
+			switch token {
+			case json.Delim('['):
+				d.inArray = true
+				return nil
+			case nil:
+				// a list without items is encoded with null items
+				continue
+			}
+			return fmt.Errorf("expected %v, got %v", json.Delim('['), token)
+		}
+		var ignored json.RawMessage
+		if err := d.dec.Decode(&ignored); err != nil {
+			return err
+		}
+	}
+	d.inObject = false
+	return d.expectDelim('}')
+}
+
+func (d *Decoder) decodeObject() (Object, error) {
+	var obj map[string]interface{}
+	if err := d.dec.Decode(&obj); err != nil {
+		return nil, err
+	}
+	return obj, nil
+}
+
+// decodeRest decodes the keys of an object whose opening brace was already
+// read.
+func (d *Decoder) decodeRest() (Object, error) {
+	obj := Object{}
+	for d.dec.More() {
+		token, err := d.dec.Token()
+		if err != nil {
+			return nil, err
+		}
+		key, ok := token.(string)
+		if !ok {
+			return nil, fmt.Errorf("expected key, got %v", token)
+		}
+		var value interface{}
+		if err := d.dec.Decode(&value); err != nil {
+			return nil, err
+		}
+		obj[key] = value
+	}
+	return obj, d.expectDelim('}')
+}
+
+func (d *Decoder) expectDelim(delim json.Delim) error {
+	token, err := d.dec.Token()
+	if errors.Is(err, io.EOF) {
+		return io.ErrUnexpectedEOF
+	} else if err != nil {
+		return err
+	}
+	if token != delim {
+		return fmt.Errorf("expected %v, got %v", delim, token)
+	}
+	return nil
+}
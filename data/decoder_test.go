@@ -0,0 +1,131 @@
+package data
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func decodeAll(t *testing.T, d *Decoder) ([]Object, error) {
+	t.Helper()
+	var result []Object
+	err := d.DecodeEach(func(obj Object) error {
+		result = append(result, obj)
+		return nil
+	})
+	return result, err
+}
+
+func TestDecoder(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		items    string
+		expected []Object
+	}{
+		{
+			name:  "ndjson",
+			input: "{\"a\":1}\n{\"b\":{\"c\":[1,2]}}\nnull\n{}\n",
+			expected: []Object{
+				{"a": json.Number("1")},
+				{"b": map[string]interface{}{"c": []interface{}{json.Number("1"), json.Number("2")}}},
+				{},
+			},
+		},
+		{
+			name:     "array",
+			input:    `[{"a":1},{"a":2}] {"a":3}`,
+			expected: []Object{{"a": json.Number("1")}, {"a": json.Number("2")}, {"a": json.Number("3")}},
+		},
+		{
+			name:  "list items",
+			input: `{"kind":"List","items":[{"a":1},{"a":2}],"metadata":{}} {"items":[{"a":3}]}`,
+			items: "items",
+			expected: []Object{
+				{"a": json.Number("1")},
+				{"a": json.Number("2")},
+				{"a": json.Number("3")},
+			},
+		},
+		{
+			name:     "list with null items",
+			input:    `{"kind":"List","items":null} {"items":[],"other":1} {"items":[{"a":1}]}`,
+			items:    "items",
+			expected: []Object{{"a": json.Number("1")}},
+		},
+		{
+			name:  "empty",
+			input: " \n",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			d := NewDecoder(strings.NewReader(test.input))
+			d.Items = test.items
+			result, err := decodeAll(t, d)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(result, test.expected) {
+				t.Fatalf("expected %v, got %v", test.expected, result)
+			}
+		})
+	}
+}
+
+func TestDecoderErrors(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		items    string
+		expected string
+	}{
+		{
+			name:     "scalar",
+			input:    `{"a":1} 1`,
+			expected: "expected object or array, got 1",
+		},
+		{
+			name:     "truncated object",
+			input:    `{"a":1`,
+			expected: "unexpected end of JSON input",
+		},
+		{
+			name:     "truncated array",
+			input:    `[{"a":1}`,
+			expected: "unexpected end of JSON input",
+		},
+		{
+			name:     "items not an array",
+			input:    `{"items":{"a":1}}`,
+			items:    "items",
+			expected: "expected [, got {",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			d := NewDecoder(strings.NewReader(test.input))
+			d.Items = test.items
+			_, err := decodeAll(t, d)
+			if err == nil || err.Error() != test.expected {
+				t.Fatalf("expected error %q, got %v", test.expected, err)
+			}
+		})
+	}
+}
+
+func TestDecoderEachError(t *testing.T) {
+	stop := errors.New("stop")
+	count := 0
+	err := NewDecoder(strings.NewReader(`{} {} {}`)).DecodeEach(func(Object) error {
+		count++
+		return stop
+	})
+	if !errors.Is(err, stop) || count != 1 {
+		t.Fatalf("expected to stop after one object, got %d %v", count, err)
+	}
+}
@@ -0,0 +1,75 @@
+package data
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// RedactFunc returns the value to print in place of value at path.
+type RedactFunc func(path string, value interface{}) interface{}
+
+type DiffStringOption func(*diffStringOptions)
+
+type diffStringOptions struct {
+	redact []RedactFunc
+}
+
+// WithRedaction passes every printed value through fn, for example to hide
+// secrets.
+func WithRedaction(fn RedactFunc) DiffStringOption {
+	return func(o *diffStringOptions) {
+		o.redact = append(o.redact, fn)
+	}
+}
+
+// DiffString renders the differences between old and new, one line per leaf
+// value sorted by path. Removed values are prefixed with "-", added values
+// with "+" and changed values are printed as a removal followed by an
+// addition. The result is empty if both are equal.
+func DiffString(old, new Object, opts ...DiffStringOption) string {
+	var o diffStringOptions
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	oldLeaves, newLeaves := Flatten(old), Flatten(new)
+	paths := make([]string, 0, len(oldLeaves)+len(newLeaves))
+	for path := range oldLeaves {
+		paths = append(paths, path)
+	}
+	for path := range newLeaves {
+		if _, ok := oldLeaves[path]; !ok {
+			paths = append(paths, path)
+		}
+	}
+	sort.Strings(paths)
+
+	buf := &strings.Builder{}
+	for _, path := range paths {
+		oldValue, inOld := oldLeaves[path]
+		newValue, inNew := newLeaves[path]
+		if inOld && inNew && equalValue(oldValue, newValue) {
+			continue
+		}
+		if inOld {
+			fmt.Fprintf(buf, "- %s: %s\n", path, o.format(path, oldValue))
+		}
+		if inNew {
+			fmt.Fprintf(buf, "+ %s: %s\n", path, o.format(path, newValue))
+		}
+	}
+	return buf.String()
+}
+
+func (o diffStringOptions) format(path string, value interface{}) string {
+	for _, redact := range o.redact {
+		value = redact(path, value)
+	}
+	content, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Sprint(value)
+	}
+	return string(content)
+}
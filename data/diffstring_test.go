@@ -0,0 +1,78 @@
+package data
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestDiffString(t *testing.T) {
+	tests := []struct {
+		name     string
+		old, new Object
+		expected string
+	}{
+		{
+			name: "equal",
+			old:  Object{"a": map[string]interface{}{"b": int64(1)}},
+			new:  Object{"a": map[string]interface{}{"b": 1.0}},
+		},
+		{
+			name: "nested maps",
+			old: Object{"spec": map[string]interface{}{
+				"template": map[string]interface{}{"image": "nginx:1", "debug": true},
+			}},
+			new: Object{"spec": map[string]interface{}{
+				"template": map[string]interface{}{"image": "nginx:2", "port": int64(80)},
+			}},
+			expected: `- spec.template.debug: true
+- spec.template.image: "nginx:1"
++ spec.template.image: "nginx:2"
++ spec.template.port: 80
+`,
+		},
+		{
+			name:     "arrays",
+			old:      Object{"args": []interface{}{"-v", "-x"}},
+			new:      Object{"args": []interface{}{"-v", "-y", "-z"}},
+			expected: "- args[1]: \"-x\"\n+ args[1]: \"-y\"\n+ args[2]: \"-z\"\n",
+		},
+		{
+			name:     "type changes",
+			old:      Object{"port": "80", "env": map[string]interface{}{"A": "1"}},
+			new:      Object{"port": int64(80), "env": []interface{}{"A=1"}},
+			expected: "- env.A: \"1\"\n+ env[0]: \"A=1\"\n- port: \"80\"\n+ port: 80\n",
+		},
+		{
+			name:     "escaped keys",
+			old:      Object{"labels": map[string]interface{}{"app.kubernetes.io/name": "a"}},
+			new:      Object{"labels": map[string]interface{}{}},
+			expected: "+ labels: {}\n- labels.app\\.kubernetes\\.io/name: \"a\"\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if diff := DiffString(tt.old, tt.new); diff != tt.expected {
+				t.Fatalf("expected\n%s\ngot\n%s", tt.expected, diff)
+			}
+		})
+	}
+}
+
+func TestDiffStringRedaction(t *testing.T) {
+	old := Object{"password": "old", "user": "admin"}
+	new := Object{"password": "new", "user": "root"}
+
+	diff := DiffString(old, new, WithRedaction(func(path string, value interface{}) interface{} {
+		if path == "password" {
+			return "***"
+		}
+		return value
+	}))
+	if strings.Contains(diff, "old") || strings.Contains(diff, "new") || !strings.Contains(diff, `+ password: "***"`) {
+		t.Fatalf("expected the password to be redacted, got\n%s", diff)
+	}
+	if !strings.Contains(diff, `+ user: "root"`) {
+		t.Fatalf("expected the user to be printed, got\n%s", diff)
+	}
+}
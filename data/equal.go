@@ -0,0 +1,211 @@
+package data
+
+import (
+	"encoding/json"
+	"reflect"
+
+	"github.com/acorn-io/schemer/data/convert"
+)
+
+type equalOptions struct {
+	nilIsMissing bool
+	// strict makes nil only equal nil and nil maps and slices, so changes
+	// between null and an empty collection aren't lost when creating patches
+	strict bool
+}
+
+type EqualOption func(*equalOptions)
+
+// NilEqualsMissing makes Equal treat a key that is set to nil the same as a
+// key that is not set at all.
+func NilEqualsMissing() EqualOption {
+	return func(o *equalOptions) {
+		o.nilIsMissing = true
+	}
+}
+
+// Equal compares two objects semantically. Numbers are equal if their values
+// are, regardless of their Go type, nil and empty maps and slices are equal
+// and maps and slices of different element types are compared element by
+// element, so []string{"a"} equals []interface{}{"a"}. Null equals nil.
+func Equal(a, b Object, opts ...EqualOption) bool {
+	return EqualValues(map[string]interface{}(a), map[string]interface{}(b), opts...)
+}
+
+// EqualValues is Equal for arbitrary values.
+func EqualValues(a, b interface{}, opts ...EqualOption) bool {
+	var o equalOptions
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return equal(a, b, o)
+}
+
+func equal(a, b interface{}, o equalOptions) bool {
+	if _, ok := a.(NullValue); ok {
+		return IsNull(b)
+	}
+	if _, ok := b.(NullValue); ok {
+		return a == nil
+	}
+	if o.strict {
+		if aNil, bNil := isNilValue(a), isNilValue(b); aNil || bNil {
+			return aNil == bNil
+		}
+	} else if a == nil || b == nil {
+		return isEmptyCollection(a) && isEmptyCollection(b)
+	}
+	if isNumber(a) && isNumber(b) {
+		return equalNumber(a, b)
+	}
+
+	if aMap, ok := asMap(a); ok {
+		if bMap, ok := asMap(b); ok {
+			return equalMap(aMap, bMap, o)
+		}
+	}
+	if aList, ok := a.([]interface{}); ok {
+		if bList, ok := b.([]interface{}); ok {
+			return equalList(aList, bList, o)
+		}
+	}
+
+	av, bv := reflect.ValueOf(a), reflect.ValueOf(b)
+	if !av.IsValid() || !bv.IsValid() {
+		return av.IsValid() == bv.IsValid()
+	}
+
+	switch {
+	case isList(av) && isList(bv):
+		if av.Len() != bv.Len() {
+			return false
+		}
+		for i := 0; i < av.Len(); i++ {
+			if !equal(av.Index(i).Interface(), bv.Index(i).Interface(), o) {
+				return false
+			}
+		}
+		return true
+	case isStringMap(av) && isStringMap(bv):
+		return equalMap(toInterfaceMap(av), toInterfaceMap(bv), o)
+	}
+
+	return reflect.DeepEqual(a, b)
+}
+
+func equalNumber(a, b interface{}) bool {
+	if an, ok := a.(json.Number); ok {
+		if bn, ok := b.(json.Number); ok && an == bn {
+			return true
+		}
+	}
+	if ai, err := convert.ToNumber(a); err == nil && isInteger(a) {
+		if bi, err := convert.ToNumber(b); err == nil && isInteger(b) {
+			return ai == bi
+		}
+	}
+	af, aErr := convert.ToFloat(a)
+	bf, bErr := convert.ToFloat(b)
+	return aErr == nil && bErr == nil && af == bf
+}
+
+func isNumber(v interface{}) bool {
+	switch v.(type) {
+	case int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64, float32, float64, json.Number:
+		return true
+	}
+	return false
+}
+
+func isInteger(v interface{}) bool {
+	switch n := v.(type) {
+	case int, int8, int16, int32, int64, uint8, uint16, uint32:
+		return true
+	case json.Number:
+		_, err := n.Int64()
+		return err == nil
+	}
+	return false
+}
+
+func equalMap(a, b map[string]interface{}, o equalOptions) bool {
+	if !o.nilIsMissing && len(a) != len(b) {
+		return false
+	}
+	for k, av := range a {
+		bv, ok := b[k]
+		if !ok {
+			if o.nilIsMissing && av == nil {
+				continue
+			}
+			return false
+		}
+		if !equal(av, bv, o) {
+			return false
+		}
+	}
+	if o.nilIsMissing {
+		for k, bv := range b {
+			if _, ok := a[k]; !ok && bv != nil {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+func equalList(a, b []interface{}, o equalOptions) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if !equal(a[i], b[i], o) {
+			return false
+		}
+	}
+	return true
+}
+
+// isEmptyCollection returns true for nil and for maps and slices, including
+// typed nil ones, without elements.
+func isEmptyCollection(v interface{}) bool {
+	if v == nil {
+		return true
+	}
+	rv := reflect.ValueOf(v)
+	switch rv.Kind() {
+	case reflect.Map, reflect.Slice:
+		return rv.Len() == 0
+	}
+	return false
+}
+
+// isNilValue returns true for nil and for nil maps and slices.
+func isNilValue(v interface{}) bool {
+	if v == nil {
+		return true
+	}
+	rv := reflect.ValueOf(v)
+	switch rv.Kind() {
+	case reflect.Map, reflect.Slice:
+		return rv.IsNil()
+	}
+	return false
+}
+
+func isList(v reflect.Value) bool {
+	return (v.Kind() == reflect.Slice || v.Kind() == reflect.Array) && v.Type().Elem().Kind() != reflect.Uint8
+}
+
+func isStringMap(v reflect.Value) bool {
+	return v.Kind() == reflect.Map && v.Type().Key().Kind() == reflect.String
+}
+
+func toInterfaceMap(v reflect.Value) map[string]interface{} {
+	result := make(map[string]interface{}, v.Len())
+	iter := v.MapRange()
+	for iter.Next() {
+		result[iter.Key().String()] = iter.Value().Interface()
+	}
+	return result
+}
@@ -0,0 +1,87 @@
+package data
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func TestEqual(t *testing.T) {
+	tests := []struct {
+		name  string
+		a, b  Object
+		equal bool
+	}{
+		{"nil and empty list", Object{"a": nil}, Object{"a": []interface{}{}}, true},
+		{"nil and empty map", Object{"a": nil}, Object{"a": map[string]interface{}{}}, true},
+		{"typed nil and empty list", Object{"a": []string(nil)}, Object{"a": []interface{}{}}, true},
+		{"empty list and empty map", Object{"a": []interface{}{}}, Object{"a": map[string]interface{}{}}, false},
+		{"nil and list", Object{"a": nil}, Object{"a": []interface{}{"x"}}, false},
+		{"nil and string", Object{"a": nil}, Object{"a": ""}, false},
+		{"nil and zero", Object{"a": nil}, Object{"a": 0}, false},
+		{"numbers", Object{"a": int64(1)}, Object{"a": json.Number("1.0")}, true},
+		{"typed lists", Object{"a": []string{"x"}}, Object{"a": []interface{}{"x"}}, true},
+		{"typed maps", Object{"a": map[string]string{"x": "y"}}, Object{"a": map[string]interface{}{"x": "y"}}, true},
+		{"null and nil", Object{"a": Null}, Object{"a": nil}, true},
+		{"missing key", Object{"a": nil}, Object{}, false},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := Equal(test.a, test.b); got != test.equal {
+				t.Errorf("Equal(%v, %v) = %v", test.a, test.b, got)
+			}
+			if got := Equal(test.b, test.a); got != test.equal {
+				t.Errorf("Equal(%v, %v) = %v", test.b, test.a, got)
+			}
+		})
+	}
+
+	if !Equal(Object{"a": nil}, Object{}, NilEqualsMissing()) {
+		t.Error("expected nil to equal a missing key")
+	}
+}
+
+func TestDiffNilAndEmpty(t *testing.T) {
+	tests := []struct {
+		old, new Object
+	}{
+		{
+			old: Object{"a": []interface{}{}, "b": map[string]interface{}{}},
+			new: Object{"a": nil, "b": nil},
+		},
+		{
+			old: Object{"a": nil, "b": nil},
+			new: Object{"a": []interface{}{}, "b": map[string]interface{}{}},
+		},
+		{
+			old: Object{"a": []interface{}(nil), "b": map[string]interface{}(nil)},
+			new: Object{"a": []interface{}{}, "b": map[string]interface{}{}},
+		},
+	}
+
+	for _, test := range tests {
+		// Equal treats them as equal, patches must not
+		if !Equal(test.old, test.new) {
+			t.Errorf("expected %v to equal %v", test.old, test.new)
+		}
+
+		ops, err := Diff(test.old, test.new)
+		if err != nil {
+			t.Fatal(err)
+		}
+		result, err := Apply(test.old, ops)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(result, test.new) {
+			t.Errorf("expected JSON patch %v to turn %v into %v, got %v", ops, test.old, test.new, result)
+		}
+
+		if patch := CreateMergePatch(test.old, test.new); len(patch) != 2 {
+			t.Errorf("expected merge patch of %v to %v to change a and b, got %v", test.old, test.new, patch)
+		}
+		if patch := CreateThreeWayMergePatch(test.old, test.new, test.old); len(patch) != 2 {
+			t.Errorf("expected three-way merge patch of %v to %v to change a and b, got %v", test.old, test.new, patch)
+		}
+	}
+}
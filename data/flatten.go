@@ -0,0 +1,83 @@
+package data
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+)
+
+// Flatten returns the leaf values of obj keyed by their path in the syntax of
+// GetPath, for example "spec.ports[0].port". Keys are escaped with
+// EscapePathKey, so empty keys and keys with dots round-trip. Empty maps and
+// slices are kept as leaves so Unflatten can restore them.
+func Flatten(obj Object) map[string]interface{} {
+	result := map[string]interface{}{}
+	flatten("", map[string]interface{}(obj), result)
+	return result
+}
+
+func flatten(path string, v interface{}, result map[string]interface{}) {
+	if m, ok := asMap(v); ok && (len(m) > 0 || path == "") {
+		for k, item := range m {
+			key := EscapePathKey(k)
+			if path != "" {
+				key = path + "." + key
+			}
+			flatten(key, item, result)
+		}
+		return
+	}
+	if items, ok := v.([]interface{}); ok && len(items) > 0 {
+		for i, item := range items {
+			flatten(path+"["+strconv.Itoa(i)+"]", item, result)
+		}
+		return
+	}
+	result[path] = v
+}
+
+// Unflatten reverses Flatten. Slice indexes must be contiguous from 0.
+func Unflatten(flat map[string]interface{}) (Object, error) {
+	type entry struct {
+		path  string
+		parts []pathPart
+	}
+
+	entries := make([]entry, 0, len(flat))
+	for path := range flat {
+		parts, err := parsePath(path)
+		if err != nil {
+			return nil, err
+		}
+		if parts[0].isIndex {
+			return nil, fmt.Errorf("invalid path %q: must start with a key", path)
+		}
+		entries = append(entries, entry{path: path, parts: parts})
+	}
+
+	// slice elements have to be set in index order, so indexes are compared
+	// as numbers
+	sort.Slice(entries, func(i, j int) bool {
+		a, b := entries[i].parts, entries[j].parts
+		for k := 0; k < len(a) && k < len(b); k++ {
+			if a[k].isIndex != b[k].isIndex {
+				return a[k].isIndex
+			}
+			if a[k].isIndex && a[k].index != b[k].index {
+				return a[k].index < b[k].index
+			}
+			if !a[k].isIndex && a[k].key != b[k].key {
+				return a[k].key < b[k].key
+			}
+		}
+		return len(a) < len(b)
+	})
+
+	result := Object{}
+	for _, e := range entries {
+		if _, err := setPath(result, e.parts, copyValue(flat[e.path])); err != nil {
+			return nil, fmt.Errorf("failed to set %s: %w", e.path, err)
+		}
+	}
+	return result, nil
+}
@@ -0,0 +1,88 @@
+package data
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestFlatten(t *testing.T) {
+	obj := Object{
+		"metadata": map[string]interface{}{
+			"labels": map[string]interface{}{"app.kubernetes.io/name": "app"},
+		},
+		"spec": map[string]interface{}{
+			"ports": []interface{}{
+				map[string]interface{}{"port": int64(80)},
+			},
+			"env":      []interface{}{},
+			"selector": map[string]interface{}{},
+		},
+	}
+
+	expected := map[string]interface{}{
+		`metadata.labels.app\.kubernetes\.io/name`: "app",
+		"spec.ports[0].port":                       int64(80),
+		"spec.env":                                 []interface{}{},
+		"spec.selector":                            map[string]interface{}{},
+	}
+	flat := Flatten(obj)
+	if !reflect.DeepEqual(flat, expected) {
+		t.Fatalf("expected %v, got %v", expected, flat)
+	}
+}
+
+func TestFlattenRoundTrip(t *testing.T) {
+	tests := []struct {
+		name string
+		obj  Object
+	}{
+		{
+			name: "nested",
+			obj: Object{
+				"spec": map[string]interface{}{
+					"containers": []interface{}{
+						map[string]interface{}{"name": "a", "args": []interface{}{"x", "y"}},
+					},
+				},
+			},
+		},
+		{
+			name: "escaped keys",
+			obj: Object{
+				"a.b": map[string]interface{}{`c[0]\d`: "e", `"quoted"`: "f"},
+			},
+		},
+		{
+			name: "empty keys",
+			obj: Object{
+				"":    "root",
+				"a.b": map[string]interface{}{"": int64(2)},
+				"c":   []interface{}{map[string]interface{}{"": map[string]interface{}{"": true}}},
+				`""`:  "quotes",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := Unflatten(Flatten(tt.obj))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(result, tt.obj) {
+				t.Fatalf("expected %v, got %v", tt.obj, result)
+			}
+		})
+	}
+}
+
+func TestUnflattenInvalid(t *testing.T) {
+	for _, flat := range []map[string]interface{}{
+		{"[0]": "a"},
+		{"a..b": "a"},
+		{"a.b": "a", "a.b.c": "b"},
+	} {
+		if _, err := Unflatten(flat); err == nil {
+			t.Errorf("expected error for %v", flat)
+		}
+	}
+}
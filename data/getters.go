@@ -0,0 +1,189 @@
+package data
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"strings"
+	"time"
+)
+
+// The E getters return an error instead of coercing values of the wrong
+// type. A missing or nil value is not an error and returns the zero value.
+
+func (o Object) StringE(names ...string) (string, error) {
+	v := GetValueN(o, names...)
+	switch t := v.(type) {
+	case nil:
+		return "", nil
+	case string:
+		return t, nil
+	}
+	return "", typeError(names, "string", v)
+}
+
+func (o Object) BoolE(names ...string) (bool, error) {
+	v := GetValueN(o, names...)
+	switch t := v.(type) {
+	case nil:
+		return false, nil
+	case bool:
+		return t, nil
+	}
+	return false, typeError(names, "boolean", v)
+}
+
+func (o Object) Int64E(names ...string) (int64, error) {
+	v := GetValueN(o, names...)
+	switch t := v.(type) {
+	case nil:
+		return 0, nil
+	case int:
+		return int64(t), nil
+	case int8:
+		return int64(t), nil
+	case int16:
+		return int64(t), nil
+	case int32:
+		return int64(t), nil
+	case int64:
+		return t, nil
+	case uint8:
+		return int64(t), nil
+	case uint16:
+		return int64(t), nil
+	case uint32:
+		return int64(t), nil
+	case uint:
+		if uint64(t) <= math.MaxInt64 {
+			return int64(t), nil
+		}
+	case uint64:
+		if t <= math.MaxInt64 {
+			return int64(t), nil
+		}
+	case float32:
+		if f := float64(t); f == math.Trunc(f) && f >= math.MinInt64 && f < math.MaxInt64 {
+			return int64(f), nil
+		}
+	case float64:
+		if t == math.Trunc(t) && t >= math.MinInt64 && t < math.MaxInt64 {
+			return int64(t), nil
+		}
+	case json.Number:
+		if i, err := t.Int64(); err == nil {
+			return i, nil
+		}
+	}
+	return 0, typeError(names, "integer", v)
+}
+
+func (o Object) Float64E(names ...string) (float64, error) {
+	v := GetValueN(o, names...)
+	switch t := v.(type) {
+	case nil:
+		return 0, nil
+	case float64:
+		return t, nil
+	case float32:
+		return float64(t), nil
+	case uint:
+		return float64(t), nil
+	case uint64:
+		return float64(t), nil
+	case json.Number:
+		if f, err := t.Float64(); err == nil {
+			return f, nil
+		}
+	default:
+		if isNumber(v) {
+			i, err := o.Int64E(names...)
+			return float64(i), err
+		}
+	}
+	return 0, typeError(names, "number", v)
+}
+
+// TimeE accepts time.Time values and RFC 3339 strings.
+func (o Object) TimeE(names ...string) (time.Time, error) {
+	v := GetValueN(o, names...)
+	switch t := v.(type) {
+	case nil:
+		return time.Time{}, nil
+	case time.Time:
+		return t, nil
+	case string:
+		if t == "" {
+			return time.Time{}, nil
+		}
+		result, err := time.Parse(time.RFC3339Nano, t)
+		if err != nil {
+			return time.Time{}, fmt.Errorf("field %s: %w", strings.Join(names, "."), err)
+		}
+		return result, nil
+	}
+	return time.Time{}, typeError(names, "timestamp", v)
+}
+
+func (o Object) MapE(names ...string) (Object, error) {
+	v := GetValueN(o, names...)
+	if v == nil {
+		return nil, nil
+	}
+	if m, ok := asMap(v); ok {
+		return m, nil
+	}
+	return nil, typeError(names, "object", v)
+}
+
+func (o Object) SliceE(names ...string) ([]Object, error) {
+	v := GetValueN(o, names...)
+	switch t := v.(type) {
+	case nil:
+		return nil, nil
+	case []interface{}:
+		result := make([]Object, 0, len(t))
+		for i, item := range t {
+			m, ok := asMap(item)
+			if !ok {
+				return nil, typeError(append(names[:len(names):len(names)], fmt.Sprint(i)), "object", item)
+			}
+			result = append(result, m)
+		}
+		return result, nil
+	case []map[string]interface{}:
+		result := make([]Object, 0, len(t))
+		for _, item := range t {
+			result = append(result, item)
+		}
+		return result, nil
+	case []Object:
+		return t, nil
+	}
+	return nil, typeError(names, "array", v)
+}
+
+func (o Object) StringSliceE(names ...string) ([]string, error) {
+	v := GetValueN(o, names...)
+	switch t := v.(type) {
+	case nil:
+		return nil, nil
+	case []string:
+		return t, nil
+	case []interface{}:
+		result := make([]string, 0, len(t))
+		for i, item := range t {
+			s, ok := item.(string)
+			if !ok {
+				return nil, typeError(append(names[:len(names):len(names)], fmt.Sprint(i)), "string", item)
+			}
+			result = append(result, s)
+		}
+		return result, nil
+	}
+	return nil, typeError(names, "array", v)
+}
+
+func typeError(names []string, expected string, value interface{}) error {
+	return fmt.Errorf("field %s: expected %s, got %T", strings.Join(names, "."), expected, value)
+}
@@ -0,0 +1,31 @@
+package data
+
+import (
+	"math"
+	"testing"
+)
+
+func TestFloat64E(t *testing.T) {
+	obj := Object{
+		"uint64": uint64(math.MaxUint64),
+		"uint":   uint(7),
+		"int":    int32(-3),
+		"string": "1.5",
+	}
+
+	for key, expected := range map[string]float64{
+		"uint64":  math.MaxUint64,
+		"uint":    7,
+		"int":     -3,
+		"missing": 0,
+	} {
+		f, err := obj.Float64E(key)
+		if err != nil || f != expected {
+			t.Errorf("%s: expected %v, got %v, %v", key, expected, f, err)
+		}
+	}
+
+	if _, err := obj.Float64E("string"); err == nil {
+		t.Error("expected an error for a string")
+	}
+}
@@ -0,0 +1,353 @@
+package data
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+const (
+	OpAdd     = "add"
+	OpRemove  = "remove"
+	OpReplace = "replace"
+	OpMove    = "move"
+	OpCopy    = "copy"
+	OpTest    = "test"
+)
+
+// PatchOp is a single RFC 6902 JSON Patch operation.
+type PatchOp struct {
+	Op    string      `json:"op"`
+	Path  string      `json:"path"`
+	From  string      `json:"from,omitempty"`
+	Value interface{} `json:"value,omitempty"`
+}
+
+func (p PatchOp) MarshalJSON() ([]byte, error) {
+	type op PatchOp
+	if p.Op != OpAdd && p.Op != OpReplace && p.Op != OpTest {
+		return json.Marshal(op(p))
+	}
+	// value is required for these operations even if it's null
+	return json.Marshal(struct {
+		op
+		Value interface{} `json:"value"`
+	}{
+		op:    op(p),
+		Value: p.Value,
+	})
+}
+
+// Diff returns the JSON Patch that transforms old into new. Lists that only
+// differ in the middle are patched element by element, the rest of the patch
+// is made of add, remove and replace operations on the changed values.
+func Diff(old, new Object) ([]PatchOp, error) {
+	return diffValue("", map[string]interface{}(old), map[string]interface{}(new), nil), nil
+}
+
+func diffValue(path string, old, new interface{}, ops []PatchOp) []PatchOp {
+	if isNilValue(old) != isNilValue(new) {
+		return append(ops, PatchOp{Op: OpReplace, Path: path, Value: copyValue(new)})
+	}
+	if oldMap, ok := asMap(old); ok {
+		if newMap, ok := asMap(new); ok {
+			return diffMap(path, oldMap, newMap, ops)
+		}
+	}
+	if oldList, ok := old.([]interface{}); ok {
+		if newList, ok := new.([]interface{}); ok {
+			return diffList(path, oldList, newList, ops)
+		}
+	}
+	if equalValue(old, new) {
+		return ops
+	}
+	return append(ops, PatchOp{Op: OpReplace, Path: path, Value: copyValue(new)})
+}
+
+func diffMap(path string, old, new map[string]interface{}, ops []PatchOp) []PatchOp {
+	var keys []string
+	for k := range old {
+		keys = append(keys, k)
+	}
+	for k := range new {
+		if _, ok := old[k]; !ok {
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
+
+	for _, k := range keys {
+		childPath := path + "/" + escapePointer(k)
+		oldValue, inOld := old[k]
+		newValue, inNew := new[k]
+		switch {
+		case !inNew:
+			ops = append(ops, PatchOp{Op: OpRemove, Path: childPath})
+		case !inOld:
+			ops = append(ops, PatchOp{Op: OpAdd, Path: childPath, Value: copyValue(newValue)})
+		default:
+			ops = diffValue(childPath, oldValue, newValue, ops)
+		}
+	}
+	return ops
+}
+
+func diffList(path string, old, new []interface{}, ops []PatchOp) []PatchOp {
+	prefix := 0
+	for prefix < len(old) && prefix < len(new) && equalValue(old[prefix], new[prefix]) {
+		prefix++
+	}
+	suffix := 0
+	for suffix < len(old)-prefix && suffix < len(new)-prefix &&
+		equalValue(old[len(old)-1-suffix], new[len(new)-1-suffix]) {
+		suffix++
+	}
+
+	oldMiddle := old[prefix : len(old)-suffix]
+	newMiddle := new[prefix : len(new)-suffix]
+
+	common := min(len(oldMiddle), len(newMiddle))
+	for i := 0; i < common; i++ {
+		ops = diffValue(path+"/"+strconv.Itoa(prefix+i), oldMiddle[i], newMiddle[i], ops)
+	}
+	for i := len(oldMiddle) - 1; i >= common; i-- {
+		ops = append(ops, PatchOp{Op: OpRemove, Path: path + "/" + strconv.Itoa(prefix+i)})
+	}
+	for i := common; i < len(newMiddle); i++ {
+		ops = append(ops, PatchOp{Op: OpAdd, Path: path + "/" + strconv.Itoa(prefix+i), Value: copyValue(newMiddle[i])})
+	}
+	return ops
+}
+
+// equalValue compares two values with the semantics of Equal, except that nil
+// is not equal to empty maps and slices because their JSON differs.
+func equalValue(a, b interface{}) bool {
+	return equal(a, b, equalOptions{strict: true})
+}
+
+func escapePointer(token string) string {
+	return strings.NewReplacer("~", "~0", "/", "~1").Replace(token)
+}
+
+func parsePointer(pointer string) ([]string, error) {
+	if pointer == "" {
+		return nil, nil
+	}
+	if !strings.HasPrefix(pointer, "/") {
+		return nil, fmt.Errorf("invalid JSON pointer %q", pointer)
+	}
+	tokens := strings.Split(pointer[1:], "/")
+	unescape := strings.NewReplacer("~1", "/", "~0", "~")
+	for i, token := range tokens {
+		tokens[i] = unescape.Replace(token)
+	}
+	return tokens, nil
+}
+
+// Apply applies a JSON Patch to obj and returns the result, obj is not
+// modified. All operations of RFC 6902 are supported, a failing "test"
+// operation aborts the patch with an error.
+func Apply(obj Object, ops []PatchOp) (Object, error) {
+	var doc interface{} = copyValue(map[string]interface{}(obj))
+	for i, op := range ops {
+		var err error
+		doc, err = applyOp(doc, op)
+		if err != nil {
+			return nil, fmt.Errorf("patch operation %d (%s %s): %w", i, op.Op, op.Path, err)
+		}
+	}
+
+	result, ok := asMap(doc)
+	if !ok {
+		return nil, fmt.Errorf("patch result is not an object")
+	}
+	return result, nil
+}
+
+func applyOp(doc interface{}, op PatchOp) (interface{}, error) {
+	path, err := parsePointer(op.Path)
+	if err != nil {
+		return nil, err
+	}
+
+	switch op.Op {
+	case OpAdd:
+		return addPointer(doc, path, copyValue(op.Value))
+	case OpRemove:
+		result, _, err := removePointer(doc, path)
+		return result, err
+	case OpReplace:
+		if _, err := getPointer(doc, path); err != nil {
+			return nil, err
+		}
+		if result, _, err := removePointer(doc, path); err == nil {
+			doc = result
+		}
+		return addPointer(doc, path, copyValue(op.Value))
+	case OpMove, OpCopy:
+		from, err := parsePointer(op.From)
+		if err != nil {
+			return nil, err
+		}
+		if op.Op == OpMove {
+			if op.Path == op.From {
+				return doc, nil
+			}
+			if strings.HasPrefix(op.Path, op.From+"/") {
+				return nil, fmt.Errorf("can not move %s into one of its children", op.From)
+			}
+			doc, value, err := removePointer(doc, from)
+			if err != nil {
+				return nil, err
+			}
+			return addPointer(doc, path, value)
+		}
+		value, err := getPointer(doc, from)
+		if err != nil {
+			return nil, err
+		}
+		return addPointer(doc, path, copyValue(value))
+	case OpTest:
+		value, err := getPointer(doc, path)
+		if err != nil {
+			return nil, err
+		}
+		if !equalValue(value, op.Value) {
+			return nil, fmt.Errorf("test failed, value is %v", value)
+		}
+		return doc, nil
+	}
+
+	return nil, fmt.Errorf("unknown operation %q", op.Op)
+}
+
+func getPointer(doc interface{}, path []string) (interface{}, error) {
+	for _, token := range path {
+		switch container := doc.(type) {
+		case map[string]interface{}, Object:
+			m, _ := asMap(container)
+			value, ok := m[token]
+			if !ok {
+				return nil, fmt.Errorf("key %s does not exist", token)
+			}
+			doc = value
+		case []interface{}:
+			i, err := listIndex(token, len(container), false)
+			if err != nil {
+				return nil, err
+			}
+			doc = container[i]
+		default:
+			return nil, fmt.Errorf("can not look up %s in a value that is not an object or list", token)
+		}
+	}
+	return doc, nil
+}
+
+// updatePointer calls update with the parent of the last element of path and
+// stores the returned value in place of the parent.
+func updatePointer(doc interface{}, path []string, update func(parent interface{}, token string) (interface{}, error)) (interface{}, error) {
+	if len(path) == 1 {
+		return update(doc, path[0])
+	}
+
+	token := path[0]
+	switch container := doc.(type) {
+	case map[string]interface{}, Object:
+		m, _ := asMap(container)
+		child, ok := m[token]
+		if !ok {
+			return nil, fmt.Errorf("key %s does not exist", token)
+		}
+		value, err := updatePointer(child, path[1:], update)
+		if err != nil {
+			return nil, err
+		}
+		m[token] = value
+	case []interface{}:
+		i, err := listIndex(token, len(container), false)
+		if err != nil {
+			return nil, err
+		}
+		value, err := updatePointer(container[i], path[1:], update)
+		if err != nil {
+			return nil, err
+		}
+		container[i] = value
+	default:
+		return nil, fmt.Errorf("can not look up %s in a value that is not an object or list", token)
+	}
+	return doc, nil
+}
+
+func addPointer(doc interface{}, path []string, value interface{}) (interface{}, error) {
+	if len(path) == 0 {
+		return value, nil
+	}
+	return updatePointer(doc, path, func(parent interface{}, token string) (interface{}, error) {
+		switch container := parent.(type) {
+		case map[string]interface{}, Object:
+			m, _ := asMap(container)
+			m[token] = value
+			return container, nil
+		case []interface{}:
+			i, err := listIndex(token, len(container), true)
+			if err != nil {
+				return nil, err
+			}
+			container = append(container, nil)
+			copy(container[i+1:], container[i:])
+			container[i] = value
+			return container, nil
+		}
+		return nil, fmt.Errorf("can not add %s to a value that is not an object or list", token)
+	})
+}
+
+func removePointer(doc interface{}, path []string) (interface{}, interface{}, error) {
+	if len(path) == 0 {
+		return nil, nil, fmt.Errorf("can not remove the whole document")
+	}
+
+	var removed interface{}
+	result, err := updatePointer(doc, path, func(parent interface{}, token string) (interface{}, error) {
+		switch container := parent.(type) {
+		case map[string]interface{}, Object:
+			m, _ := asMap(container)
+			value, ok := m[token]
+			if !ok {
+				return nil, fmt.Errorf("key %s does not exist", token)
+			}
+			removed = value
+			delete(m, token)
+			return container, nil
+		case []interface{}:
+			i, err := listIndex(token, len(container), false)
+			if err != nil {
+				return nil, err
+			}
+			removed = container[i]
+			return append(container[:i], container[i+1:]...), nil
+		}
+		return nil, fmt.Errorf("can not remove %s from a value that is not an object or list", token)
+	})
+	return result, removed, err
+}
+
+func listIndex(token string, length int, forAdd bool) (int, error) {
+	if forAdd && token == "-" {
+		return length, nil
+	}
+	// only digits without leading zeros are valid, so "+1" and "-0" aren't
+	i, err := strconv.Atoi(token)
+	if err != nil || strings.Trim(token, "0123456789") != "" || (token != "0" && strings.HasPrefix(token, "0")) {
+		return 0, fmt.Errorf("invalid list index %q", token)
+	}
+	if i > length || (!forAdd && i == length) {
+		return 0, fmt.Errorf("list index %d is out of range", i)
+	}
+	return i, nil
+}
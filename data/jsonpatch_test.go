@@ -0,0 +1,174 @@
+package data
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestDiffApplyRoundTrip(t *testing.T) {
+	tests := []struct {
+		name     string
+		old, new Object
+	}{
+		{
+			name: "fields",
+			old:  Object{"a": "1", "b": "2", "c": map[string]interface{}{"d": int64(1)}},
+			new:  Object{"a": "1", "b": "3", "c": map[string]interface{}{"e": int64(2)}, "f": nil},
+		},
+		{
+			name: "escaped keys",
+			old:  Object{"a/b": "1", "c~d": "2"},
+			new:  Object{"a/b": "2", "e~1": "3"},
+		},
+		{
+			name: "list middle",
+			old:  Object{"l": []interface{}{"a", "b", "c", "d"}},
+			new:  Object{"l": []interface{}{"a", "x", "y", "z", "d"}},
+		},
+		{
+			name: "list shrinks",
+			old:  Object{"l": []interface{}{"a", "b", "c", "d"}},
+			new:  Object{"l": []interface{}{"a", "d"}},
+		},
+		{
+			name: "nested lists",
+			old:  Object{"l": []interface{}{map[string]interface{}{"n": "a", "v": int64(1)}}},
+			new:  Object{"l": []interface{}{map[string]interface{}{"n": "a", "v": int64(2)}, map[string]interface{}{"n": "b"}}},
+		},
+		{
+			name: "type change",
+			old:  Object{"a": map[string]interface{}{"b": "c"}},
+			new:  Object{"a": []interface{}{"b"}},
+		},
+		{
+			name: "equal",
+			old:  Object{"a": int64(1)},
+			new:  Object{"a": json.Number("1")},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ops, err := Diff(test.old, test.new)
+			if err != nil {
+				t.Fatal(err)
+			}
+			result, err := Apply(test.old, ops)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !Equal(result, test.new) {
+				t.Errorf("expected %v, got %v with %v", test.new, result, ops)
+			}
+		})
+	}
+}
+
+func TestApply(t *testing.T) {
+	doc := Object{
+		"a": map[string]interface{}{"b": "c"},
+		"l": []interface{}{"x", "y"},
+	}
+
+	tests := []struct {
+		name     string
+		ops      []PatchOp
+		expected Object
+		err      string
+	}{
+		{
+			name:     "append with -",
+			ops:      []PatchOp{{Op: OpAdd, Path: "/l/-", Value: "z"}},
+			expected: Object{"a": map[string]interface{}{"b": "c"}, "l": []interface{}{"x", "y", "z"}},
+		},
+		{
+			name:     "insert",
+			ops:      []PatchOp{{Op: OpAdd, Path: "/l/1", Value: "z"}},
+			expected: Object{"a": map[string]interface{}{"b": "c"}, "l": []interface{}{"x", "z", "y"}},
+		},
+		{
+			name:     "move",
+			ops:      []PatchOp{{Op: OpMove, From: "/a/b", Path: "/l/0"}},
+			expected: Object{"a": map[string]interface{}{}, "l": []interface{}{"c", "x", "y"}},
+		},
+		{
+			name:     "copy and test",
+			ops:      []PatchOp{{Op: OpCopy, From: "/a", Path: "/d"}, {Op: OpTest, Path: "/d/b", Value: "c"}},
+			expected: Object{"a": map[string]interface{}{"b": "c"}, "d": map[string]interface{}{"b": "c"}, "l": []interface{}{"x", "y"}},
+		},
+		{
+			name: "move into child",
+			ops:  []PatchOp{{Op: OpMove, From: "/a", Path: "/a/b/c"}},
+			err:  "patch operation 0 (move /a/b/c): can not move /a into one of its children",
+		},
+		{
+			name: "- only appends",
+			ops:  []PatchOp{{Op: OpReplace, Path: "/l/-", Value: "z"}},
+			err:  `patch operation 0 (replace /l/-): invalid list index "-"`,
+		},
+		{
+			name: "leading zero",
+			ops:  []PatchOp{{Op: OpRemove, Path: "/l/01"}},
+			err:  `patch operation 0 (remove /l/01): invalid list index "01"`,
+		},
+		{
+			name: "sign",
+			ops:  []PatchOp{{Op: OpRemove, Path: "/l/+1"}},
+			err:  `patch operation 0 (remove /l/+1): invalid list index "+1"`,
+		},
+		{
+			name: "out of range",
+			ops:  []PatchOp{{Op: OpAdd, Path: "/l/3", Value: "z"}},
+			err:  "patch operation 0 (add /l/3): list index 3 is out of range",
+		},
+		{
+			name: "failing test",
+			ops:  []PatchOp{{Op: OpAdd, Path: "/e", Value: "f"}, {Op: OpTest, Path: "/a/b", Value: "d"}},
+			err:  "patch operation 1 (test /a/b): test failed, value is c",
+		},
+		{
+			name: "missing key",
+			ops:  []PatchOp{{Op: OpReplace, Path: "/missing", Value: "z"}},
+			err:  "patch operation 0 (replace /missing): key missing does not exist",
+		},
+		{
+			name: "unknown operation",
+			ops:  []PatchOp{{Op: "merge", Path: "/a"}},
+			err:  `patch operation 0 (merge /a): unknown operation "merge"`,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			result, err := Apply(doc, test.ops)
+			if test.err != "" {
+				if err == nil || err.Error() != test.err {
+					t.Fatalf("expected error %q, got %v", test.err, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !Equal(result, test.expected) {
+				t.Errorf("expected %v, got %v", test.expected, result)
+			}
+		})
+	}
+
+	if !Equal(doc, Object{"a": map[string]interface{}{"b": "c"}, "l": []interface{}{"x", "y"}}) {
+		t.Errorf("Apply modified the document: %v", doc)
+	}
+}
+
+func TestPatchOpMarshalJSON(t *testing.T) {
+	b, err := json.Marshal([]PatchOp{
+		{Op: OpAdd, Path: "/a", Value: nil},
+		{Op: OpRemove, Path: "/b"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s := string(b); !strings.Contains(s, `"value":null`) || strings.Count(s, "value") != 1 {
+		t.Errorf("unexpected encoding %s", s)
+	}
+}
@@ -0,0 +1,229 @@
+package data
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+)
+
+type LimitKind string
+
+const (
+	LimitDepth LimitKind = "depth"
+	LimitKeys  LimitKind = "keys"
+	LimitSize  LimitKind = "size"
+)
+
+// LimitError is returned when a payload exceeds one of the Limits.
+type LimitError struct {
+	Kind LimitKind
+	Max  int
+}
+
+func (e *LimitError) Error() string {
+	return fmt.Sprintf("payload exceeds the maximum %s of %d", e.Kind, e.Max)
+}
+
+// Limits protects against abusive payloads. Zero values are unlimited.
+type Limits struct {
+	// MaxDepth is the maximum nesting of objects and lists, the top level
+	// object has a depth of 1.
+	MaxDepth int
+	// MaxKeys is the maximum total number of object keys and list elements.
+	MaxKeys int
+	// MaxSize is the maximum size of the encoded payload in bytes.
+	MaxSize int
+}
+
+// DecodeJSON decodes a JSON object from r while enforcing the limits, the
+// payload is rejected as soon as a limit is exceeded. Numbers are decoded as
+// json.Number.
+func (l Limits) DecodeJSON(r io.Reader) (Object, error) {
+	if l.MaxSize > 0 {
+		r = &limitReader{r: r, remaining: l.MaxSize, max: l.MaxSize}
+	}
+
+	dec := json.NewDecoder(r)
+	dec.UseNumber()
+
+	type frame struct {
+		m      map[string]interface{}
+		items  []interface{}
+		key    string
+		hasKey bool
+	}
+
+	var (
+		stack  []*frame
+		result map[string]interface{}
+		keys   int
+	)
+
+	add := func(v interface{}) error {
+		if len(stack) == 0 {
+			m, ok := v.(map[string]interface{})
+			if !ok {
+				return fmt.Errorf("JSON document is a %T, not an object", v)
+			}
+			result = m
+			return nil
+		}
+		keys++
+		if l.MaxKeys > 0 && keys > l.MaxKeys {
+			return &LimitError{Kind: LimitKeys, Max: l.MaxKeys}
+		}
+		top := stack[len(stack)-1]
+		if top.m != nil {
+			top.m[top.key] = v
+			top.hasKey = false
+		} else {
+			top.items = append(top.items, v)
+		}
+		return nil
+	}
+
+	for {
+		token, err := dec.Token()
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				err = io.ErrUnexpectedEOF
+			}
+			var limitErr *LimitError
+			if errors.As(err, &limitErr) {
+				return nil, limitErr
+			}
+			return nil, err
+		}
+
+		if top := len(stack); top > 0 && stack[top-1].m != nil && !stack[top-1].hasKey {
+			if key, ok := token.(string); ok {
+				stack[top-1].key = key
+				stack[top-1].hasKey = true
+				continue
+			}
+		}
+
+		switch t := token.(type) {
+		case json.Delim:
+			switch t {
+			case '{', '[':
+				if l.MaxDepth > 0 && len(stack) >= l.MaxDepth {
+					return nil, &LimitError{Kind: LimitDepth, Max: l.MaxDepth}
+				}
+				f := &frame{}
+				if t == '{' {
+					f.m = map[string]interface{}{}
+				} else {
+					f.items = []interface{}{}
+				}
+				stack = append(stack, f)
+			case '}', ']':
+				f := stack[len(stack)-1]
+				stack = stack[:len(stack)-1]
+				var v interface{} = f.items
+				if f.m != nil {
+					v = f.m
+				}
+				if err := add(v); err != nil {
+					return nil, err
+				}
+				if len(stack) == 0 {
+					// only a single document is read
+					if _, err := dec.Token(); err != io.EOF {
+						return nil, fmt.Errorf("unexpected data after JSON document")
+					}
+					return result, nil
+				}
+			}
+		default:
+			if err := add(t); err != nil {
+				return nil, err
+			}
+		}
+	}
+}
+
+// DecodeJSONBytes is DecodeJSON for a byte slice.
+func (l Limits) DecodeJSONBytes(content []byte) (Object, error) {
+	if l.MaxSize > 0 && len(content) > l.MaxSize {
+		return nil, &LimitError{Kind: LimitSize, Max: l.MaxSize}
+	}
+	return l.DecodeJSON(bytes.NewReader(content))
+}
+
+// DecodeYAML decodes a YAML document from r like FromYAML while enforcing the
+// limits. Aliases count towards the keys every time they are expanded, so
+// the limits also apply to the decoded size of the document.
+func (l Limits) DecodeYAML(r io.Reader) (Object, error) {
+	if l.MaxSize > 0 {
+		r = &limitReader{r: r, remaining: l.MaxSize, max: l.MaxSize}
+	}
+	content, err := io.ReadAll(r)
+	if err != nil {
+		var limitErr *LimitError
+		if errors.As(err, &limitErr) {
+			return nil, limitErr
+		}
+		return nil, err
+	}
+	return decodeYAML(content, l)
+}
+
+// DecodeYAMLBytes is DecodeYAML for a byte slice.
+func (l Limits) DecodeYAMLBytes(content []byte) (Object, error) {
+	if l.MaxSize > 0 && len(content) > l.MaxSize {
+		return nil, &LimitError{Kind: LimitSize, Max: l.MaxSize}
+	}
+	return decodeYAML(content, l)
+}
+
+// Check validates the depth and key limits for an object that was built
+// from external input some other way. YAML should be decoded with
+// DecodeYAML instead, which stops before aliases are expanded too far.
+func (l Limits) Check(obj Object) error {
+	keys := 0
+	var err error
+	Walk(obj, func(path []string, value interface{}) bool {
+		keys++
+		if l.MaxKeys > 0 && keys > l.MaxKeys {
+			err = &LimitError{Kind: LimitKeys, Max: l.MaxKeys}
+			return true
+		}
+		if _, isMap := asMap(value); isMap && l.MaxDepth > 0 && len(path)+1 > l.MaxDepth {
+			err = &LimitError{Kind: LimitDepth, Max: l.MaxDepth}
+			return true
+		}
+		if _, isList := value.([]interface{}); isList && l.MaxDepth > 0 && len(path)+1 > l.MaxDepth {
+			err = &LimitError{Kind: LimitDepth, Max: l.MaxDepth}
+			return true
+		}
+		return false
+	})
+	return err
+}
+
+type limitReader struct {
+	r         io.Reader
+	remaining int
+	max       int
+}
+
+func (l *limitReader) Read(p []byte) (int, error) {
+	if l.remaining <= 0 {
+		// read one more byte to tell a payload of exactly max bytes from a
+		// larger one
+		var b [1]byte
+		if n, _ := l.r.Read(b[:]); n > 0 {
+			return 0, &LimitError{Kind: LimitSize, Max: l.max}
+		}
+		return 0, io.EOF
+	}
+	if len(p) > l.remaining {
+		p = p[:l.remaining]
+	}
+	n, err := l.r.Read(p)
+	l.remaining -= n
+	return n, err
+}
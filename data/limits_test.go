@@ -0,0 +1,94 @@
+package data
+
+import (
+	"errors"
+	"io"
+	"strings"
+	"testing"
+	"testing/iotest"
+)
+
+func TestLimitsDecodeJSON(t *testing.T) {
+	tests := []struct {
+		name    string
+		limits  Limits
+		content string
+		err     error
+	}{
+		{name: "depth", limits: Limits{MaxDepth: 2}, content: `{"a":{"b":1}}`},
+		{name: "too deep", limits: Limits{MaxDepth: 2}, content: `{"a":{"b":[1]}}`, err: &LimitError{Kind: LimitDepth, Max: 2}},
+		{name: "keys", limits: Limits{MaxKeys: 3}, content: `{"a":1,"b":[1]}`},
+		{name: "too many keys", limits: Limits{MaxKeys: 3}, content: `{"a":1,"b":[1,2]}`, err: &LimitError{Kind: LimitKeys, Max: 3}},
+		{name: "exact size", limits: Limits{MaxSize: 9}, content: `{"a":"b"}`},
+		{name: "too large", limits: Limits{MaxSize: 8}, content: `{"a":"b"}`, err: &LimitError{Kind: LimitSize, Max: 8}},
+		{name: "trailing data", content: `{"a":"b"} {}`, err: errors.New("unexpected data after JSON document")},
+		{name: "truncated", content: `{"a":`, err: io.ErrUnexpectedEOF},
+		{name: "not an object", content: `[1]`, err: errors.New("JSON document is a []interface {}, not an object")},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			// read byte by byte to hit the size limit exactly at the end
+			obj, err := test.limits.DecodeJSON(iotest.OneByteReader(strings.NewReader(test.content)))
+			checkLimitError(t, err, test.err)
+			if test.err == nil && obj == nil {
+				t.Error("expected an object")
+			}
+
+			_, err = test.limits.DecodeJSONBytes([]byte(test.content))
+			checkLimitError(t, err, test.err)
+		})
+	}
+}
+
+func TestLimitsDecodeYAML(t *testing.T) {
+	tests := []struct {
+		name    string
+		limits  Limits
+		content string
+		err     error
+	}{
+		{name: "depth", limits: Limits{MaxDepth: 2}, content: "a:\n  b: 1\n"},
+		{name: "too deep", limits: Limits{MaxDepth: 2}, content: "a:\n  b: [1]\n", err: &LimitError{Kind: LimitDepth, Max: 2}},
+		{name: "keys", limits: Limits{MaxKeys: 3}, content: "a: 1\nb: [1]\n"},
+		{name: "too many keys", limits: Limits{MaxKeys: 3}, content: "a: 1\nb: [1, 2]\n", err: &LimitError{Kind: LimitKeys, Max: 3}},
+		{name: "aliases count", limits: Limits{MaxKeys: 4}, content: "a: &a [1, 2]\nb: *a\n", err: &LimitError{Kind: LimitKeys, Max: 4}},
+		{name: "merge keys count", limits: Limits{MaxKeys: 4}, content: "a: &a {x: 1, y: 2}\nb: {<<: *a}\n", err: &LimitError{Kind: LimitKeys, Max: 4}},
+		{name: "alias bomb", limits: Limits{MaxKeys: 1000}, content: aliasBomb(9), err: &LimitError{Kind: LimitKeys, Max: 1000}},
+		{name: "exact size", limits: Limits{MaxSize: 5}, content: "a: b\n"},
+		{name: "too large", limits: Limits{MaxSize: 4}, content: "a: b\n", err: &LimitError{Kind: LimitSize, Max: 4}},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := test.limits.DecodeYAML(iotest.OneByteReader(strings.NewReader(test.content)))
+			checkLimitError(t, err, test.err)
+
+			_, err = test.limits.DecodeYAMLBytes([]byte(test.content))
+			checkLimitError(t, err, test.err)
+		})
+	}
+}
+
+func TestLimitsCheck(t *testing.T) {
+	obj := Object{"a": map[string]interface{}{"b": []interface{}{int64(1)}}}
+	if err := (Limits{MaxDepth: 3, MaxKeys: 3}).Check(obj); err != nil {
+		t.Errorf("unexpected error %v", err)
+	}
+	checkLimitError(t, Limits{MaxDepth: 2}.Check(obj), &LimitError{Kind: LimitDepth, Max: 2})
+	checkLimitError(t, Limits{MaxKeys: 2}.Check(obj), &LimitError{Kind: LimitKeys, Max: 2})
+}
+
+func checkLimitError(t *testing.T, err, expected error) {
+	t.Helper()
+	switch {
+	case expected == nil && err != nil:
+		t.Errorf("unexpected error %v", err)
+	case expected != nil && err == nil:
+		t.Errorf("expected error %v", expected)
+	case expected != nil && err.Error() != expected.Error():
+		t.Errorf("expected error %v, got %v", expected, err)
+	}
+	var limitErr *LimitError
+	if _, ok := expected.(*LimitError); ok && !errors.As(err, &limitErr) {
+		t.Errorf("expected a *LimitError, got %T", err)
+	}
+}
@@ -0,0 +1,66 @@
+package data
+
+import (
+	"sort"
+
+	"github.com/acorn-io/schemer/data/convert"
+)
+
+// Filter returns the objects for which fn returns true.
+func (l List) Filter(fn func(obj Object) bool) List {
+	var result List
+	for _, obj := range l {
+		if fn(obj) {
+			result = append(result, obj)
+		}
+	}
+	return result
+}
+
+// Find returns the first object for which fn returns true.
+func (l List) Find(fn func(obj Object) bool) (Object, bool) {
+	for _, obj := range l {
+		if fn(obj) {
+			return obj, true
+		}
+	}
+	return nil, false
+}
+
+// MapKeyed indexes the objects by the string form of the value at path, in
+// the syntax of GetPath. Objects without a value at path are skipped and
+// later objects win if keys are duplicated.
+func (l List) MapKeyed(path string) map[string]Object {
+	result := make(map[string]Object, len(l))
+	for _, obj := range l {
+		if v, ok := obj.GetPath(path); ok && !IsNull(v) {
+			result[convert.ToString(v)] = obj
+		}
+	}
+	return result
+}
+
+// SortBy sorts the list in place by the value at path and returns it. Numbers
+// are compared by value, everything else by its string form. Objects without
+// a value at path sort first and the order of equal objects is kept.
+func (l List) SortBy(path string) List {
+	sort.SliceStable(l, func(i, j int) bool {
+		a, aOK := l[i].GetPath(path)
+		b, bOK := l[j].GetPath(path)
+		if !aOK || !bOK {
+			return !aOK && bOK
+		}
+		if isNumber(a) && isNumber(b) {
+			af, _ := convert.ToFloat(a)
+			bf, _ := convert.ToFloat(b)
+			return af < bf
+		}
+		return convert.ToString(a) < convert.ToString(b)
+	})
+	return l
+}
+
+// Append returns the list with objs added to the end.
+func (l List) Append(objs ...Object) List {
+	return append(l, objs...)
+}
@@ -0,0 +1,64 @@
+package data
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func listNames(l List) []string {
+	var names []string
+	for _, obj := range l {
+		names = append(names, obj.String("name"))
+	}
+	return names
+}
+
+func testList() List {
+	return List{
+		{"name": "b", "spec": map[string]interface{}{"replicas": int64(10)}},
+		{"name": "a", "spec": map[string]interface{}{"replicas": json.Number("9")}},
+		{"name": "c"},
+		{"name": "d", "spec": map[string]interface{}{"replicas": 2.5}},
+	}
+}
+
+func TestListFilterFind(t *testing.T) {
+	l := testList()
+	scaled := func(obj Object) bool {
+		_, ok := obj.GetPath("spec.replicas")
+		return ok
+	}
+
+	if names := listNames(l.Filter(scaled)); !reflect.DeepEqual(names, []string{"b", "a", "d"}) {
+		t.Fatalf("unexpected filter result %v", names)
+	}
+	if obj, ok := l.Find(scaled); !ok || obj.String("name") != "b" {
+		t.Fatalf("expected b, got %v %v", obj, ok)
+	}
+	if _, ok := l.Find(func(Object) bool { return false }); ok {
+		t.Fatal("expected nothing to be found")
+	}
+}
+
+func TestListSortBy(t *testing.T) {
+	// numbers of different types are compared by value and objects without
+	// the value sort first
+	if names := listNames(testList().SortBy("spec.replicas")); !reflect.DeepEqual(names, []string{"c", "d", "a", "b"}) {
+		t.Fatalf("unexpected order %v", names)
+	}
+	if names := listNames(testList().SortBy("name")); !reflect.DeepEqual(names, []string{"a", "b", "c", "d"}) {
+		t.Fatalf("unexpected order %v", names)
+	}
+}
+
+func TestListMapKeyed(t *testing.T) {
+	l := testList().Append(Object{"name": "a", "duplicate": true}, Object{"name": Null})
+	keyed := l.MapKeyed("name")
+	if len(keyed) != 4 {
+		t.Fatalf("expected 4 keys, got %v", keyed)
+	}
+	if !keyed["a"].Bool("duplicate") {
+		t.Fatalf("expected the later object to win, got %v", keyed["a"])
+	}
+}
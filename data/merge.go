@@ -0,0 +1,213 @@
+package data
+
+import (
+	"reflect"
+	"strings"
+)
+
+const (
+	patchDirective          = "$patch"
+	deleteFromPrimitiveList = "$deleteFromPrimitiveList/"
+	setElementOrder         = "$setElementOrder/"
+)
+
+// MergeSchema provides the strategic merge metadata of an object's fields.
+// The schemas package implements it for a Schema with Schema.PatchSchema.
+type MergeSchema interface {
+	// MergeStrategy returns the patchStrategy and patchMergeKey of the field.
+	MergeStrategy(field string) (strategy, mergeKey string)
+	// FieldSchema returns the schema of the field's value, or of its
+	// elements for lists and maps. It returns nil for primitive fields.
+	FieldSchema(field string) MergeSchema
+}
+
+// MergePatch applies patch to base using Kubernetes strategic merge patch
+// semantics and returns the result, base is not modified. Lists whose field
+// has the "merge" patch strategy are merged by their patchMergeKey, or as a
+// set for lists of primitives, all other lists are replaced. A nil or Null
+// value removes a key and the "$patch" directive supports "replace" and "delete".
+// A nil schema merges all lists by replacing them. "$setElementOrder"
+// directives are ignored, merged lists keep the order of base with new items
+// appended in the order of the patch.
+func MergePatch(base, patch Object, schema MergeSchema) Object {
+	result, _ := mergeObject(base, patch, schema)
+	return result
+}
+
+func mergeObject(base, patch map[string]interface{}, schema MergeSchema) (Object, bool) {
+	switch patch[patchDirective] {
+	case "delete":
+		return nil, false
+	case "replace":
+		result := Object{}
+		for k, v := range patch {
+			if k != patchDirective {
+				result[k] = copyValue(v)
+			}
+		}
+		return result, true
+	}
+
+	result := make(Object, len(base))
+	for k, v := range base {
+		result[k] = copyValue(v)
+	}
+
+	for k, v := range patch {
+		if k == patchDirective || strings.HasPrefix(k, setElementOrder) {
+			continue
+		}
+		if strings.HasPrefix(k, deleteFromPrimitiveList) {
+			field := strings.TrimPrefix(k, deleteFromPrimitiveList)
+			if items, ok := result[field].([]interface{}); ok {
+				result[field] = removeValues(items, toSlice(v))
+			}
+			continue
+		}
+		if IsNull(v) {
+			delete(result, k)
+			continue
+		}
+
+		var fieldSchema MergeSchema
+		if schema != nil {
+			fieldSchema = schema.FieldSchema(k)
+		}
+
+		switch patchValue := v.(type) {
+		case map[string]interface{}, Object:
+			baseMap, _ := asMap(result[k])
+			merged, keep := mergeObject(baseMap, toMap(patchValue), fieldSchema)
+			if keep {
+				result[k] = map[string]interface{}(merged)
+			} else {
+				delete(result, k)
+			}
+		case []interface{}:
+			var strategy, mergeKey string
+			if schema != nil {
+				strategy, mergeKey = schema.MergeStrategy(k)
+			}
+			baseList, _ := result[k].([]interface{})
+			if hasStrategy(strategy, "merge") {
+				result[k] = mergeList(baseList, patchValue, mergeKey, fieldSchema)
+			} else {
+				result[k] = copyValue(patchValue)
+			}
+		default:
+			result[k] = copyValue(v)
+		}
+	}
+
+	return result, true
+}
+
+func mergeList(base, patch []interface{}, mergeKey string, schema MergeSchema) []interface{} {
+	result := make([]interface{}, 0, len(base)+len(patch))
+	for _, item := range base {
+		result = append(result, copyValue(item))
+	}
+
+	for _, item := range patch {
+		if patchItem, isMap := asMap(item); isMap && patchItem[patchDirective] == "replace" {
+			// a replace directive in a list replaces the whole list
+			return copyValue(withoutDirectives(patch)).([]interface{})
+		}
+	}
+
+	for _, item := range patch {
+		patchItem, isMap := asMap(item)
+		if isMap && mergeKey == "" {
+			if _, ok := patchItem[patchDirective]; ok {
+				// a delete directive needs a merge key to select the item
+				continue
+			}
+		}
+		if !isMap || mergeKey == "" {
+			if !containsValue(result, item) {
+				result = append(result, copyValue(item))
+			}
+			continue
+		}
+
+		key, ok := patchItem[mergeKey]
+		index := -1
+		if ok {
+			for i, existing := range result {
+				if existingMap, ok := asMap(existing); ok && reflect.DeepEqual(existingMap[mergeKey], key) {
+					index = i
+					break
+				}
+			}
+		}
+
+		if index < 0 {
+			if patchItem[patchDirective] != "delete" {
+				merged, _ := mergeObject(nil, patchItem, schema)
+				result = append(result, map[string]interface{}(merged))
+			}
+			continue
+		}
+
+		existingMap, _ := asMap(result[index])
+		merged, keep := mergeObject(existingMap, patchItem, schema)
+		if keep {
+			result[index] = map[string]interface{}(merged)
+		} else {
+			result = append(result[:index], result[index+1:]...)
+		}
+	}
+
+	return result
+}
+
+func hasStrategy(strategy, name string) bool {
+	for _, s := range strings.Split(strategy, ",") {
+		if s == name {
+			return true
+		}
+	}
+	return false
+}
+
+func withoutDirectives(items []interface{}) []interface{} {
+	result := make([]interface{}, 0, len(items))
+	for _, item := range items {
+		if m, ok := asMap(item); ok {
+			if _, ok := m[patchDirective]; ok {
+				continue
+			}
+		}
+		result = append(result, item)
+	}
+	return result
+}
+
+func containsValue(items []interface{}, value interface{}) bool {
+	for _, item := range items {
+		if reflect.DeepEqual(item, value) {
+			return true
+		}
+	}
+	return false
+}
+
+func removeValues(items, remove []interface{}) []interface{} {
+	result := make([]interface{}, 0, len(items))
+	for _, item := range items {
+		if !containsValue(remove, item) {
+			result = append(result, item)
+		}
+	}
+	return result
+}
+
+func toSlice(v interface{}) []interface{} {
+	items, _ := v.([]interface{})
+	return items
+}
+
+func toMap(v interface{}) map[string]interface{} {
+	m, _ := asMap(v)
+	return m
+}
@@ -0,0 +1,142 @@
+package data
+
+import (
+	"reflect"
+	"testing"
+
+	"k8s.io/apimachinery/pkg/util/strategicpatch"
+)
+
+type smpContainer struct {
+	Name  string   `json:"name"`
+	Image string   `json:"image,omitempty"`
+	Args  []string `json:"args,omitempty"`
+}
+
+type smpSpec struct {
+	Containers []smpContainer `json:"containers,omitempty" patchStrategy:"merge" patchMergeKey:"name"`
+	Finalizers []string       `json:"finalizers,omitempty" patchStrategy:"merge"`
+	Args       []string       `json:"args,omitempty"`
+}
+
+// testMergeSchema describes smpSpec.
+type testMergeSchema map[string][2]string
+
+func (t testMergeSchema) MergeStrategy(field string) (string, string) {
+	return t[field][0], t[field][1]
+}
+
+func (t testMergeSchema) FieldSchema(string) MergeSchema {
+	return nil
+}
+
+var smpSchema = testMergeSchema{
+	"containers": {"merge", "name"},
+	"finalizers": {"merge", ""},
+}
+
+func smpBase() Object {
+	return Object{
+		"containers": []interface{}{
+			map[string]interface{}{"name": "a", "image": "a:1", "args": []interface{}{"x"}},
+			map[string]interface{}{"name": "b", "image": "b:1"},
+		},
+		"finalizers": []interface{}{"f1", "f2"},
+		"args":       []interface{}{"1", "2"},
+	}
+}
+
+func TestMergePatchMatchesStrategicMergePatch(t *testing.T) {
+	tests := []struct {
+		name  string
+		patch Object
+	}{
+		{
+			name: "merge key list",
+			patch: Object{"containers": []interface{}{
+				map[string]interface{}{"name": "b", "image": "b:2"},
+				map[string]interface{}{"name": "c", "image": "c:1"},
+			}},
+		},
+		{
+			name:  "primitive set",
+			patch: Object{"finalizers": []interface{}{"f2", "f3"}},
+		},
+		{
+			name:  "replaced list",
+			patch: Object{"args": []interface{}{"3"}},
+		},
+		{
+			name: "delete directive",
+			patch: Object{"containers": []interface{}{
+				map[string]interface{}{"name": "a", "$patch": "delete"},
+				map[string]interface{}{"name": "missing", "$patch": "delete"},
+			}},
+		},
+		{
+			name: "replace directive",
+			patch: Object{"containers": []interface{}{
+				map[string]interface{}{"name": "c", "image": "c:1"},
+				map[string]interface{}{"$patch": "replace"},
+			}},
+		},
+		{
+			name:  "delete from primitive list",
+			patch: Object{"$deleteFromPrimitiveList/finalizers": []interface{}{"f1"}},
+		},
+		{
+			name:  "null removes a field",
+			patch: Object{"args": nil},
+		},
+		{
+			name:  "replace object",
+			patch: Object{"$patch": "replace", "args": []interface{}{"9"}},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			expected, err := strategicpatch.StrategicMergeMapPatch(strategicpatch.JSONMap(smpBase()), strategicpatch.JSONMap(test.patch.DeepCopy()), smpSpec{})
+			if err != nil {
+				t.Fatal(err)
+			}
+			actual := MergePatch(smpBase(), test.patch, smpSchema)
+			if !Equal(Object(expected), actual) {
+				t.Errorf("expected %v, got %v", expected, actual)
+			}
+		})
+	}
+}
+
+func TestMergePatchReplacePrimitiveList(t *testing.T) {
+	// rejected by Kubernetes, the directive must not end up in the list
+	actual := MergePatch(smpBase(), Object{"finalizers": []interface{}{
+		"f3",
+		map[string]interface{}{"$patch": "replace"},
+	}}, smpSchema)
+	if expected := []interface{}{"f3"}; !reflect.DeepEqual(actual["finalizers"], expected) {
+		t.Errorf("expected %v, got %v", expected, actual["finalizers"])
+	}
+}
+
+func TestMergePatchSetElementOrder(t *testing.T) {
+	actual := MergePatch(smpBase(), Object{
+		"$setElementOrder/finalizers": []interface{}{"f3", "f1", "f2"},
+		"finalizers":                  []interface{}{"f3"},
+	}, smpSchema)
+	if expected := []interface{}{"f1", "f2", "f3"}; !reflect.DeepEqual(actual["finalizers"], expected) {
+		t.Errorf("expected %v, got %v", expected, actual["finalizers"])
+	}
+	if _, ok := actual["$setElementOrder/finalizers"]; ok {
+		t.Errorf("expected the directive to be removed, got %v", actual)
+	}
+}
+
+func TestMergePatchDoesNotModifyBase(t *testing.T) {
+	base := smpBase()
+	MergePatch(base, Object{"containers": []interface{}{
+		map[string]interface{}{"name": "a", "image": "a:2"},
+	}}, smpSchema)
+	if !Equal(base, smpBase()) {
+		t.Errorf("base was modified: %v", base)
+	}
+}
@@ -0,0 +1,103 @@
+package data
+
+// ApplyMergePatch applies an RFC 7386 JSON Merge Patch to o and returns the
+// result, o is not modified. Objects in the patch are merged recursively,
+// nil and Null values remove keys and every other value, including lists,
+// replaces the existing value.
+func (o Object) ApplyMergePatch(patch Object) Object {
+	return applyMergePatch(o, patch)
+}
+
+func applyMergePatch(base, patch map[string]interface{}) Object {
+	result := make(Object, len(base))
+	for k, v := range base {
+		result[k] = copyValue(v)
+	}
+
+	for k, v := range patch {
+		if IsNull(v) {
+			delete(result, k)
+			continue
+		}
+		if patchMap, ok := asMap(v); ok {
+			baseMap, _ := asMap(result[k])
+			result[k] = map[string]interface{}(applyMergePatch(baseMap, patchMap))
+			continue
+		}
+		result[k] = copyValue(v)
+	}
+
+	return result
+}
+
+// CreateMergePatch returns the JSON Merge Patch that turns old into new. The
+// patch is empty if both are equal.
+func CreateMergePatch(old, new Object) Object {
+	return createMergePatch(old, new)
+}
+
+func createMergePatch(old, new map[string]interface{}) Object {
+	patch := Object{}
+	for k := range old {
+		if _, ok := new[k]; !ok {
+			patch[k] = nil
+		}
+	}
+
+	for k, newValue := range new {
+		oldValue, ok := old[k]
+		if ok && equalValue(oldValue, newValue) {
+			continue
+		}
+
+		oldMap, oldIsMap := asMap(oldValue)
+		newMap, newIsMap := asMap(newValue)
+		if ok && oldIsMap && newIsMap && isNilValue(oldValue) == isNilValue(newValue) {
+			patch[k] = map[string]interface{}(createMergePatch(oldMap, newMap))
+			continue
+		}
+		patch[k] = copyValue(newValue)
+	}
+
+	return patch
+}
+
+// CreateThreeWayMergePatch returns the JSON Merge Patch that applies the
+// changes made from original to modified onto current. Keys that were added
+// to current by someone else are kept, only keys removed between original
+// and modified are deleted.
+func CreateThreeWayMergePatch(original, modified, current Object) Object {
+	return createThreeWayMergePatch(original, modified, current)
+}
+
+func createThreeWayMergePatch(original, modified, current map[string]interface{}) Object {
+	patch := Object{}
+	for k := range original {
+		if _, ok := modified[k]; ok {
+			continue
+		}
+		if _, ok := current[k]; ok {
+			patch[k] = nil
+		}
+	}
+
+	for k, modifiedValue := range modified {
+		currentValue, ok := current[k]
+		if ok && equalValue(currentValue, modifiedValue) {
+			continue
+		}
+
+		currentMap, currentIsMap := asMap(currentValue)
+		modifiedMap, modifiedIsMap := asMap(modifiedValue)
+		if ok && currentIsMap && modifiedIsMap && isNilValue(currentValue) == isNilValue(modifiedValue) {
+			originalMap, _ := asMap(original[k])
+			if nested := createThreeWayMergePatch(originalMap, modifiedMap, currentMap); len(nested) > 0 {
+				patch[k] = map[string]interface{}(nested)
+			}
+			continue
+		}
+		patch[k] = copyValue(modifiedValue)
+	}
+
+	return patch
+}
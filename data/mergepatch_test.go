@@ -0,0 +1,118 @@
+package data
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestApplyMergePatch(t *testing.T) {
+	// the examples of RFC 7386 section 3
+	tests := []struct {
+		name                  string
+		base, patch, expected Object
+	}{
+		{
+			name:     "replace",
+			base:     Object{"a": "b"},
+			patch:    Object{"a": "c"},
+			expected: Object{"a": "c"},
+		},
+		{
+			name:     "add",
+			base:     Object{"a": "b"},
+			patch:    Object{"b": "c"},
+			expected: Object{"a": "b", "b": "c"},
+		},
+		{
+			name:     "remove",
+			base:     Object{"a": "b", "b": "c"},
+			patch:    Object{"a": nil},
+			expected: Object{"b": "c"},
+		},
+		{
+			name:     "replace list",
+			base:     Object{"a": []interface{}{"b"}},
+			patch:    Object{"a": []interface{}{"c", "d"}},
+			expected: Object{"a": []interface{}{"c", "d"}},
+		},
+		{
+			name:     "nested",
+			base:     Object{"a": map[string]interface{}{"b": "c", "d": "e"}},
+			patch:    Object{"a": map[string]interface{}{"b": "x", "d": nil}},
+			expected: Object{"a": map[string]interface{}{"b": "x"}},
+		},
+		{
+			name:     "object over value",
+			base:     Object{"a": "b"},
+			patch:    Object{"a": map[string]interface{}{"b": "c", "d": nil}},
+			expected: Object{"a": map[string]interface{}{"b": "c"}},
+		},
+		{
+			name:     "null value",
+			base:     Object{"a": "b"},
+			patch:    Object{"a": Null},
+			expected: Object{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			base := tt.base.DeepCopy()
+			result := tt.base.ApplyMergePatch(tt.patch)
+			if !reflect.DeepEqual(result, tt.expected) {
+				t.Fatalf("expected %v, got %v", tt.expected, result)
+			}
+			if !reflect.DeepEqual(tt.base, base) {
+				t.Fatalf("expected the base to be unchanged, got %v", tt.base)
+			}
+		})
+	}
+}
+
+func TestCreateMergePatch(t *testing.T) {
+	old := Object{
+		"name":   "app",
+		"labels": map[string]interface{}{"a": "1", "b": "2"},
+		"ports":  []interface{}{int64(80)},
+		"gone":   "value",
+	}
+	new := Object{
+		"name":   "app",
+		"labels": map[string]interface{}{"a": "1", "c": "3"},
+		"ports":  []interface{}{int64(80), int64(443)},
+	}
+
+	patch := CreateMergePatch(old, new)
+	expected := Object{
+		"labels": map[string]interface{}{"b": nil, "c": "3"},
+		"ports":  []interface{}{int64(80), int64(443)},
+		"gone":   nil,
+	}
+	if !reflect.DeepEqual(patch, expected) {
+		t.Fatalf("expected %v, got %v", expected, patch)
+	}
+	if result := old.ApplyMergePatch(patch); !reflect.DeepEqual(result, new) {
+		t.Fatalf("expected the patch to produce %v, got %v", new, result)
+	}
+	if patch := CreateMergePatch(old, old.DeepCopy()); len(patch) != 0 {
+		t.Fatalf("expected an empty patch, got %v", patch)
+	}
+}
+
+func TestCreateThreeWayMergePatch(t *testing.T) {
+	original := Object{"replicas": int64(1), "image": "nginx:1", "debug": true}
+	modified := Object{"replicas": int64(1), "image": "nginx:2"}
+	// someone else scaled and added an annotation
+	current := Object{"replicas": int64(3), "image": "nginx:1", "debug": true, "annotation": "x"}
+
+	patch := CreateThreeWayMergePatch(original, modified, current)
+	expected := Object{"replicas": int64(1), "image": "nginx:2", "debug": nil}
+	if !reflect.DeepEqual(patch, expected) {
+		t.Fatalf("expected %v, got %v", expected, patch)
+	}
+
+	result := current.ApplyMergePatch(patch)
+	if expected := (Object{"replicas": int64(1), "image": "nginx:2", "annotation": "x"}); !reflect.DeepEqual(result, expected) {
+		t.Fatalf("expected %v, got %v", expected, result)
+	}
+}
@@ -0,0 +1,127 @@
+package data
+
+import (
+	"fmt"
+	"strings"
+)
+
+// The Nested accessors mirror the helpers of k8s.io/apimachinery's
+// unstructured package. found is false if the value is missing and err is
+// set if it has the wrong type.
+
+func (o Object) NestedField(keys ...string) (interface{}, bool) {
+	return GetValue(o, keys...)
+}
+
+// NestedFieldCopy returns a deep copy of the nested value.
+func (o Object) NestedFieldCopy(keys ...string) (interface{}, bool) {
+	v, found := GetValue(o, keys...)
+	return copyValue(v), found
+}
+
+func (o Object) NestedString(keys ...string) (string, bool, error) {
+	v, found := GetValue(o, keys...)
+	if !found {
+		return "", false, nil
+	}
+	s, ok := v.(string)
+	if !ok {
+		return "", true, nestedTypeError(keys, "string", v)
+	}
+	return s, true, nil
+}
+
+func (o Object) NestedBool(keys ...string) (bool, bool, error) {
+	v, found := GetValue(o, keys...)
+	if !found {
+		return false, false, nil
+	}
+	b, ok := v.(bool)
+	if !ok {
+		return false, true, nestedTypeError(keys, "bool", v)
+	}
+	return b, true, nil
+}
+
+// NestedInt64 accepts all integer types, json.Number and integral floats.
+func (o Object) NestedInt64(keys ...string) (int64, bool, error) {
+	if _, found := GetValue(o, keys...); !found {
+		return 0, false, nil
+	}
+	i, err := o.Int64E(keys...)
+	return i, true, err
+}
+
+// NestedFloat64 accepts all number types.
+func (o Object) NestedFloat64(keys ...string) (float64, bool, error) {
+	if _, found := GetValue(o, keys...); !found {
+		return 0, false, nil
+	}
+	f, err := o.Float64E(keys...)
+	return f, true, err
+}
+
+func (o Object) NestedStringSlice(keys ...string) ([]string, bool, error) {
+	v, found := GetValue(o, keys...)
+	if !found {
+		return nil, false, nil
+	}
+	s, err := o.StringSliceE(keys...)
+	if err != nil {
+		return nil, true, nestedTypeError(keys, "[]string", v)
+	}
+	return s, true, nil
+}
+
+// NestedSlice returns a deep copy of the nested []interface{}.
+func (o Object) NestedSlice(keys ...string) ([]interface{}, bool, error) {
+	v, found := GetValue(o, keys...)
+	if !found {
+		return nil, false, nil
+	}
+	items, ok := v.([]interface{})
+	if !ok {
+		return nil, true, nestedTypeError(keys, "[]interface{}", v)
+	}
+	return copyValue(items).([]interface{}), true, nil
+}
+
+// NestedMap returns a deep copy of the nested object.
+func (o Object) NestedMap(keys ...string) (Object, bool, error) {
+	v, found := GetValue(o, keys...)
+	if !found {
+		return nil, false, nil
+	}
+	m, ok := asMap(v)
+	if !ok {
+		return nil, true, nestedTypeError(keys, "map[string]interface{}", v)
+	}
+	return Object(m).DeepCopy(), true, nil
+}
+
+func (o Object) NestedStringMap(keys ...string) (map[string]string, bool, error) {
+	v, found := GetValue(o, keys...)
+	if !found {
+		return nil, false, nil
+	}
+	if m, ok := v.(map[string]string); ok {
+		return copyValue(m).(map[string]string), true, nil
+	}
+	m, ok := asMap(v)
+	if !ok {
+		return nil, true, nestedTypeError(keys, "map[string]string", v)
+	}
+	result := make(map[string]string, len(m))
+	for k, item := range m {
+		s, ok := item.(string)
+		if !ok {
+			return nil, true, nestedTypeError(append(keys[:len(keys):len(keys)], k), "string", item)
+		}
+		result[k] = s
+	}
+	return result, true, nil
+}
+
+func nestedTypeError(keys []string, expected string, value interface{}) error {
+	return fmt.Errorf("%v accessor error: %v is of the type %T, expected %s", strings.Join(keys, "."), value, value, expected)
+}
@@ -0,0 +1,130 @@
+package data
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+func nestedObject() Object {
+	return Object{
+		"metadata": map[string]interface{}{
+			"name":   "app",
+			"labels": map[string]interface{}{"app": "web"},
+		},
+		"spec": map[string]interface{}{
+			"paused":   true,
+			"replicas": int64(3),
+			"args":     []interface{}{"-v"},
+			"ratio":    0.5,
+		},
+	}
+}
+
+// TestNestedMatchesUnstructured checks that the accessors return what the
+// unstructured helpers return for values of the expected type.
+func TestNestedMatchesUnstructured(t *testing.T) {
+	obj := nestedObject()
+	u := map[string]interface{}(nestedObject())
+
+	for _, keys := range [][]string{{"metadata", "name"}, {"missing"}} {
+		s, found, err := obj.NestedString(keys...)
+		us, ufound, uerr := unstructured.NestedString(u, keys...)
+		if s != us || found != ufound || (err == nil) != (uerr == nil) {
+			t.Errorf("NestedString %v: expected %q %v %v, got %q %v %v", keys, us, ufound, uerr, s, found, err)
+		}
+	}
+
+	for _, keys := range [][]string{{"spec", "paused"}, {"missing"}} {
+		b, found, err := obj.NestedBool(keys...)
+		ub, ufound, uerr := unstructured.NestedBool(u, keys...)
+		if b != ub || found != ufound || (err == nil) != (uerr == nil) {
+			t.Errorf("NestedBool %v: expected %v %v %v, got %v %v %v", keys, ub, ufound, uerr, b, found, err)
+		}
+	}
+
+	i, found, err := obj.NestedInt64("spec", "replicas")
+	ui, ufound, uerr := unstructured.NestedInt64(u, "spec", "replicas")
+	if i != ui || found != ufound || err != nil || uerr != nil {
+		t.Errorf("NestedInt64: expected %v %v %v, got %v %v %v", ui, ufound, uerr, i, found, err)
+	}
+
+	labels, found, err := obj.NestedStringMap("metadata", "labels")
+	ulabels, ufound, uerr := unstructured.NestedStringMap(u, "metadata", "labels")
+	if !reflect.DeepEqual(labels, ulabels) || found != ufound || err != nil || uerr != nil {
+		t.Errorf("NestedStringMap: expected %v %v %v, got %v %v %v", ulabels, ufound, uerr, labels, found, err)
+	}
+
+	args, found, err := obj.NestedStringSlice("spec", "args")
+	uargs, ufound, uerr := unstructured.NestedStringSlice(u, "spec", "args")
+	if !reflect.DeepEqual(args, uargs) || found != ufound || err != nil || uerr != nil {
+		t.Errorf("NestedStringSlice: expected %v %v %v, got %v %v %v", uargs, ufound, uerr, args, found, err)
+	}
+}
+
+func TestNestedWrongType(t *testing.T) {
+	obj := nestedObject()
+
+	// unlike the unstructured helpers, found reports that the value exists
+	if s, found, err := obj.NestedString("spec", "replicas"); s != "" || !found || err == nil {
+		t.Fatalf("expected a type error, got %q %v %v", s, found, err)
+	} else if !strings.Contains(err.Error(), "spec.replicas accessor error") {
+		t.Fatalf("expected the path in the error, got %v", err)
+	}
+	if _, found, err := obj.NestedBool("metadata", "name"); !found || err == nil {
+		t.Fatalf("expected a type error, got %v %v", found, err)
+	}
+	if _, found, err := obj.NestedStringSlice("spec", "paused"); !found || err == nil {
+		t.Fatalf("expected a type error, got %v %v", found, err)
+	}
+}
+
+func TestNestedNumbers(t *testing.T) {
+	obj := Object{"a": json.Number("9007199254740993"), "b": 2.0, "c": 2.5, "d": "x"}
+
+	if i, found, err := obj.NestedInt64("a"); i != 9007199254740993 || !found || err != nil {
+		t.Fatalf("expected json.Number to be accepted, got %v %v %v", i, found, err)
+	}
+	if i, _, err := obj.NestedInt64("b"); i != 2 || err != nil {
+		t.Fatalf("expected an integral float to be accepted, got %v %v", i, err)
+	}
+	if _, found, err := obj.NestedInt64("c"); !found || err == nil {
+		t.Fatal("expected error for a fraction")
+	}
+	if f, _, err := obj.NestedFloat64("c"); f != 2.5 || err != nil {
+		t.Fatalf("expected 2.5, got %v %v", f, err)
+	}
+	if _, found, err := obj.NestedFloat64("d"); !found || err == nil {
+		t.Fatal("expected error for a string")
+	}
+}
+
+func TestNestedCopies(t *testing.T) {
+	obj := nestedObject()
+
+	m, found, err := obj.NestedMap("metadata")
+	if !found || err != nil {
+		t.Fatal(found, err)
+	}
+	m.Map("labels")["app"] = "changed"
+
+	args, _, _ := obj.NestedSlice("spec", "args")
+	args[0] = "changed"
+
+	v, _ := obj.NestedFieldCopy("metadata", "labels")
+	v.(map[string]interface{})["app"] = "changed"
+
+	if !reflect.DeepEqual(obj, nestedObject()) {
+		t.Fatalf("expected the object to be unchanged, got %v", obj)
+	}
+
+	if _, _, err := obj.NestedMap("metadata", "name"); err == nil {
+		t.Fatal("expected error for a string")
+	}
+	if _, _, err := (Object{"m": map[string]interface{}{"a": int64(1)}}).NestedStringMap("m"); err == nil {
+		t.Fatal("expected error for a map with a number")
+	}
+}
@@ -0,0 +1,83 @@
+package data
+
+import (
+	"bytes"
+	"encoding/json"
+)
+
+// NullValue marks a key that was explicitly set to null, as opposed to a key
+// that is absent. Plain nil values are easily lost, for example when an
+// object is pruned or mapped, so in null tracking mode nulls are stored as
+// Null instead. Null encodes to JSON null and merge patches created from an
+// object containing it clear the key.
+type NullValue struct{}
+
+var Null = NullValue{}
+
+func (NullValue) MarshalJSON() ([]byte, error) {
+	return []byte("null"), nil
+}
+
+func (NullValue) String() string {
+	return "null"
+}
+
+// IsNull returns true if v is nil or Null.
+func IsNull(v interface{}) bool {
+	if v == nil {
+		return true
+	}
+	_, ok := v.(NullValue)
+	return ok
+}
+
+// FromJSONWithNulls decodes a JSON object and stores every null as Null so
+// that explicitly cleared keys can be told apart from absent ones. Numbers
+// are decoded as json.Number.
+func FromJSONWithNulls(content []byte) (Object, error) {
+	var obj map[string]interface{}
+	dec := json.NewDecoder(bytes.NewReader(content))
+	dec.UseNumber()
+	if err := dec.Decode(&obj); err != nil {
+		return nil, err
+	}
+	return Object(obj).MarkNulls(), nil
+}
+
+// MarkNulls replaces all nil values nested in o with Null and returns o.
+func (o Object) MarkNulls() Object {
+	replaceNulls(o, func(v interface{}) interface{} {
+		if v == nil {
+			return Null
+		}
+		return v
+	})
+	return o
+}
+
+// ClearNulls replaces all Null values nested in o with nil and returns o.
+func (o Object) ClearNulls() Object {
+	replaceNulls(o, func(v interface{}) interface{} {
+		if _, ok := v.(NullValue); ok {
+			return nil
+		}
+		return v
+	})
+	return o
+}
+
+func replaceNulls(v interface{}, replace func(interface{}) interface{}) {
+	if m, ok := asMap(v); ok {
+		for k, item := range m {
+			m[k] = replace(item)
+			replaceNulls(item, replace)
+		}
+		return
+	}
+	if items, ok := v.([]interface{}); ok {
+		for i, item := range items {
+			items[i] = replace(item)
+			replaceNulls(item, replace)
+		}
+	}
+}
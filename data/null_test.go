@@ -0,0 +1,78 @@
+package data
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func TestFromJSONWithNulls(t *testing.T) {
+	obj, err := FromJSONWithNulls([]byte(`{"a": null, "b": {"c": null, "d": 1}, "e": [null, "f"]}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := Object{
+		"a": Null,
+		"b": map[string]interface{}{"c": Null, "d": json.Number("1")},
+		"e": []interface{}{Null, "f"},
+	}
+	if !reflect.DeepEqual(obj, expected) {
+		t.Fatalf("expected %v, got %v", expected, obj)
+	}
+
+	// null and absent keys can be told apart
+	if v, ok := obj["a"]; !ok || !IsNull(v) {
+		t.Fatalf("expected a to be null, got %v %v", v, ok)
+	}
+	if _, ok := obj["missing"]; ok {
+		t.Fatal("expected missing to be absent")
+	}
+
+	// nulls survive pruning
+	obj.Prune()
+	if !IsNull(obj["a"]) {
+		t.Fatalf("expected a to survive Prune, got %v", obj)
+	}
+
+	content, err := json.Marshal(obj)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(content) != `{"a":null,"b":{"c":null,"d":1},"e":[null,"f"]}` {
+		t.Fatalf("unexpected JSON %s", content)
+	}
+
+	if _, err := FromJSONWithNulls([]byte(`[1]`)); err == nil {
+		t.Fatal("expected error for JSON that is not an object")
+	}
+}
+
+func TestClearNulls(t *testing.T) {
+	obj := Object{
+		"a": Null,
+		"b": map[string]interface{}{"c": Null},
+		"d": []interface{}{Null},
+	}
+	expected := Object{
+		"a": nil,
+		"b": map[string]interface{}{"c": nil},
+		"d": []interface{}{nil},
+	}
+	if result := obj.ClearNulls(); !reflect.DeepEqual(result, expected) {
+		t.Fatalf("expected %v, got %v", expected, result)
+	}
+	if result := obj.MarkNulls(); !reflect.DeepEqual(result["b"], map[string]interface{}{"c": Null}) {
+		t.Fatalf("expected nulls to be marked again, got %v", result)
+	}
+}
+
+func TestNullMergePatch(t *testing.T) {
+	patch, err := FromJSONWithNulls([]byte(`{"a": null}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	result := Object{"a": "b", "c": "d"}.ApplyMergePatch(patch)
+	if expected := (Object{"c": "d"}); !reflect.DeepEqual(result, expected) {
+		t.Fatalf("expected Null to clear the key, got %v", result)
+	}
+}
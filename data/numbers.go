@@ -0,0 +1,60 @@
+package data
+
+import (
+	"bytes"
+	"encoding/json"
+	"math"
+	"strconv"
+
+	"github.com/acorn-io/schemer/data/convert"
+)
+
+// FromJSON decodes a JSON object with numbers kept as json.Number, so
+// integers larger than 2^53 don't lose precision.
+func FromJSON(content []byte) (Object, error) {
+	var obj map[string]interface{}
+	dec := json.NewDecoder(bytes.NewReader(content))
+	dec.UseNumber()
+	if err := dec.Decode(&obj); err != nil {
+		return nil, err
+	}
+	return obj, nil
+}
+
+// Int64 returns the nested value as int64 without going through float64 for
+// json.Number and integer values. Invalid values return 0.
+func (o Object) Int64(names ...string) int64 {
+	if i, err := o.Int64E(names...); err == nil {
+		return i
+	}
+	i, _ := convert.ToNumber(GetValueN(o, names...))
+	return i
+}
+
+// Uint64 returns the nested value as uint64, including values larger than
+// math.MaxInt64. Invalid and negative values return 0.
+func (o Object) Uint64(names ...string) uint64 {
+	switch t := GetValueN(o, names...).(type) {
+	case uint64:
+		return t
+	case uint:
+		return uint64(t)
+	case json.Number:
+		if u, err := strconv.ParseUint(string(t), 10, 64); err == nil {
+			return u
+		}
+	case string:
+		if u, err := strconv.ParseUint(t, 10, 64); err == nil {
+			return u
+		}
+	case float64:
+		if t >= 0 && t < math.MaxUint64 && t == math.Trunc(t) {
+			return uint64(t)
+		}
+		return 0
+	}
+	if i := o.Int64(names...); i > 0 {
+		return uint64(i)
+	}
+	return 0
+}
@@ -0,0 +1,80 @@
+package data
+
+import (
+	"encoding/json"
+	"math"
+	"testing"
+
+	"github.com/acorn-io/schemer/data/convert"
+)
+
+func TestFromJSONLargeNumbers(t *testing.T) {
+	obj, err := FromJSON([]byte(`{"int": 9007199254740993, "max": 18446744073709551615, "neg": -9223372036854775808}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if obj["int"] != json.Number("9007199254740993") {
+		t.Fatalf("expected a json.Number, got %#v", obj["int"])
+	}
+	if i := obj.Int64("int"); i != 9007199254740993 {
+		t.Fatalf("expected 9007199254740993, got %d", i)
+	}
+	if i := obj.Int64("neg"); i != math.MinInt64 {
+		t.Fatalf("expected MinInt64, got %d", i)
+	}
+	if u := obj.Uint64("max"); u != math.MaxUint64 {
+		t.Fatalf("expected MaxUint64, got %d", u)
+	}
+
+	if _, err := FromJSON([]byte(`{"a":`)); err == nil {
+		t.Fatal("expected error for invalid JSON")
+	}
+}
+
+func TestUint64(t *testing.T) {
+	for _, tt := range []struct {
+		value    interface{}
+		expected uint64
+	}{
+		{value: uint64(math.MaxUint64), expected: math.MaxUint64},
+		{value: uint(7), expected: 7},
+		{value: "18446744073709551615", expected: math.MaxUint64},
+		{value: 42.0, expected: 42},
+		{value: 4.5},
+		{value: -1.0},
+		{value: int64(-1)},
+		{value: int64(5), expected: 5},
+		{value: "invalid"},
+	} {
+		if u := (Object{"v": tt.value}).Uint64("v"); u != tt.expected {
+			t.Errorf("%#v: expected %d, got %d", tt.value, tt.expected, u)
+		}
+	}
+}
+
+func TestFloatToNumber(t *testing.T) {
+	for _, tt := range []struct {
+		value    float64
+		bitSize  int
+		expected json.Number
+	}{
+		{value: 1e21, bitSize: 64, expected: "1000000000000000000000"},
+		{value: 9007199254740992, bitSize: 64, expected: "9007199254740992"},
+		{value: 0.5, bitSize: 64, expected: "0.5"},
+		{value: float64(float32(0.1)), bitSize: 32, expected: "0.1"},
+	} {
+		n, err := convert.FloatToNumber(tt.value, tt.bitSize)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if n != tt.expected {
+			t.Errorf("expected %s, got %s", tt.expected, n)
+		}
+	}
+
+	for _, f := range []float64{math.NaN(), math.Inf(1)} {
+		if _, err := convert.FloatToNumber(f, 64); err == nil {
+			t.Errorf("expected error for %v", f)
+		}
+	}
+}
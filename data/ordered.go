@@ -0,0 +1,250 @@
+package data
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	"gopkg.in/yaml.v3"
+)
+
+// KeyOrder records the order of the keys of an object and of the objects
+// nested in it, as they appeared in the input.
+type KeyOrder struct {
+	Keys   []string
+	Fields map[string]*KeyOrder
+	Items  []*KeyOrder
+}
+
+// keys returns the keys of m, first the known ones in their recorded order,
+// then new ones sorted.
+func (k *KeyOrder) keys(m map[string]interface{}) []string {
+	if k == nil {
+		return Object(m).SortedKeys()
+	}
+
+	result := make([]string, 0, len(m))
+	seen := make(map[string]bool, len(k.Keys))
+	for _, key := range k.Keys {
+		if _, ok := m[key]; ok && !seen[key] {
+			result = append(result, key)
+			seen[key] = true
+		}
+	}
+
+	start := len(result)
+	for key := range m {
+		if !seen[key] {
+			result = append(result, key)
+		}
+	}
+	sort.Strings(result[start:])
+	return result
+}
+
+func (k *KeyOrder) field(key string) *KeyOrder {
+	if k == nil {
+		return nil
+	}
+	return k.Fields[key]
+}
+
+func (k *KeyOrder) item(i int) *KeyOrder {
+	if k == nil || i >= len(k.Items) {
+		return nil
+	}
+	return k.Items[i]
+}
+
+// OrderedObject keeps the key order of a decoded JSON or YAML document so it
+// can be written back without reordering. Object can be mapped and modified
+// like any other object, keys that were not in the input are written after
+// the known ones in sorted order.
+type OrderedObject struct {
+	Object Object
+	Order  *KeyOrder
+}
+
+func (o *OrderedObject) UnmarshalJSON(content []byte) error {
+	obj, err := FromJSON(content)
+	if err != nil {
+		return err
+	}
+
+	dec := json.NewDecoder(bytes.NewReader(content))
+	dec.UseNumber()
+	order, err := readKeyOrder(dec)
+	if err != nil {
+		return err
+	}
+
+	o.Object = obj
+	o.Order = order
+	return nil
+}
+
+func (o OrderedObject) MarshalJSON() ([]byte, error) {
+	if o.Object == nil {
+		return []byte("null"), nil
+	}
+	buf := &bytes.Buffer{}
+	if err := writeOrderedJSON(buf, map[string]interface{}(o.Object), o.Order); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// OrderedFromYAML decodes a YAML document like FromYAML and records its key
+// order.
+func OrderedFromYAML(content []byte) (*OrderedObject, error) {
+	obj, err := FromYAML(content)
+	if err != nil {
+		return nil, err
+	}
+
+	var node yaml.Node
+	if err := yaml.Unmarshal(content, &node); err != nil {
+		return nil, err
+	}
+	return &OrderedObject{
+		Object: obj,
+		Order:  yamlKeyOrder(&node),
+	}, nil
+}
+
+// ToYAML encodes the object as YAML in the recorded key order.
+func (o *OrderedObject) ToYAML() ([]byte, error) {
+	return encodeYAML(map[string]interface{}(o.Object), o.Order)
+}
+
+func readKeyOrder(dec *json.Decoder) (*KeyOrder, error) {
+	token, err := dec.Token()
+	if err != nil {
+		return nil, err
+	}
+
+	delim, ok := token.(json.Delim)
+	if !ok {
+		return nil, nil
+	}
+
+	order := &KeyOrder{}
+	switch delim {
+	case '{':
+		for dec.More() {
+			keyToken, err := dec.Token()
+			if err != nil {
+				return nil, err
+			}
+			key, ok := keyToken.(string)
+			if !ok {
+				return nil, fmt.Errorf("invalid object key %v", keyToken)
+			}
+			order.Keys = append(order.Keys, key)
+
+			child, err := readKeyOrder(dec)
+			if err != nil {
+				return nil, err
+			}
+			if child != nil {
+				if order.Fields == nil {
+					order.Fields = map[string]*KeyOrder{}
+				}
+				order.Fields[key] = child
+			}
+		}
+	case '[':
+		for dec.More() {
+			child, err := readKeyOrder(dec)
+			if err != nil {
+				return nil, err
+			}
+			order.Items = append(order.Items, child)
+		}
+	}
+
+	// closing delimiter
+	if _, err := dec.Token(); err != nil {
+		return nil, err
+	}
+	return order, nil
+}
+
+func yamlKeyOrder(node *yaml.Node) *KeyOrder {
+	switch node.Kind {
+	case yaml.DocumentNode:
+		if len(node.Content) == 0 {
+			return nil
+		}
+		return yamlKeyOrder(node.Content[0])
+	case yaml.AliasNode:
+		return yamlKeyOrder(node.Alias)
+	case yaml.MappingNode:
+		order := &KeyOrder{}
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			key := node.Content[i].Value
+			if node.Content[i].Tag == "!!merge" {
+				continue
+			}
+			order.Keys = append(order.Keys, key)
+			if child := yamlKeyOrder(node.Content[i+1]); child != nil {
+				if order.Fields == nil {
+					order.Fields = map[string]*KeyOrder{}
+				}
+				order.Fields[key] = child
+			}
+		}
+		return order
+	case yaml.SequenceNode:
+		order := &KeyOrder{}
+		for _, item := range node.Content {
+			order.Items = append(order.Items, yamlKeyOrder(item))
+		}
+		return order
+	}
+	return nil
+}
+
+func writeOrderedJSON(buf *bytes.Buffer, v interface{}, order *KeyOrder) error {
+	if m, ok := asMap(v); ok {
+		buf.WriteByte('{')
+		for i, key := range order.keys(m) {
+			if i > 0 {
+				buf.WriteByte(',')
+			}
+			keyJSON, err := json.Marshal(key)
+			if err != nil {
+				return err
+			}
+			buf.Write(keyJSON)
+			buf.WriteByte(':')
+			if err := writeOrderedJSON(buf, m[key], order.field(key)); err != nil {
+				return err
+			}
+		}
+		buf.WriteByte('}')
+		return nil
+	}
+
+	if items, ok := v.([]interface{}); ok {
+		buf.WriteByte('[')
+		for i, item := range items {
+			if i > 0 {
+				buf.WriteByte(',')
+			}
+			if err := writeOrderedJSON(buf, item, order.item(i)); err != nil {
+				return err
+			}
+		}
+		buf.WriteByte(']')
+		return nil
+	}
+
+	content, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	buf.Write(content)
+	return nil
+}
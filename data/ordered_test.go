@@ -0,0 +1,114 @@
+package data
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestOrderedObjectJSONRoundTrip(t *testing.T) {
+	input := `{"zeta":1,"alpha":{"y":true,"x":null},"list":[{"b":"1","a":"2"},{"d":3.5,"c":[]}],"mid":"m"}`
+
+	var obj OrderedObject
+	if err := json.Unmarshal([]byte(input), &obj); err != nil {
+		t.Fatal(err)
+	}
+	output, err := json.Marshal(obj)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(output) != `{"zeta":1,"alpha":{"y":true,"x":null},"list":[{"b":"1","a":"2"},{"d":3.5,"c":[]}],"mid":"m"}` {
+		t.Fatalf("unexpected output %s", output)
+	}
+}
+
+func TestOrderedObjectModified(t *testing.T) {
+	var obj OrderedObject
+	if err := json.Unmarshal([]byte(`{"z":1,"y":{"b":1,"a":2},"x":[{"d":1,"c":2}]}`), &obj); err != nil {
+		t.Fatal(err)
+	}
+
+	delete(obj.Object, "z")
+	obj.Object["new2"] = 1
+	obj.Object["new1"] = 2
+	obj.Object.Map("y")["0"] = 3
+	items := obj.Object["x"].([]interface{})
+	obj.Object["x"] = append(items, map[string]interface{}{"f": 1, "e": 2})
+
+	output, err := json.Marshal(obj)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(output) != `{"y":{"b":1,"a":2,"0":3},"x":[{"d":1,"c":2},{"e":2,"f":1}],"new1":2,"new2":1}` {
+		t.Fatalf("unexpected output %s", output)
+	}
+}
+
+func TestOrderedObjectNull(t *testing.T) {
+	output, err := json.Marshal(OrderedObject{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(output) != "null" {
+		t.Fatalf("expected null, got %s", output)
+	}
+}
+
+func TestOrderedFromYAML(t *testing.T) {
+	input := `zeta: 1
+alpha:
+  y: true
+  x: null
+base: &base
+  d: 1
+  c: 2
+merged:
+  <<: *base
+  b: 3
+list:
+  - b: "1"
+    a: "2"
+`
+	obj, err := OrderedFromYAML([]byte(input))
+	if err != nil {
+		t.Fatal(err)
+	}
+	output, err := obj.ToYAML()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// merged keys are not in the input order of merged and are sorted
+	expected := `zeta: 1
+alpha:
+  y: true
+  x: null
+base:
+  d: 1
+  c: 2
+merged:
+  b: 3
+  c: 2
+  d: 1
+list:
+  - b: "1"
+    a: "2"
+`
+	if string(output) != expected {
+		t.Fatalf("expected\n%s\ngot\n%s", expected, output)
+	}
+
+	jsonOutput, err := json.Marshal(obj)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(jsonOutput) != `{"zeta":1,"alpha":{"y":true,"x":null},"base":{"d":1,"c":2},"merged":{"b":3,"c":2,"d":1},"list":[{"b":"1","a":"2"}]}` {
+		t.Fatalf("unexpected JSON %s", jsonOutput)
+	}
+}
+
+func TestOrderedObjectInvalidJSON(t *testing.T) {
+	var obj OrderedObject
+	if err := json.Unmarshal([]byte(`{"a":`), &obj); err == nil {
+		t.Fatal("expected an error")
+	}
+}
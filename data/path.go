@@ -0,0 +1,215 @@
+package data
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+type pathPart struct {
+	key     string
+	index   int
+	isIndex bool
+}
+
+func (p pathPart) String() string {
+	if p.isIndex {
+		return fmt.Sprintf("[%d]", p.index)
+	}
+	return p.key
+}
+
+// parsePath splits a path like "spec.containers[0].env" into its parts. A "."
+// that is part of a key is escaped as "\.", for example
+// "metadata.labels.app\.kubernetes\.io/name". An empty key is written as "".
+func parsePath(path string) ([]pathPart, error) {
+	var (
+		parts   []pathPart
+		key     strings.Builder
+		hasKey  bool
+		escaped bool
+	)
+
+	flush := func() {
+		if hasKey {
+			parts = append(parts, pathPart{key: key.String()})
+		}
+		key.Reset()
+		hasKey = false
+	}
+
+	for i := 0; i < len(path); i++ {
+		c := path[i]
+		if escaped {
+			key.WriteByte(c)
+			hasKey = true
+			escaped = false
+			continue
+		}
+
+		if c == '"' && !hasKey && strings.HasPrefix(path[i:], `""`) &&
+			(i+2 == len(path) || path[i+2] == '.' || path[i+2] == '[') {
+			hasKey = true
+			i++
+			continue
+		}
+
+		switch c {
+		case '\\':
+			escaped = true
+		case '.':
+			if !hasKey && (i == 0 || path[i-1] != ']') {
+				return nil, fmt.Errorf("invalid path %q: empty key at offset %d", path, i)
+			}
+			flush()
+		case '[':
+			flush()
+			end := strings.IndexByte(path[i:], ']')
+			if end < 0 {
+				return nil, fmt.Errorf("invalid path %q: missing ]", path)
+			}
+			index, err := strconv.Atoi(path[i+1 : i+end])
+			if err != nil || index < 0 {
+				return nil, fmt.Errorf("invalid path %q: invalid index %q", path, path[i+1:i+end])
+			}
+			parts = append(parts, pathPart{index: index, isIndex: true})
+			i += end
+		default:
+			key.WriteByte(c)
+			hasKey = true
+		}
+	}
+
+	if escaped {
+		return nil, fmt.Errorf("invalid path %q: trailing escape", path)
+	}
+	if !hasKey && (len(path) == 0 || path[len(path)-1] == '.') {
+		return nil, fmt.Errorf("invalid path %q: empty key", path)
+	}
+	flush()
+	return parts, nil
+}
+
+// EscapePathKey escapes a key so it can be used as a single element of a path
+// passed to GetPath or SetPath. The empty key is escaped as "".
+func EscapePathKey(key string) string {
+	if key == "" {
+		return `""`
+	}
+	r := strings.NewReplacer(`\`, `\\`, `.`, `\.`, `[`, `\[`, `"`, `\"`)
+	return r.Replace(key)
+}
+
+// GetPath returns the value at a path like "spec.template.metadata.labels.app"
+// or "spec.containers[0].name". The second return value is false if the path
+// is invalid or any element along it does not exist.
+func (o Object) GetPath(path string) (interface{}, bool) {
+	parts, err := parsePath(path)
+	if err != nil {
+		return nil, false
+	}
+
+	var current interface{} = o
+	for _, part := range parts {
+		var ok bool
+		if part.isIndex {
+			var items []interface{}
+			if items, ok = current.([]interface{}); ok && part.index < len(items) {
+				current = items[part.index]
+				continue
+			}
+			return nil, false
+		}
+
+		m, ok := asMap(current)
+		if !ok {
+			return nil, false
+		}
+		if current, ok = m[part.key]; !ok {
+			return nil, false
+		}
+	}
+
+	return current, true
+}
+
+// SetPath sets the value at path, creating missing objects along the way. An
+// index may address an existing element or be equal to the length of the
+// slice to append to it.
+func (o Object) SetPath(path string, value interface{}) error {
+	if o == nil {
+		return fmt.Errorf("can not set %s on nil object", path)
+	}
+
+	parts, err := parsePath(path)
+	if err != nil {
+		return err
+	}
+	if parts[0].isIndex {
+		return fmt.Errorf("invalid path %q: must start with a key", path)
+	}
+
+	_, err = setPath(o, parts, value)
+	if err != nil {
+		return fmt.Errorf("failed to set %s: %w", path, err)
+	}
+	return nil
+}
+
+func setPath(current interface{}, parts []pathPart, value interface{}) (interface{}, error) {
+	if len(parts) == 0 {
+		return value, nil
+	}
+
+	part := parts[0]
+	if part.isIndex {
+		if current == nil {
+			current = []interface{}{}
+		}
+		items, ok := current.([]interface{})
+		if !ok {
+			return nil, fmt.Errorf("%s is indexed but is not a slice", part)
+		}
+		switch {
+		case part.index < len(items):
+			v, err := setPath(items[part.index], parts[1:], value)
+			if err != nil {
+				return nil, err
+			}
+			items[part.index] = v
+		case part.index == len(items):
+			v, err := setPath(nil, parts[1:], value)
+			if err != nil {
+				return nil, err
+			}
+			items = append(items, v)
+		default:
+			return nil, fmt.Errorf("index %s is out of range", part)
+		}
+		return items, nil
+	}
+
+	if current == nil {
+		current = map[string]interface{}{}
+	}
+	m, ok := asMap(current)
+	if !ok {
+		return nil, fmt.Errorf("can not set key %s on a value that is not an object", part)
+	}
+	v, err := setPath(m[part.key], parts[1:], value)
+	if err != nil {
+		return nil, err
+	}
+	m[part.key] = v
+	return current, nil
+}
+
+func asMap(v interface{}) (map[string]interface{}, bool) {
+	switch m := v.(type) {
+	case map[string]interface{}:
+		return m, true
+	case Object:
+		return m, true
+	}
+	return nil, false
+}
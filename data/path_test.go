@@ -0,0 +1,106 @@
+package data
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestGetPath(t *testing.T) {
+	obj := Object{
+		"metadata": map[string]interface{}{
+			"labels": map[string]interface{}{"app.kubernetes.io/name": "app"},
+		},
+		"spec": map[string]interface{}{
+			"containers": []interface{}{
+				map[string]interface{}{"name": "web", "ports": []interface{}{int64(80)}},
+			},
+		},
+		"": map[string]interface{}{"": "empty"},
+	}
+
+	tests := []struct {
+		path     string
+		expected interface{}
+		ok       bool
+	}{
+		{path: "spec.containers[0].name", expected: "web", ok: true},
+		{path: "spec.containers[0].ports[0]", expected: int64(80), ok: true},
+		{path: `metadata.labels.app\.kubernetes\.io/name`, expected: "app", ok: true},
+		{path: `"".""`, expected: "empty", ok: true},
+		{path: "spec.containers[1].name"},
+		{path: "spec.containers.name"},
+		{path: "metadata.labels.app"},
+		{path: "spec..containers"},
+		{path: "spec.containers[x]"},
+		{path: `spec\`},
+	}
+	for _, tt := range tests {
+		v, ok := obj.GetPath(tt.path)
+		if ok != tt.ok || !reflect.DeepEqual(v, tt.expected) {
+			t.Errorf("%s: expected %v %v, got %v %v", tt.path, tt.expected, tt.ok, v, ok)
+		}
+	}
+}
+
+func TestSetPath(t *testing.T) {
+	obj := Object{}
+	for path, value := range map[string]interface{}{
+		"spec.replicas":               int64(2),
+		`metadata.labels.app\.io`:     "app",
+		"spec.containers[0].name":     "web",
+		"spec.containers[0].ports[0]": int64(80),
+	} {
+		if err := obj.SetPath(path, value); err != nil {
+			t.Fatalf("%s: %v", path, err)
+		}
+	}
+	// appending needs the previous element, so it's set afterwards
+	if err := obj.SetPath("spec.containers[1].name", "sidecar"); err != nil {
+		t.Fatal(err)
+	}
+
+	expected := Object{
+		"metadata": map[string]interface{}{
+			"labels": map[string]interface{}{"app.io": "app"},
+		},
+		"spec": map[string]interface{}{
+			"replicas": int64(2),
+			"containers": []interface{}{
+				map[string]interface{}{"name": "web", "ports": []interface{}{int64(80)}},
+				map[string]interface{}{"name": "sidecar"},
+			},
+		},
+	}
+	if !reflect.DeepEqual(obj, expected) {
+		t.Fatalf("expected %v, got %v", expected, obj)
+	}
+
+	for _, path := range []string{
+		"spec.containers[5].name",
+		"spec.replicas.value",
+		"spec[0]",
+		"[0]",
+		"spec.",
+	} {
+		if err := obj.SetPath(path, "x"); err == nil {
+			t.Errorf("expected error for %s", path)
+		}
+	}
+
+	var nilObject Object
+	if err := nilObject.SetPath("a", "b"); err == nil {
+		t.Fatal("expected error for a nil object")
+	}
+}
+
+func TestEscapePathKey(t *testing.T) {
+	for _, key := range []string{"plain", "a.b", `a\b`, "a[0]", "", `""`, `"`} {
+		obj := Object{}
+		if err := obj.SetPath("x."+EscapePathKey(key), "v"); err != nil {
+			t.Fatalf("%q: %v", key, err)
+		}
+		if v := obj.Map("x")[key]; v != "v" {
+			t.Errorf("%q: expected the key to be set, got %v", key, obj)
+		}
+	}
+}
@@ -0,0 +1,56 @@
+package data
+
+import "sync"
+
+// ObjectPool recycles the maps of objects that are no longer used. It's meant
+// for servers that build and discard many objects, for example by encoding
+// structs with FromStruct(v, WithPool(pool)) and releasing the result after
+// it was written to the response.
+type ObjectPool struct {
+	pool sync.Pool
+}
+
+func NewObjectPool() *ObjectPool {
+	return &ObjectPool{
+		pool: sync.Pool{
+			New: func() interface{} {
+				return map[string]interface{}{}
+			},
+		},
+	}
+}
+
+// Get returns an empty object.
+func (p *ObjectPool) Get() Object {
+	return p.pool.Get().(map[string]interface{})
+}
+
+func (p *ObjectPool) newMap(int) map[string]interface{} {
+	return p.pool.Get().(map[string]interface{})
+}
+
+// Release returns obj and all objects nested in it to the pool. Neither obj
+// nor any value nested in it may be used afterwards.
+func (p *ObjectPool) Release(obj Object) {
+	if obj == nil {
+		return
+	}
+	for _, v := range obj {
+		p.release(v)
+	}
+	clear(obj)
+	p.pool.Put(map[string]interface{}(obj))
+}
+
+func (p *ObjectPool) release(v interface{}) {
+	switch t := v.(type) {
+	case map[string]interface{}:
+		p.Release(t)
+	case Object:
+		p.Release(t)
+	case []interface{}:
+		for _, item := range t {
+			p.release(item)
+		}
+	}
+}
@@ -0,0 +1,45 @@
+package data
+
+import "testing"
+
+type benchContainer struct {
+	Name  string            `json:"name"`
+	Image string            `json:"image"`
+	Env   map[string]string `json:"env"`
+}
+
+type benchPod struct {
+	Name       string            `json:"name"`
+	Labels     map[string]string `json:"labels"`
+	Containers []benchContainer  `json:"containers"`
+}
+
+var pod = benchPod{
+	Name:   "pod",
+	Labels: map[string]string{"app": "web", "tier": "frontend"},
+	Containers: []benchContainer{
+		{Name: "web", Image: "nginx", Env: map[string]string{"A": "1"}},
+		{Name: "sidecar", Image: "envoy", Env: map[string]string{"B": "2"}},
+	},
+}
+
+func BenchmarkFromStruct(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		if _, err := FromStruct(pod); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkFromStructPooled(b *testing.B) {
+	pool := NewObjectPool()
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		obj, err := FromStruct(pod, WithPool(pool))
+		if err != nil {
+			b.Fatal(err)
+		}
+		pool.Release(obj)
+	}
+}
@@ -0,0 +1,63 @@
+package data
+
+// PruneOptions selects the kinds of empty values that Prune keeps. The zero
+// value removes all of them.
+type PruneOptions struct {
+	KeepNil          bool
+	KeepEmptyStrings bool
+	KeepEmptyMaps    bool
+	KeepEmptySlices  bool
+}
+
+// Prune recursively removes nil values, empty strings, maps and slices from o
+// and returns o. Maps that only become empty because their contents were
+// pruned are removed as well. Elements of slices are pruned but never
+// removed, so indexes stay stable. Null values are explicit and never pruned.
+func (o Object) Prune() Object {
+	return o.PruneWith(PruneOptions{})
+}
+
+// PruneWith is Prune with control over which empty values are kept.
+func (o Object) PruneWith(opts PruneOptions) Object {
+	pruneMap(o, opts)
+	return o
+}
+
+func pruneMap(m map[string]interface{}, opts PruneOptions) {
+	for k, v := range m {
+		if pruneValue(v, opts) {
+			delete(m, k)
+		}
+	}
+}
+
+// pruneValue prunes the contents of v and returns true if v itself should be
+// removed.
+func pruneValue(v interface{}, opts PruneOptions) bool {
+	if m, ok := asMap(v); ok {
+		pruneMap(m, opts)
+		return len(m) == 0 && !opts.KeepEmptyMaps
+	}
+
+	switch t := v.(type) {
+	case nil:
+		return !opts.KeepNil
+	case string:
+		return t == "" && !opts.KeepEmptyStrings
+	case []interface{}:
+		for _, item := range t {
+			pruneValue(item, opts)
+		}
+		return len(t) == 0 && !opts.KeepEmptySlices
+	case []map[string]interface{}:
+		for _, item := range t {
+			pruneMap(item, opts)
+		}
+		return len(t) == 0 && !opts.KeepEmptySlices
+	case []string:
+		return len(t) == 0 && !opts.KeepEmptySlices
+	case map[string]string:
+		return len(t) == 0 && !opts.KeepEmptyMaps
+	}
+	return false
+}
@@ -0,0 +1,60 @@
+package data
+
+import (
+	"reflect"
+	"testing"
+)
+
+func pruneObject() Object {
+	return Object{
+		"name":   "app",
+		"nil":    nil,
+		"null":   Null,
+		"empty":  "",
+		"zero":   int64(0),
+		"false":  false,
+		"map":    map[string]interface{}{},
+		"slice":  []interface{}{},
+		"nested": map[string]interface{}{"a": map[string]interface{}{"b": ""}},
+		"items": []interface{}{
+			map[string]interface{}{"name": "", "port": int64(80)},
+			map[string]interface{}{"name": ""},
+		},
+	}
+}
+
+func TestPrune(t *testing.T) {
+	expected := Object{
+		"name":  "app",
+		"null":  Null,
+		"zero":  int64(0),
+		"false": false,
+		"items": []interface{}{
+			map[string]interface{}{"port": int64(80)},
+			map[string]interface{}{},
+		},
+	}
+	if result := pruneObject().Prune(); !reflect.DeepEqual(result, expected) {
+		t.Fatalf("expected %v, got %v", expected, result)
+	}
+}
+
+func TestPruneWith(t *testing.T) {
+	result := pruneObject().PruneWith(PruneOptions{KeepNil: true, KeepEmptySlices: true})
+	for _, key := range []string{"nil", "slice"} {
+		if _, ok := result[key]; !ok {
+			t.Errorf("expected %s to be kept, got %v", key, result)
+		}
+	}
+	for _, key := range []string{"empty", "map", "nested"} {
+		if _, ok := result[key]; ok {
+			t.Errorf("expected %s to be removed, got %v", key, result)
+		}
+	}
+
+	result = pruneObject().PruneWith(PruneOptions{KeepEmptyStrings: true, KeepEmptyMaps: true})
+	expected := map[string]interface{}{"a": map[string]interface{}{"b": ""}}
+	if !reflect.DeepEqual(result["nested"], expected) || result["empty"] != "" || result["map"] == nil {
+		t.Fatalf("expected empty strings and maps to be kept, got %v", result)
+	}
+}
@@ -0,0 +1,289 @@
+package query
+
+import (
+	"encoding/json"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/acorn-io/schemer/data/convert"
+)
+
+type filter interface {
+	match(value interface{}) bool
+}
+
+type orFilter []filter
+
+func (o orFilter) match(value interface{}) bool {
+	for _, f := range o {
+		if f.match(value) {
+			return true
+		}
+	}
+	return false
+}
+
+type andFilter []filter
+
+func (a andFilter) match(value interface{}) bool {
+	for _, f := range a {
+		if !f.match(value) {
+			return false
+		}
+	}
+	return true
+}
+
+type notFilter struct {
+	filter filter
+}
+
+func (n notFilter) match(value interface{}) bool {
+	return !n.filter.match(value)
+}
+
+// operand is either a path relative to the current value or a literal.
+type operand struct {
+	steps   []step
+	literal interface{}
+	isPath  bool
+}
+
+func (o operand) eval(value interface{}) (interface{}, bool) {
+	if !o.isPath {
+		return o.literal, true
+	}
+	matches := execute(o.steps, Match{Value: value})
+	if len(matches) == 0 {
+		return nil, false
+	}
+	return matches[0].Value, true
+}
+
+type existsFilter struct {
+	operand operand
+}
+
+func (e existsFilter) match(value interface{}) bool {
+	v, ok := e.operand.eval(value)
+	if !ok {
+		return false
+	}
+	if b, isBool := v.(bool); isBool {
+		return b
+	}
+	return true
+}
+
+type compareFilter struct {
+	left, right operand
+	op          string
+}
+
+func (c compareFilter) match(value interface{}) bool {
+	left, ok := c.left.eval(value)
+	if !ok {
+		return false
+	}
+	right, ok := c.right.eval(value)
+	if !ok {
+		return false
+	}
+
+	switch c.op {
+	case "==":
+		return equal(left, right)
+	case "!=":
+		return !equal(left, right)
+	}
+
+	cmp, ok := compare(left, right)
+	if !ok {
+		return false
+	}
+	switch c.op {
+	case "<":
+		return cmp < 0
+	case "<=":
+		return cmp <= 0
+	case ">":
+		return cmp > 0
+	case ">=":
+		return cmp >= 0
+	}
+	return false
+}
+
+func isNumber(v interface{}) bool {
+	switch v.(type) {
+	case int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64, float32, float64, json.Number:
+		return true
+	}
+	return false
+}
+
+func equal(left, right interface{}) bool {
+	if isNumber(left) && isNumber(right) {
+		cmp, ok := compare(left, right)
+		return ok && cmp == 0
+	}
+	return reflect.DeepEqual(left, right)
+}
+
+func compare(left, right interface{}) (int, bool) {
+	if isNumber(left) && isNumber(right) {
+		l, err := convert.ToFloat(left)
+		if err != nil {
+			return 0, false
+		}
+		r, err := convert.ToFloat(right)
+		if err != nil {
+			return 0, false
+		}
+		switch {
+		case l < r:
+			return -1, true
+		case l > r:
+			return 1, true
+		}
+		return 0, true
+	}
+
+	l, lok := left.(string)
+	r, rok := right.(string)
+	if lok && rok {
+		return strings.Compare(l, r), true
+	}
+	return 0, false
+}
+
+func (p *parser) parseFilter() (filter, error) {
+	var or orFilter
+	for {
+		f, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		or = append(or, f)
+		p.skipSpace()
+		if !strings.HasPrefix(p.expr[p.pos:], "||") {
+			break
+		}
+		p.pos += 2
+	}
+	if len(or) == 1 {
+		return or[0], nil
+	}
+	return or, nil
+}
+
+func (p *parser) parseAnd() (filter, error) {
+	var and andFilter
+	for {
+		f, err := p.parseTerm()
+		if err != nil {
+			return nil, err
+		}
+		and = append(and, f)
+		p.skipSpace()
+		if !strings.HasPrefix(p.expr[p.pos:], "&&") {
+			break
+		}
+		p.pos += 2
+	}
+	if len(and) == 1 {
+		return and[0], nil
+	}
+	return and, nil
+}
+
+func (p *parser) parseTerm() (filter, error) {
+	p.skipSpace()
+	switch p.peek() {
+	case '!':
+		p.pos++
+		f, err := p.parseTerm()
+		if err != nil {
+			return nil, err
+		}
+		return notFilter{filter: f}, nil
+	case '(':
+		p.pos++
+		f, err := p.parseFilter()
+		if err != nil {
+			return nil, err
+		}
+		p.skipSpace()
+		if p.peek() != ')' {
+			return nil, p.errorf("expected )")
+		}
+		p.pos++
+		return f, nil
+	}
+
+	left, err := p.parseOperand()
+	if err != nil {
+		return nil, err
+	}
+
+	p.skipSpace()
+	op := p.parseOperator()
+	if op == "" {
+		return existsFilter{operand: left}, nil
+	}
+
+	p.skipSpace()
+	right, err := p.parseOperand()
+	if err != nil {
+		return nil, err
+	}
+	return compareFilter{left: left, right: right, op: op}, nil
+}
+
+func (p *parser) parseOperator() string {
+	for _, op := range []string{"==", "!=", "<=", ">=", "<", ">"} {
+		if strings.HasPrefix(p.expr[p.pos:], op) {
+			p.pos += len(op)
+			return op
+		}
+	}
+	return ""
+}
+
+func (p *parser) parseOperand() (operand, error) {
+	switch c := p.peek(); {
+	case c == '@':
+		p.pos++
+		steps, err := p.parsePath(false)
+		if err != nil {
+			return operand{}, err
+		}
+		return operand{steps: steps, isPath: true}, nil
+	case c == '\'' || c == '"':
+		s, err := p.parseString()
+		if err != nil {
+			return operand{}, err
+		}
+		return operand{literal: s}, nil
+	}
+
+	start := p.pos
+	for p.pos < len(p.expr) && strings.IndexByte(" )&|=!<>", p.expr[p.pos]) < 0 {
+		p.pos++
+	}
+	word := p.expr[start:p.pos]
+	switch word {
+	case "true":
+		return operand{literal: true}, nil
+	case "false":
+		return operand{literal: false}, nil
+	case "null":
+		return operand{literal: nil}, nil
+	}
+	if _, err := strconv.ParseFloat(word, 64); err == nil {
+		return operand{literal: json.Number(word)}, nil
+	}
+	p.pos = start
+	return operand{}, p.errorf("expected @, string, number, true, false or null")
+}
@@ -0,0 +1,423 @@
+// Package query implements a subset of JSONPath that is evaluated against
+// data.Object values. Supported are the root "$", child access with ".name"
+// and "['name']", wildcards ".*" and "[*]", array indexes including negative
+// ones, unions "['a','b']" and "[0,1]", recursive descent "..name" and filters
+// "[?(@.name == 'value')]" combined with && and ||.
+package query
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/acorn-io/schemer/data"
+)
+
+// Match is a value selected by a query. Path is the location of the value in
+// the format accepted by data.Object.GetPath.
+type Match struct {
+	Path  string
+	Value interface{}
+}
+
+type stepKind int
+
+const (
+	childStep stepKind = iota
+	wildcardStep
+	indexStep
+	filterStep
+)
+
+type step struct {
+	kind      stepKind
+	recursive bool
+	names     []string
+	indexes   []int
+	filter    filter
+}
+
+type Query struct {
+	expr  string
+	steps []step
+}
+
+// Parse compiles a JSONPath expression. The leading "$" is optional.
+func Parse(expr string) (*Query, error) {
+	p := &parser{expr: expr}
+	steps, err := p.parsePath(true)
+	if err != nil {
+		return nil, err
+	}
+	if p.pos < len(expr) {
+		return nil, p.errorf("unexpected %q", expr[p.pos])
+	}
+	return &Query{
+		expr:  expr,
+		steps: steps,
+	}, nil
+}
+
+// MustParse is like Parse but panics if the expression is invalid.
+func MustParse(expr string) *Query {
+	q, err := Parse(expr)
+	if err != nil {
+		panic(err)
+	}
+	return q
+}
+
+// Evaluate parses expr and executes it against obj.
+func Evaluate(expr string, obj data.Object) ([]Match, error) {
+	q, err := Parse(expr)
+	if err != nil {
+		return nil, err
+	}
+	return q.Execute(obj), nil
+}
+
+func (q *Query) String() string {
+	return q.expr
+}
+
+// Execute returns all values in obj matched by the query, in document order
+// with object keys sorted.
+func (q *Query) Execute(obj data.Object) []Match {
+	return execute(q.steps, Match{Value: map[string]interface{}(obj)})
+}
+
+// Values returns just the values matched by the query.
+func (q *Query) Values(obj data.Object) []interface{} {
+	var result []interface{}
+	for _, match := range q.Execute(obj) {
+		result = append(result, match.Value)
+	}
+	return result
+}
+
+func execute(steps []step, root Match) []Match {
+	nodes := []Match{root}
+	for _, step := range steps {
+		var next []Match
+		for _, node := range nodes {
+			if step.recursive {
+				for _, descendant := range descendants(node, nil) {
+					next = step.apply(descendant, next)
+				}
+			} else {
+				next = step.apply(node, next)
+			}
+		}
+		nodes = next
+	}
+	return nodes
+}
+
+func (s step) apply(node Match, result []Match) []Match {
+	switch s.kind {
+	case childStep:
+		m, ok := asMap(node.Value)
+		if !ok {
+			return result
+		}
+		for _, name := range s.names {
+			if v, ok := m[name]; ok {
+				result = append(result, Match{Path: childPath(node.Path, name), Value: v})
+			}
+		}
+	case indexStep:
+		items, ok := node.Value.([]interface{})
+		if !ok {
+			return result
+		}
+		for _, i := range s.indexes {
+			if i < 0 {
+				i += len(items)
+			}
+			if i >= 0 && i < len(items) {
+				result = append(result, Match{Path: indexPath(node.Path, i), Value: items[i]})
+			}
+		}
+	case wildcardStep:
+		result = append(result, children(node)...)
+	case filterStep:
+		for _, child := range children(node) {
+			if s.filter.match(child.Value) {
+				result = append(result, child)
+			}
+		}
+	}
+	return result
+}
+
+func children(node Match) []Match {
+	var result []Match
+	if m, ok := asMap(node.Value); ok {
+		for _, key := range data.Object(m).SortedKeys() {
+			result = append(result, Match{Path: childPath(node.Path, key), Value: m[key]})
+		}
+	} else if items, ok := node.Value.([]interface{}); ok {
+		for i, item := range items {
+			result = append(result, Match{Path: indexPath(node.Path, i), Value: item})
+		}
+	}
+	return result
+}
+
+func descendants(node Match, result []Match) []Match {
+	result = append(result, node)
+	for _, child := range children(node) {
+		result = descendants(child, result)
+	}
+	return result
+}
+
+func childPath(path, key string) string {
+	if path == "" {
+		return data.EscapePathKey(key)
+	}
+	return path + "." + data.EscapePathKey(key)
+}
+
+func indexPath(path string, i int) string {
+	return path + "[" + strconv.Itoa(i) + "]"
+}
+
+func asMap(v interface{}) (map[string]interface{}, bool) {
+	switch m := v.(type) {
+	case map[string]interface{}:
+		return m, true
+	case data.Object:
+		return m, true
+	}
+	return nil, false
+}
+
+type parser struct {
+	expr string
+	pos  int
+}
+
+func (p *parser) errorf(format string, args ...interface{}) error {
+	return fmt.Errorf("invalid query %q at offset %d: %s", p.expr, p.pos, fmt.Sprintf(format, args...))
+}
+
+func (p *parser) peek() byte {
+	if p.pos < len(p.expr) {
+		return p.expr[p.pos]
+	}
+	return 0
+}
+
+func (p *parser) skipSpace() {
+	for p.pos < len(p.expr) && p.expr[p.pos] == ' ' {
+		p.pos++
+	}
+}
+
+// parsePath parses steps until the end of the expression or, when parsing the
+// path of a filter, until a character that can't continue a path.
+func (p *parser) parsePath(root bool) ([]step, error) {
+	var steps []step
+
+	if root && p.peek() == '$' {
+		p.pos++
+	}
+
+	for p.pos < len(p.expr) {
+		switch {
+		case strings.HasPrefix(p.expr[p.pos:], ".."):
+			p.pos += 2
+			var (
+				s   step
+				err error
+			)
+			if p.peek() == '[' {
+				s, err = p.parseBracket()
+			} else {
+				s, err = p.parseDotted()
+			}
+			if err != nil {
+				return nil, err
+			}
+			s.recursive = true
+			steps = append(steps, s)
+		case p.peek() == '.':
+			p.pos++
+			s, err := p.parseDotted()
+			if err != nil {
+				return nil, err
+			}
+			steps = append(steps, s)
+		case p.peek() == '[':
+			s, err := p.parseBracket()
+			if err != nil {
+				return nil, err
+			}
+			steps = append(steps, s)
+		case root && len(steps) == 0 && p.pos == 0:
+			// a path without "$" and a leading "." like "spec.replicas"
+			s, err := p.parseDotted()
+			if err != nil {
+				return nil, err
+			}
+			steps = append(steps, s)
+		default:
+			if root {
+				return nil, p.errorf("unexpected %q", p.peek())
+			}
+			return steps, nil
+		}
+	}
+
+	return steps, nil
+}
+
+func (p *parser) parseDotted() (step, error) {
+	if p.peek() == '*' {
+		p.pos++
+		return step{kind: wildcardStep}, nil
+	}
+
+	start := p.pos
+	for p.pos < len(p.expr) && isNameChar(p.expr[p.pos]) {
+		p.pos++
+	}
+	if start == p.pos {
+		return step{}, p.errorf("expected name")
+	}
+	return step{kind: childStep, names: []string{p.expr[start:p.pos]}}, nil
+}
+
+func isNameChar(c byte) bool {
+	return c == '_' || c == '-' || c == '/' ||
+		(c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z') || (c >= '0' && c <= '9')
+}
+
+func (p *parser) parseBracket() (step, error) {
+	p.pos++
+	p.skipSpace()
+
+	var s step
+	switch c := p.peek(); {
+	case c == '*':
+		p.pos++
+		s.kind = wildcardStep
+	case c == '?':
+		p.pos++
+		if p.peek() != '(' {
+			return s, p.errorf("expected ( after ?")
+		}
+		p.pos++
+		f, err := p.parseFilter()
+		if err != nil {
+			return s, err
+		}
+		p.skipSpace()
+		if p.peek() != ')' {
+			return s, p.errorf("expected ) to close filter")
+		}
+		p.pos++
+		s.kind = filterStep
+		s.filter = f
+	case c == '\'' || c == '"':
+		s.kind = childStep
+		for {
+			name, err := p.parseString()
+			if err != nil {
+				return s, err
+			}
+			s.names = append(s.names, name)
+			if !p.parseComma() {
+				break
+			}
+		}
+	default:
+		s.kind = indexStep
+		for {
+			i, err := p.parseInt()
+			if err != nil {
+				return s, err
+			}
+			s.indexes = append(s.indexes, i)
+			if !p.parseComma() {
+				break
+			}
+		}
+	}
+
+	p.skipSpace()
+	if p.peek() != ']' {
+		return s, p.errorf("expected ]")
+	}
+	p.pos++
+	return s, nil
+}
+
+func (p *parser) parseComma() bool {
+	p.skipSpace()
+	if p.peek() != ',' {
+		return false
+	}
+	p.pos++
+	p.skipSpace()
+	return true
+}
+
+func (p *parser) parseString() (string, error) {
+	quote := p.peek()
+	p.pos++
+
+	var result strings.Builder
+	for p.pos < len(p.expr) {
+		c := p.expr[p.pos]
+		p.pos++
+		switch {
+		case c == '\\' && p.pos < len(p.expr):
+			result.WriteByte(p.expr[p.pos])
+			p.pos++
+		case c == quote:
+			return result.String(), nil
+		default:
+			result.WriteByte(c)
+		}
+	}
+	return "", p.errorf("unterminated string")
+}
+
+func (p *parser) parseInt() (int, error) {
+	start := p.pos
+	if p.peek() == '-' {
+		p.pos++
+	}
+	for p.pos < len(p.expr) && p.expr[p.pos] >= '0' && p.expr[p.pos] <= '9' {
+		p.pos++
+	}
+	i, err := strconv.Atoi(p.expr[start:p.pos])
+	if err != nil {
+		return 0, p.errorf("expected index, name or filter")
+	}
+	return i, nil
+}
+
+// Redact returns a copy of obj where all values matched by one of the
+// expressions are replaced with replacement, see data.Redact for glob
+// patterns.
+func Redact(obj data.Object, exprs []string, replacement interface{}) (data.Object, error) {
+	result := obj.DeepCopy()
+	for _, expr := range exprs {
+		matches, err := Evaluate(expr, result)
+		if err != nil {
+			return nil, err
+		}
+		for _, match := range matches {
+			if match.Path == "" {
+				continue
+			}
+			if err := result.SetPath(match.Path, replacement); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return result, nil
+}
@@ -0,0 +1,178 @@
+package query
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/acorn-io/schemer/data"
+)
+
+func testObject() data.Object {
+	return data.Object{
+		"metadata": map[string]interface{}{
+			"name": "web",
+			"labels": map[string]interface{}{
+				"app":                    "web",
+				"app.kubernetes.io/name": "web",
+			},
+		},
+		"spec": map[string]interface{}{
+			"replicas": int64(3),
+			"containers": []interface{}{
+				map[string]interface{}{"name": "nginx", "image": "nginx", "port": int64(80), "secret": "a"},
+				map[string]interface{}{"name": "sidecar", "image": "envoy", "port": int64(9000)},
+				map[string]interface{}{"name": "init", "image": "busybox", "secret": "b"},
+			},
+		},
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	tests := []struct {
+		expr string
+		err  string
+	}{
+		{"$.", `invalid query "$." at offset 2: expected name`},
+		{"$.spec[", `invalid query "$.spec[" at offset 7: expected index, name or filter`},
+		{"$.spec[0", `invalid query "$.spec[0" at offset 8: expected ]`},
+		{"$['a", `invalid query "$['a" at offset 4: unterminated string`},
+		{"$[?@.a]", `invalid query "$[?@.a]" at offset 3: expected ( after ?`},
+		{"$[?(@.a == )]", `invalid query "$[?(@.a == )]" at offset 11: expected @, string, number, true, false or null`},
+		{"$[?(@.a]", `invalid query "$[?(@.a]" at offset 7: expected ) to close filter`},
+		{"$.a b", `invalid query "$.a b" at offset 3: unexpected ' '`},
+	}
+	for _, test := range tests {
+		t.Run(test.expr, func(t *testing.T) {
+			_, err := Parse(test.expr)
+			if err == nil {
+				t.Fatal("expected error")
+			}
+			if err.Error() != test.err {
+				t.Errorf("expected %s, got %s", test.err, err)
+			}
+		})
+	}
+}
+
+func TestExecute(t *testing.T) {
+	tests := []struct {
+		expr   string
+		values []interface{}
+		paths  []string
+	}{
+		{
+			expr:   "$.metadata.name",
+			values: []interface{}{"web"},
+			paths:  []string{"metadata.name"},
+		},
+		{
+			expr:   "spec.replicas",
+			values: []interface{}{int64(3)},
+		},
+		{
+			expr:   "$.metadata.labels['app.kubernetes.io/name']",
+			values: []interface{}{"web"},
+			paths:  []string{`metadata.labels.app\.kubernetes\.io/name`},
+		},
+		{
+			expr:   "$.spec.containers[-1].name",
+			values: []interface{}{"init"},
+			paths:  []string{"spec.containers[2].name"},
+		},
+		{
+			expr:   "$.spec.containers[-4].name",
+			values: nil,
+		},
+		{
+			expr:   "$.spec.containers[0, -1].name",
+			values: []interface{}{"nginx", "init"},
+		},
+		{
+			expr:   "$.metadata['name', 'missing']",
+			values: []interface{}{"web"},
+		},
+		{
+			expr:   "$.spec.containers[*].image",
+			values: []interface{}{"nginx", "envoy", "busybox"},
+		},
+		{
+			expr:   "$..secret",
+			values: []interface{}{"a", "b"},
+			paths:  []string{"spec.containers[0].secret", "spec.containers[2].secret"},
+		},
+		{
+			expr:   "$..['name']",
+			values: []interface{}{"web", "nginx", "sidecar", "init"},
+		},
+		{
+			expr:   "$.spec.containers[?(@.port >= 80 && @.port < 1000)].name",
+			values: []interface{}{"nginx"},
+		},
+		{
+			expr:   "$.spec.containers[?(@.name == 'init' || @.image == \"envoy\")].name",
+			values: []interface{}{"sidecar", "init"},
+		},
+		{
+			expr:   "$.spec.containers[?(!@.secret)].name",
+			values: []interface{}{"sidecar"},
+		},
+		{
+			expr:   "$.spec.containers[?(!(@.port > 100 || @.name == 'init'))].name",
+			values: []interface{}{"nginx"},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.expr, func(t *testing.T) {
+			q, err := Parse(test.expr)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if values := q.Values(testObject()); !reflect.DeepEqual(values, test.values) {
+				t.Errorf("expected values %v, got %v", test.values, values)
+			}
+			if test.paths == nil {
+				return
+			}
+			var paths []string
+			for _, match := range q.Execute(testObject()) {
+				paths = append(paths, match.Path)
+			}
+			if !reflect.DeepEqual(paths, test.paths) {
+				t.Errorf("expected paths %v, got %v", test.paths, paths)
+			}
+		})
+	}
+}
+
+func TestRedact(t *testing.T) {
+	obj := testObject()
+	redacted, err := Redact(obj, []string{
+		"$..secret",
+		"$.metadata.labels['app.kubernetes.io/name']",
+		"$.spec.containers[?(@.name == 'sidecar')].image",
+		"$.status.password",
+	}, "***")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if values := MustParse("$..secret").Values(redacted); !reflect.DeepEqual(values, []interface{}{"***", "***"}) {
+		t.Errorf("expected secrets to be redacted, got %v", values)
+	}
+	if v := redacted.String("metadata", "labels", "app.kubernetes.io/name"); v != "***" {
+		t.Errorf("expected label to be redacted, got %q", v)
+	}
+	if values := MustParse("$.spec.containers[*].image").Values(redacted); !reflect.DeepEqual(values, []interface{}{"nginx", "***", "busybox"}) {
+		t.Errorf("expected the filtered image to be redacted, got %v", values)
+	}
+	if _, ok := redacted["status"]; ok {
+		t.Errorf("expected missing paths to be skipped, got %v", redacted)
+	}
+	if !reflect.DeepEqual(obj, testObject()) {
+		t.Errorf("expected the original to be unchanged, got %v", obj)
+	}
+
+	if _, err := Redact(obj, []string{"$["}, "***"); err == nil {
+		t.Error("expected invalid expression to fail")
+	}
+}
@@ -0,0 +1,48 @@
+package data
+
+import "strconv"
+
+// Redact returns a copy of obj where the values at paths matching one of the
+// patterns are replaced with replacement. Patterns use the glob syntax of
+// RemoveMatching, for example "**.password" or "data.*". obj is not modified.
+func Redact(obj Object, patterns []string, replacement interface{}) (Object, error) {
+	var parsed [][]string
+	for _, pattern := range patterns {
+		segments, err := splitPattern(pattern)
+		if err != nil {
+			return nil, err
+		}
+		parsed = append(parsed, segments)
+	}
+
+	result := obj.DeepCopy()
+	redact(map[string]interface{}(result), parsed, replacement)
+	return result, nil
+}
+
+func redact(v interface{}, patterns [][]string, replacement interface{}) {
+	if m, ok := asMap(v); ok {
+		for k, child := range m {
+			remaining, matched := advancePatterns(patterns, k)
+			if matched {
+				m[k] = replacement
+			} else if len(remaining) > 0 {
+				redact(child, remaining, replacement)
+			}
+		}
+		return
+	}
+
+	items, ok := v.([]interface{})
+	if !ok {
+		return
+	}
+	for i, item := range items {
+		remaining, matched := advancePatterns(patterns, "["+strconv.Itoa(i)+"]")
+		if matched {
+			items[i] = replacement
+		} else if len(remaining) > 0 {
+			redact(item, remaining, replacement)
+		}
+	}
+}
@@ -0,0 +1,87 @@
+package data
+
+import (
+	"reflect"
+	"testing"
+)
+
+func redactObject() Object {
+	return Object{
+		"password": "root",
+		"spec": map[string]interface{}{
+			"auth": map[string]interface{}{"password": "secret", "user": "admin"},
+			"containers": []interface{}{
+				map[string]interface{}{"name": "web", "env": map[string]interface{}{"TOKEN": "a"}},
+				map[string]interface{}{"name": "sidecar"},
+			},
+		},
+		"data": map[string]interface{}{"tls.crt": "cert", "tls.key": "key"},
+	}
+}
+
+func TestRedact(t *testing.T) {
+	tests := []struct {
+		name     string
+		patterns []string
+		redacted []string
+	}{
+		{
+			name:     "nested",
+			patterns: []string{"spec.auth.password"},
+			redacted: []string{"spec.auth.password"},
+		},
+		{
+			name:     "any depth",
+			patterns: []string{"**.password"},
+			redacted: []string{"password", "spec.auth.password"},
+		},
+		{
+			name:     "array",
+			patterns: []string{"spec.containers[*].env.*"},
+			redacted: []string{"spec.containers[0].env.TOKEN"},
+		},
+		{
+			name:     "array index",
+			patterns: []string{"spec.containers[1]"},
+			redacted: []string{"spec.containers[1]"},
+		},
+		{
+			name:     "escaped key",
+			patterns: []string{`data.tls\.key`},
+			redacted: []string{`data.tls\.key`},
+		},
+		{
+			name:     "missing",
+			patterns: []string{"spec.missing.password", "status.*"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			obj := redactObject()
+			result, err := Redact(obj, tt.patterns, "***")
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(obj, redactObject()) {
+				t.Fatalf("expected the input to be unmodified, got %v", obj)
+			}
+
+			expected := redactObject()
+			for _, path := range tt.redacted {
+				if err := expected.SetPath(path, "***"); err != nil {
+					t.Fatal(err)
+				}
+			}
+			if !reflect.DeepEqual(result, expected) {
+				t.Fatalf("expected %v, got %v", expected, result)
+			}
+		})
+	}
+}
+
+func TestRedactInvalidPattern(t *testing.T) {
+	if _, err := Redact(redactObject(), []string{"spec[0"}, "***"); err == nil {
+		t.Fatal("expected error for an invalid pattern")
+	}
+}
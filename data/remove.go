@@ -0,0 +1,229 @@
+package data
+
+import (
+	"fmt"
+	"strconv"
+)
+
+// RemovePath removes the value at path, in the syntax of GetPath, and returns
+// it. Elements removed from slices shift the following elements.
+func (o Object) RemovePath(path string) (interface{}, bool) {
+	parts, err := parsePath(path)
+	if err != nil || parts[0].isIndex {
+		return nil, false
+	}
+
+	var (
+		current interface{} = map[string]interface{}(o)
+		parent  interface{}
+	)
+	for i, part := range parts {
+		last := i == len(parts)-1
+		if part.isIndex {
+			items, ok := current.([]interface{})
+			if !ok || part.index >= len(items) {
+				return nil, false
+			}
+			if !last {
+				parent, current = current, items[part.index]
+				continue
+			}
+			removed := items[part.index]
+			items = append(items[:part.index], items[part.index+1:]...)
+			// the slice header changed, store it in the parent again
+			setChild(parent, parts[i-1], items)
+			return removed, true
+		}
+
+		m, ok := asMap(current)
+		if !ok {
+			return nil, false
+		}
+		v, ok := m[part.key]
+		if !ok {
+			return nil, false
+		}
+		if last {
+			delete(m, part.key)
+			return v, true
+		}
+		parent, current = current, v
+	}
+
+	return nil, false
+}
+
+func setChild(parent interface{}, part pathPart, value interface{}) {
+	if part.isIndex {
+		if items, ok := parent.([]interface{}); ok {
+			items[part.index] = value
+		}
+	} else if m, ok := asMap(parent); ok {
+		m[part.key] = value
+	}
+}
+
+// RemoveMatching removes all values whose path matches one of the patterns
+// and returns the number of removed values. Patterns use the syntax of
+// GetPath with "*" and "?" matching within a key or index, for example
+// "metadata.annotations.kubectl\.kubernetes\.io/*" or "spec.containers[*].env",
+// and "**" matching any number of keys and indexes, as in "**.managedFields".
+func (o Object) RemoveMatching(patterns ...string) (int, error) {
+	var parsed [][]string
+	for _, pattern := range patterns {
+		segments, err := splitPattern(pattern)
+		if err != nil {
+			return 0, err
+		}
+		parsed = append(parsed, segments)
+	}
+
+	_, count := removeMatching(map[string]interface{}(o), parsed)
+	return count, nil
+}
+
+func removeMatching(v interface{}, patterns [][]string) (interface{}, int) {
+	count := 0
+	if m, ok := asMap(v); ok {
+		for k, child := range m {
+			remaining, matched := advancePatterns(patterns, k)
+			if matched {
+				delete(m, k)
+				count++
+			} else if len(remaining) > 0 {
+				var n int
+				m[k], n = removeMatching(child, remaining)
+				count += n
+			}
+		}
+		return v, count
+	}
+
+	items, ok := v.([]interface{})
+	if !ok {
+		return v, 0
+	}
+	result := items[:0]
+	for i, item := range items {
+		remaining, matched := advancePatterns(patterns, "["+strconv.Itoa(i)+"]")
+		if matched {
+			count++
+			continue
+		}
+		if len(remaining) > 0 {
+			var n int
+			item, n = removeMatching(item, remaining)
+			count += n
+		}
+		result = append(result, item)
+	}
+	return result, count
+}
+
+// advancePatterns consumes segment from the patterns and returns the
+// patterns left to match against the children of the segment. matched is
+// true if a pattern was fully matched by the segment.
+func advancePatterns(patterns [][]string, segment string) (remaining [][]string, matched bool) {
+	for _, pattern := range patterns {
+		for _, rest := range advancePattern(pattern, segment) {
+			if len(rest) == 0 {
+				matched = true
+			} else {
+				remaining = append(remaining, rest)
+			}
+		}
+	}
+	return remaining, matched
+}
+
+func advancePattern(pattern []string, segment string) [][]string {
+	if len(pattern) == 0 {
+		return nil
+	}
+	if pattern[0] == "**" {
+		// "**" either matches nothing or the segment and possibly more
+		return append(advancePattern(pattern[1:], segment), pattern)
+	}
+	if globMatch(pattern[0], segment) {
+		return [][]string{pattern[1:]}
+	}
+	return nil
+}
+
+// splitPattern splits a pattern into key and "[index]" segments, escapes are
+// kept for globMatch.
+func splitPattern(pattern string) ([]string, error) {
+	var (
+		segments []string
+		current  []byte
+	)
+	flush := func() {
+		if len(current) > 0 {
+			segments = append(segments, string(current))
+		}
+		current = nil
+	}
+
+	for i := 0; i < len(pattern); i++ {
+		switch c := pattern[i]; c {
+		case '\\':
+			if i+1 >= len(pattern) {
+				return nil, fmt.Errorf("invalid pattern %q: trailing escape", pattern)
+			}
+			current = append(current, c, pattern[i+1])
+			i++
+		case '.':
+			flush()
+		case '[':
+			flush()
+			end := i + 1
+			for end < len(pattern) && pattern[end] != ']' {
+				end++
+			}
+			if end >= len(pattern) {
+				return nil, fmt.Errorf("invalid pattern %q: missing ]", pattern)
+			}
+			segments = append(segments, pattern[i:end+1])
+			i = end
+		default:
+			current = append(current, c)
+		}
+	}
+	flush()
+
+	if len(segments) == 0 {
+		return nil, fmt.Errorf("invalid pattern %q: empty", pattern)
+	}
+	return segments, nil
+}
+
+// globMatch matches s against a pattern where "*" matches any characters,
+// "?" a single character and "\" escapes the next character.
+func globMatch(pattern, s string) bool {
+	for len(pattern) > 0 {
+		switch pattern[0] {
+		case '*':
+			for i := len(s); i >= 0; i-- {
+				if globMatch(pattern[1:], s[i:]) {
+					return true
+				}
+			}
+			return false
+		case '?':
+			if len(s) == 0 {
+				return false
+			}
+		case '\\':
+			pattern = pattern[1:]
+			if len(s) == 0 || s[0] != pattern[0] {
+				return false
+			}
+		default:
+			if len(s) == 0 || s[0] != pattern[0] {
+				return false
+			}
+		}
+		pattern, s = pattern[1:], s[1:]
+	}
+	return len(s) == 0
+}
@@ -0,0 +1,151 @@
+package data
+
+import (
+	"reflect"
+	"testing"
+)
+
+func removeObject() Object {
+	return Object{
+		"metadata": map[string]interface{}{
+			"name": "app",
+			"annotations": map[string]interface{}{
+				"kubectl.kubernetes.io/last-applied-configuration": "{}",
+				"kubectl.kubernetes.io/restartedAt":                "now",
+				"example.com/owner":                                "team",
+			},
+			"managedFields": []interface{}{"field"},
+		},
+		"spec": map[string]interface{}{
+			"containers": []interface{}{
+				map[string]interface{}{"name": "a", "env": []interface{}{"A"}},
+				map[string]interface{}{"name": "b", "env": []interface{}{"B"}},
+			},
+			"template": map[string]interface{}{
+				"metadata": map[string]interface{}{
+					"managedFields": []interface{}{"field"},
+				},
+			},
+		},
+	}
+}
+
+func TestRemovePath(t *testing.T) {
+	obj := removeObject()
+
+	if v, ok := obj.RemovePath("spec.containers[0]"); !ok || v.(map[string]interface{})["name"] != "a" {
+		t.Fatalf("expected container a to be removed, got %v %v", v, ok)
+	}
+	if v, ok := obj.RemovePath("spec.containers[0].env[0]"); !ok || v != "B" {
+		t.Fatalf("expected env B to be removed, got %v %v", v, ok)
+	}
+	if v, ok := obj.RemovePath(`metadata.annotations.example\.com/owner`); !ok || v != "team" {
+		t.Fatalf("expected the owner annotation to be removed, got %v %v", v, ok)
+	}
+
+	containers := GetValueN(obj, "spec", "containers")
+	expected := []interface{}{map[string]interface{}{"name": "b", "env": []interface{}{}}}
+	if !reflect.DeepEqual(containers, expected) {
+		t.Fatalf("expected %v, got %v", expected, containers)
+	}
+
+	for _, path := range []string{"[0]", "missing", "spec.containers[5]", "metadata.name.first", "spec.missing.key", "spec..x"} {
+		if _, ok := obj.RemovePath(path); ok {
+			t.Errorf("expected nothing to be removed for %s", path)
+		}
+	}
+}
+
+func TestRemoveMatching(t *testing.T) {
+	tests := []struct {
+		name     string
+		patterns []string
+		count    int
+		removed  [][]string
+		kept     [][]string
+	}{
+		{
+			name:     "glob in key",
+			patterns: []string{`metadata.annotations.kubectl\.kubernetes\.io/*`},
+			count:    2,
+			removed:  [][]string{{"metadata", "annotations", "kubectl.kubernetes.io/restartedAt"}},
+			kept:     [][]string{{"metadata", "annotations", "example.com/owner"}},
+		},
+		{
+			name:     "single character",
+			patterns: []string{"metadata.na?e"},
+			count:    1,
+			removed:  [][]string{{"metadata", "name"}},
+		},
+		{
+			name:     "any depth",
+			patterns: []string{"**.managedFields"},
+			count:    2,
+			removed:  [][]string{{"metadata", "managedFields"}, {"spec", "template", "metadata", "managedFields"}},
+			kept:     [][]string{{"spec", "template", "metadata"}},
+		},
+		{
+			name:     "every index",
+			patterns: []string{"spec.containers[*].env"},
+			count:    2,
+			kept:     [][]string{{"spec", "containers"}},
+		},
+		{
+			name:     "several patterns",
+			patterns: []string{"metadata.name", "spec"},
+			count:    2,
+			removed:  [][]string{{"metadata", "name"}, {"spec"}},
+			kept:     [][]string{{"metadata", "annotations"}},
+		},
+		{
+			name:     "no match",
+			patterns: []string{"status.*"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			obj := removeObject()
+			count, err := obj.RemoveMatching(test.patterns...)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if count != test.count {
+				t.Errorf("expected %d removed values, got %d", test.count, count)
+			}
+			for _, path := range test.removed {
+				if _, ok := GetValue(obj, path...); ok {
+					t.Errorf("expected %v to be removed", path)
+				}
+			}
+			for _, path := range test.kept {
+				if _, ok := GetValue(obj, path...); !ok {
+					t.Errorf("expected %v to be kept", path)
+				}
+			}
+		})
+	}
+}
+
+func TestRemoveMatchingIndexes(t *testing.T) {
+	obj := Object{"items": []interface{}{"a", "b", "c"}}
+	count, err := obj.RemoveMatching("items[1]", "items[2]")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if count != 2 || !reflect.DeepEqual(obj["items"], []interface{}{"a"}) {
+		t.Fatalf("expected [a], got %d %v", count, obj["items"])
+	}
+}
+
+func TestRemoveMatchingErrors(t *testing.T) {
+	for pattern, expected := range map[string]string{
+		"":        `invalid pattern "": empty`,
+		`a\`:      `invalid pattern "a\\": trailing escape`,
+		"items[0": `invalid pattern "items[0": missing ]`,
+	} {
+		if _, err := (Object{}).RemoveMatching(pattern); err == nil || err.Error() != expected {
+			t.Errorf("expected error %q for %q, got %v", expected, pattern, err)
+		}
+	}
+}
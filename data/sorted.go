@@ -0,0 +1,26 @@
+package data
+
+import "sort"
+
+// SortedKeys returns the keys of o in sorted order.
+func (o Object) SortedKeys() []string {
+	keys := make([]string, 0, len(o))
+	for k := range o {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// Sorted returns an iterator over the keys and values of o in key order. It
+// can be called with a yield function, which returns false to stop, or be
+// used with range on Go versions that support range over functions.
+func (o Object) Sorted() func(yield func(key string, value interface{}) bool) {
+	return func(yield func(key string, value interface{}) bool) {
+		for _, k := range o.SortedKeys() {
+			if !yield(k, o[k]) {
+				return
+			}
+		}
+	}
+}
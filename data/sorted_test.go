@@ -0,0 +1,31 @@
+package data
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestSorted(t *testing.T) {
+	obj := Object{"c": int64(3), "a": int64(1), "b": int64(2)}
+	if keys := obj.SortedKeys(); !reflect.DeepEqual(keys, []string{"a", "b", "c"}) {
+		t.Fatalf("expected sorted keys, got %v", keys)
+	}
+
+	var (
+		keys   []string
+		values []interface{}
+	)
+	obj.Sorted()(func(key string, value interface{}) bool {
+		keys = append(keys, key)
+		values = append(values, value)
+		return key != "b"
+	})
+	if !reflect.DeepEqual(keys, []string{"a", "b"}) || !reflect.DeepEqual(values, []interface{}{int64(1), int64(2)}) {
+		t.Fatalf("expected iteration to stop after b, got %v %v", keys, values)
+	}
+
+	var empty Object
+	if keys := empty.SortedKeys(); len(keys) != 0 {
+		t.Fatalf("expected no keys, got %v", keys)
+	}
+}
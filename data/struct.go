@@ -0,0 +1,76 @@
+package data
+
+import (
+	"reflect"
+
+	"github.com/acorn-io/schemer/data/convert"
+)
+
+type FromStructOption func(opts *convert.EncodeOptions)
+
+// WithTags selects the struct tags used to name fields, the first one present
+// on a field wins.
+func WithTags(tagNames ...string) FromStructOption {
+	return func(opts *convert.EncodeOptions) {
+		opts.TagNames = tagNames
+	}
+}
+
+// WithOmitEmpty selects which empty fields are left out.
+func WithOmitEmpty(omitEmpty convert.OmitEmpty) FromStructOption {
+	return func(opts *convert.EncodeOptions) {
+		opts.OmitEmpty = omitEmpty
+	}
+}
+
+// WithOmitZero leaves out fields holding zero values and, if collections is
+// true, empty maps and slices.
+func WithOmitZero(collections bool) FromStructOption {
+	return func(opts *convert.EncodeOptions) {
+		opts.OmitZero = true
+		opts.OmitEmptyCollections = collections
+	}
+}
+
+// WithUnexportedTag includes unexported fields tagged with tagName.
+func WithUnexportedTag(tagName string) FromStructOption {
+	return func(opts *convert.EncodeOptions) {
+		opts.UnexportedTag = tagName
+	}
+}
+
+// WithEncoder encodes all values of the type of sample with fn.
+func WithEncoder(sample interface{}, fn convert.EncoderFunc) FromStructOption {
+	return func(opts *convert.EncodeOptions) {
+		if opts.Encoders == nil {
+			opts.Encoders = map[reflect.Type]convert.EncoderFunc{}
+		}
+		opts.Encoders[reflect.TypeOf(sample)] = fn
+	}
+}
+
+// WithNilPointers selects whether nil pointer fields are left out or set to
+// nil.
+func WithNilPointers(nilPointers convert.NilPointers) FromStructOption {
+	return func(opts *convert.EncodeOptions) {
+		opts.NilPointers = nilPointers
+	}
+}
+
+// WithPool allocates the maps of the result from pool, release the result
+// to the pool once it's no longer used.
+func WithPool(pool *ObjectPool) FromStructOption {
+	return func(opts *convert.EncodeOptions) {
+		opts.NewMap = pool.newMap
+	}
+}
+
+// FromStruct converts a struct, or a pointer to one, to an Object using
+// reflection.
+func FromStruct(v interface{}, opts ...FromStructOption) (Object, error) {
+	var encodeOpts convert.EncodeOptions
+	for _, opt := range opts {
+		opt(&encodeOpts)
+	}
+	return convert.EncodeToMapWithOptions(v, encodeOpts)
+}
@@ -0,0 +1,54 @@
+package data
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/acorn-io/schemer/data/convert"
+)
+
+type structSpec struct {
+	Name     string    `json:"name" yaml:"displayName"`
+	Replicas int       `json:"replicas,omitempty"`
+	Image    string    `json:"image"`
+	Created  time.Time `json:"created"`
+}
+
+func TestFromStruct(t *testing.T) {
+	created := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	spec := &structSpec{Name: "app", Created: created}
+
+	obj, err := FromStruct(spec)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := Object{"name": "app", "image": "", "created": "2024-01-02T03:04:05Z"}
+	if !reflect.DeepEqual(obj, expected) {
+		t.Fatalf("expected %v, got %v", expected, obj)
+	}
+
+	obj, err = FromStruct(spec,
+		WithTags("yaml", "json"),
+		WithOmitEmpty(convert.OmitEmptyAlways),
+		WithEncoder(time.Time{}, func(value interface{}) (interface{}, error) {
+			return value.(time.Time).Unix(), nil
+		}),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected = Object{"displayName": "app", "created": created.Unix()}
+	if !reflect.DeepEqual(obj, expected) {
+		t.Fatalf("expected %v, got %v", expected, obj)
+	}
+
+	obj, err = FromStruct(spec, WithOmitEmpty(convert.OmitEmptyNever))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if obj["replicas"] != json.Number("0") {
+		t.Fatalf("expected replicas to be kept, got %v", obj)
+	}
+}
@@ -0,0 +1,79 @@
+package data
+
+import "sync"
+
+// SyncObject guards an Object with a lock so it can be shared between
+// goroutines. Values returned by its methods are deep copies, so they can be
+// used without holding the lock.
+type SyncObject struct {
+	lock sync.RWMutex
+	obj  Object
+}
+
+// NewSyncObject wraps a copy of obj.
+func NewSyncObject(obj Object) *SyncObject {
+	obj = obj.DeepCopy()
+	if obj == nil {
+		obj = Object{}
+	}
+	return &SyncObject{
+		obj: obj,
+	}
+}
+
+// Get returns a copy of the value at path, see Object.GetPath.
+func (s *SyncObject) Get(path string) (interface{}, bool) {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	v, ok := s.obj.GetPath(path)
+	return copyValue(v), ok
+}
+
+// Set stores a copy of value at path, see Object.SetPath.
+func (s *SyncObject) Set(path string, value interface{}) error {
+	value = copyValue(value)
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return s.obj.SetPath(path, value)
+}
+
+// Map returns a copy of the nested object, see Object.Map.
+func (s *SyncObject) Map(names ...string) Object {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	return s.obj.Map(names...).DeepCopy()
+}
+
+// Update calls fn with the object while holding the write lock. fn must not
+// keep references to the object after it returns.
+func (s *SyncObject) Update(fn func(obj Object) error) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return fn(s.obj)
+}
+
+// View calls fn with the object while holding the read lock. fn must not
+// modify the object or keep references to it after it returns.
+func (s *SyncObject) View(fn func(obj Object)) {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	fn(s.obj)
+}
+
+// Snapshot returns a deep copy of the whole object.
+func (s *SyncObject) Snapshot() Object {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	return s.obj.DeepCopy()
+}
+
+// Replace swaps the object for a copy of obj.
+func (s *SyncObject) Replace(obj Object) {
+	obj = obj.DeepCopy()
+	if obj == nil {
+		obj = Object{}
+	}
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.obj = obj
+}
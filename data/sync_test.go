@@ -0,0 +1,68 @@
+package data
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+)
+
+func TestSyncObject(t *testing.T) {
+	obj := Object{"spec": map[string]interface{}{"replicas": int64(1)}}
+	s := NewSyncObject(obj)
+
+	// the wrapped object and returned values are copies
+	obj.Map("spec")["replicas"] = int64(5)
+	if v, ok := s.Get("spec.replicas"); !ok || v != int64(1) {
+		t.Fatalf("expected 1, got %v %v", v, ok)
+	}
+	s.Map("spec")["replicas"] = int64(5)
+	s.Snapshot().Map("spec")["replicas"] = int64(5)
+	if v, _ := s.Get("spec.replicas"); v != int64(1) {
+		t.Fatalf("expected returned objects to be copies, got %v", v)
+	}
+
+	if err := s.Set("spec.image", "nginx"); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.Update(func(obj Object) error {
+		obj.Map("spec")["replicas"] = int64(2)
+		return nil
+	}); err != nil {
+		t.Fatal(err)
+	}
+	s.View(func(obj Object) {
+		if obj.String("spec", "image") != "nginx" || obj.Map("spec")["replicas"] != int64(2) {
+			t.Errorf("unexpected object %v", obj)
+		}
+	})
+
+	s.Replace(nil)
+	if snapshot := s.Snapshot(); len(snapshot) != 0 {
+		t.Fatalf("expected an empty object, got %v", snapshot)
+	}
+	if err := s.Set("name", "app"); err != nil {
+		t.Fatalf("expected a replaced nil object to be usable, got %v", err)
+	}
+}
+
+func TestSyncObjectConcurrent(t *testing.T) {
+	s := NewSyncObject(nil)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			if err := s.Set(fmt.Sprintf("items.item%d", i), int64(i)); err != nil {
+				t.Error(err)
+			}
+			_ = s.Snapshot()
+			_, _ = s.Get("items")
+		}(i)
+	}
+	wg.Wait()
+
+	if items := s.Map("items"); len(items) != 10 {
+		t.Fatalf("expected 10 items, got %v", items)
+	}
+}
@@ -0,0 +1,121 @@
+package data
+
+import "fmt"
+
+// TransformFunc receives the current value at a path, or nil if it's not set,
+// and returns the value to store. Returning nil removes the value.
+type TransformFunc func(value interface{}) (interface{}, error)
+
+// PathTransform is a single transformation applied by TransformAll.
+type PathTransform struct {
+	Path string
+	Func TransformFunc
+}
+
+// Transform replaces the value at path, in the syntax of GetPath, with the
+// result of fn. Missing objects along the path are created when a value is
+// set and objects and slices that become empty because a value was removed
+// are removed as well.
+func Transform(obj Object, path string, fn TransformFunc) error {
+	if obj == nil {
+		return fmt.Errorf("can not transform %s on nil object", path)
+	}
+
+	parts, err := parsePath(path)
+	if err != nil {
+		return err
+	}
+	if parts[0].isIndex {
+		return fmt.Errorf("invalid path %q: must start with a key", path)
+	}
+
+	if _, err := transformValue(map[string]interface{}(obj), parts, fn); err != nil {
+		return fmt.Errorf("failed to transform %s: %w", path, err)
+	}
+	return nil
+}
+
+// TransformAll applies the transforms in order and stops at the first error.
+func TransformAll(obj Object, transforms ...PathTransform) error {
+	for _, t := range transforms {
+		if err := Transform(obj, t.Path, t.Func); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func transformValue(current interface{}, parts []pathPart, fn TransformFunc) (interface{}, error) {
+	if len(parts) == 0 {
+		return fn(current)
+	}
+
+	part := parts[0]
+	if part.isIndex {
+		var items []interface{}
+		if current != nil {
+			var ok bool
+			if items, ok = current.([]interface{}); !ok {
+				return nil, fmt.Errorf("%s is indexed but is not a slice", part)
+			}
+		}
+
+		switch {
+		case part.index < len(items):
+			v, err := transformValue(items[part.index], parts[1:], fn)
+			if err != nil {
+				return nil, err
+			}
+			if v != nil {
+				items[part.index] = v
+				return items, nil
+			}
+			items = append(items[:part.index], items[part.index+1:]...)
+			if len(items) == 0 {
+				return nil, nil
+			}
+			return items, nil
+		case part.index == len(items):
+			v, err := transformValue(nil, parts[1:], fn)
+			if err != nil || v == nil {
+				return current, err
+			}
+			return append(items, v), nil
+		}
+		return nil, fmt.Errorf("index %s is out of range", part)
+	}
+
+	var m map[string]interface{}
+	if current != nil {
+		var ok bool
+		if m, ok = asMap(current); !ok {
+			return nil, fmt.Errorf("can not transform key %s of a value that is not an object", part)
+		}
+	}
+
+	existing, exists := m[part.key]
+	v, err := transformValue(existing, parts[1:], fn)
+	if err != nil {
+		return nil, err
+	}
+
+	if v == nil {
+		if !exists {
+			return current, nil
+		}
+		delete(m, part.key)
+		if len(m) == 0 {
+			return nil, nil
+		}
+		return current, nil
+	}
+
+	if m == nil {
+		m = map[string]interface{}{}
+	}
+	m[part.key] = v
+	if current == nil {
+		return m, nil
+	}
+	return current, nil
+}
@@ -0,0 +1,125 @@
+package data
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestTransform(t *testing.T) {
+	obj := Object{
+		"spec": map[string]interface{}{
+			"replicas": int64(1),
+			"ports":    []interface{}{int64(80), int64(443)},
+		},
+	}
+
+	double := func(value interface{}) (interface{}, error) {
+		return value.(int64) * 2, nil
+	}
+	remove := func(interface{}) (interface{}, error) {
+		return nil, nil
+	}
+	set := func(v interface{}) TransformFunc {
+		return func(interface{}) (interface{}, error) {
+			return v, nil
+		}
+	}
+
+	for path, fn := range map[string]TransformFunc{
+		"spec.replicas":           double,
+		"spec.ports[0]":           remove,
+		"metadata.labels.app":     set("web"),
+		"spec.containers[0].name": set("web"),
+		"missing.value":           remove,
+	} {
+		if err := Transform(obj, path, fn); err != nil {
+			t.Fatalf("%s: %v", path, err)
+		}
+	}
+
+	expected := Object{
+		"metadata": map[string]interface{}{
+			"labels": map[string]interface{}{"app": "web"},
+		},
+		"spec": map[string]interface{}{
+			"replicas":   int64(2),
+			"ports":      []interface{}{int64(443)},
+			"containers": []interface{}{map[string]interface{}{"name": "web"}},
+		},
+	}
+	if !reflect.DeepEqual(obj, expected) {
+		t.Fatalf("expected %v, got %v", expected, obj)
+	}
+
+	// objects and slices that become empty are removed
+	if err := Transform(obj, "metadata.labels.app", remove); err != nil {
+		t.Fatal(err)
+	}
+	if err := Transform(obj, "spec.ports[0]", remove); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := obj["metadata"]; ok {
+		t.Fatalf("expected empty metadata to be removed, got %v", obj)
+	}
+	if _, ok := obj.Map("spec")["ports"]; ok {
+		t.Fatalf("expected empty ports to be removed, got %v", obj)
+	}
+}
+
+func TestTransformAll(t *testing.T) {
+	obj := Object{"name": "web"}
+
+	// transforms see the results of the previous ones
+	var order []string
+	appendName := func(suffix string) TransformFunc {
+		return func(value interface{}) (interface{}, error) {
+			order = append(order, suffix)
+			return value.(string) + suffix, nil
+		}
+	}
+	err := TransformAll(obj,
+		PathTransform{Path: "name", Func: appendName("-a")},
+		PathTransform{Path: "name", Func: appendName("-b")},
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if obj["name"] != "web-a-b" || !reflect.DeepEqual(order, []string{"-a", "-b"}) {
+		t.Fatalf("expected transforms to run in order, got %v %v", obj["name"], order)
+	}
+
+	// the first error stops the pipeline
+	failed := errors.New("failed")
+	order = nil
+	err = TransformAll(obj,
+		PathTransform{Path: "name", Func: func(interface{}) (interface{}, error) { return nil, failed }},
+		PathTransform{Path: "name", Func: appendName("-c")},
+	)
+	if !errors.Is(err, failed) {
+		t.Fatalf("expected the error of the transform, got %v", err)
+	}
+	if len(order) != 0 || obj["name"] != "web-a-b" {
+		t.Fatalf("expected no transform after the error, got %v %v", obj["name"], order)
+	}
+}
+
+func TestTransformErrors(t *testing.T) {
+	keep := func(value interface{}) (interface{}, error) {
+		return value, nil
+	}
+
+	if err := Transform(nil, "name", keep); err == nil {
+		t.Fatal("expected error for a nil object")
+	}
+	if err := TransformAll(nil, PathTransform{Path: "name", Func: keep}); err == nil {
+		t.Fatal("expected error for a nil object")
+	}
+
+	obj := Object{"name": "web", "ports": []interface{}{int64(80)}}
+	for _, path := range []string{"[0]", "name.first", "name[0]", "ports[2]", "ports..x"} {
+		if err := Transform(obj, path, keep); err == nil {
+			t.Errorf("expected error for %s", path)
+		}
+	}
+}
@@ -0,0 +1,139 @@
+package data
+
+import (
+	"encoding/json"
+	"math"
+	"strconv"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+// FromUnstructured returns the content of u without copying it, changes to
+// the Object are visible in u.
+func FromUnstructured(u *unstructured.Unstructured) Object {
+	if u == nil {
+		return nil
+	}
+	return u.Object
+}
+
+// FromUnstructuredList returns the items of l without copying them.
+func FromUnstructuredList(l *unstructured.UnstructuredList) List {
+	if l == nil {
+		return nil
+	}
+	result := make(List, len(l.Items))
+	for i := range l.Items {
+		result[i] = l.Items[i].Object
+	}
+	return result
+}
+
+// ToUnstructured wraps o without copying it. Values the unstructured package
+// can't handle, like int or []string, are converted in place to their JSON
+// equivalents first.
+func (o Object) ToUnstructured() *unstructured.Unstructured {
+	Normalize(o)
+	return &unstructured.Unstructured{Object: o}
+}
+
+// ToUnstructuredList wraps the items of l without copying them, see
+// Object.ToUnstructured.
+func (l List) ToUnstructuredList() *unstructured.UnstructuredList {
+	result := &unstructured.UnstructuredList{
+		Items: make([]unstructured.Unstructured, len(l)),
+	}
+	for i, obj := range l {
+		result.Items[i].Object = obj.ToUnstructured().Object
+	}
+	return result
+}
+
+// Normalize converts the values of o in place to the types produced by
+// decoding JSON, which the unstructured package requires: integers become
+// int64, or json.Number if they don't fit, float32 and json.Number values that
+// aren't integers become float64 and typed maps and slices become
+// map[string]interface{} and []interface{}.
+func Normalize(o Object) {
+	for k, v := range o {
+		o[k] = normalizeValue(v)
+	}
+}
+
+func normalizeValue(v interface{}) interface{} {
+	switch t := v.(type) {
+	case map[string]interface{}:
+		Normalize(t)
+		return t
+	case Object:
+		Normalize(t)
+		return map[string]interface{}(t)
+	case []interface{}:
+		for i, item := range t {
+			t[i] = normalizeValue(item)
+		}
+		return t
+	case List:
+		result := make([]interface{}, len(t))
+		for i, item := range t {
+			result[i] = normalizeValue(item)
+		}
+		return result
+	case []map[string]interface{}:
+		result := make([]interface{}, len(t))
+		for i, item := range t {
+			result[i] = normalizeValue(item)
+		}
+		return result
+	case []string:
+		result := make([]interface{}, len(t))
+		for i, item := range t {
+			result[i] = item
+		}
+		return result
+	case map[string]string:
+		result := make(map[string]interface{}, len(t))
+		for k, item := range t {
+			result[k] = item
+		}
+		return result
+	case int:
+		return int64(t)
+	case int8:
+		return int64(t)
+	case int16:
+		return int64(t)
+	case int32:
+		return int64(t)
+	case uint:
+		return normalizeUint(uint64(t))
+	case uint8:
+		return int64(t)
+	case uint16:
+		return int64(t)
+	case uint32:
+		return int64(t)
+	case uint64:
+		return normalizeUint(t)
+	case float32:
+		return float64(t)
+	case json.Number:
+		if i, err := t.Int64(); err == nil {
+			return i
+		}
+		if _, err := strconv.ParseUint(string(t), 10, 64); err == nil {
+			return t
+		}
+		if f, err := t.Float64(); err == nil {
+			return f
+		}
+	}
+	return v
+}
+
+func normalizeUint(u uint64) interface{} {
+	if u > math.MaxInt64 {
+		return json.Number(strconv.FormatUint(u, 10))
+	}
+	return int64(u)
+}
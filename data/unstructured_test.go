@@ -0,0 +1,83 @@
+package data
+
+import (
+	"encoding/json"
+	"math"
+	"reflect"
+	"testing"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+func TestNormalize(t *testing.T) {
+	obj := Object{
+		"int":     1,
+		"int8":    int8(2),
+		"uint32":  uint32(3),
+		"uint64":  uint64(math.MaxUint64),
+		"float32": float32(1.5),
+		"number":  json.Number("4"),
+		"float":   json.Number("4.5"),
+		"big":     json.Number("18446744073709551615"),
+		"strings": []string{"a"},
+		"labels":  map[string]string{"a": "b"},
+		"nested":  Object{"list": List{{"port": 80}}},
+		"maps":    []map[string]interface{}{{"a": int16(5)}},
+	}
+	Normalize(obj)
+
+	expected := Object{
+		"int":     int64(1),
+		"int8":    int64(2),
+		"uint32":  int64(3),
+		"uint64":  json.Number("18446744073709551615"),
+		"float32": float64(1.5),
+		"number":  int64(4),
+		"float":   4.5,
+		"big":     json.Number("18446744073709551615"),
+		"strings": []interface{}{"a"},
+		"labels":  map[string]interface{}{"a": "b"},
+		"nested":  map[string]interface{}{"list": []interface{}{map[string]interface{}{"port": int64(80)}}},
+		"maps":    []interface{}{map[string]interface{}{"a": int64(5)}},
+	}
+	if !reflect.DeepEqual(obj, expected) {
+		t.Fatalf("expected %v, got %v", expected, obj)
+	}
+
+	// the unstructured package panics on values it doesn't know
+	u := obj.ToUnstructured().DeepCopy()
+	if port, ok, err := unstructured.NestedInt64(u.Object, "number"); err != nil || !ok || port != 4 {
+		t.Fatalf("expected 4, got %d %v %v", port, ok, err)
+	}
+}
+
+func TestUnstructuredSharesContent(t *testing.T) {
+	u := &unstructured.Unstructured{Object: map[string]interface{}{"kind": "App"}}
+	obj := FromUnstructured(u)
+	obj["spec"] = map[string]interface{}{"replicas": 1}
+	if u.Object["spec"] == nil {
+		t.Fatal("expected changes to the object to be visible in the unstructured object")
+	}
+	if FromUnstructured(nil) != nil || FromUnstructuredList(nil) != nil {
+		t.Fatal("expected nil for nil input")
+	}
+
+	u = obj.ToUnstructured()
+	if replicas, _, _ := unstructured.NestedInt64(u.Object, "spec", "replicas"); replicas != 1 {
+		t.Fatalf("expected replicas to be normalized, got %v", u.Object)
+	}
+}
+
+func TestUnstructuredList(t *testing.T) {
+	list := List{{"kind": "App", "port": 80}, {"kind": "Other"}}
+	u := list.ToUnstructuredList()
+	if len(u.Items) != 2 || u.Items[0].GetKind() != "App" || u.Items[0].Object["port"] != int64(80) {
+		t.Fatalf("unexpected list %v", u.Items)
+	}
+
+	result := FromUnstructuredList(u)
+	result[1]["kind"] = "Changed"
+	if u.Items[1].GetKind() != "Changed" {
+		t.Fatal("expected the items to be shared")
+	}
+}
@@ -1,5 +1,10 @@
 package data
 
+import (
+	"fmt"
+	"strings"
+)
+
 func RemoveValue(data map[string]interface{}, keys ...string) (interface{}, bool) {
 	for i, key := range keys {
 		if i == len(keys)-1 {
@@ -7,7 +12,7 @@ func RemoveValue(data map[string]interface{}, keys ...string) (interface{}, bool
 			delete(data, key)
 			return val, ok
 		}
-		data, _ = data[key].(map[string]interface{})
+		data, _ = asMap(data[key])
 	}
 
 	return nil, false
@@ -24,35 +29,39 @@ func GetValue(data map[string]interface{}, keys ...string) (interface{}, bool) {
 			val, ok := data[key]
 			return val, ok
 		}
-		data, _ = data[key].(map[string]interface{})
+		data, _ = asMap(data[key])
 	}
 
 	return nil, false
 }
 
+// PutValue sets val at the nested keys, creating missing or nil parent maps.
+// Nothing is set if one of the parents is not a map, use PutValueE to get an
+// error in that case.
 func PutValue(data map[string]interface{}, val interface{}, keys ...string) {
-	if data == nil {
-		return
+	_ = PutValueE(data, val, keys...)
+}
+
+// PutValueE is PutValue but returns an error if one of the parents is not a
+// map.
+func PutValueE(data map[string]interface{}, val interface{}, keys ...string) error {
+	if data == nil || len(keys) == 0 {
+		return nil
 	}
 
-	// This is so ugly
-	for i, key := range keys {
-		if i == len(keys)-1 {
-			data[key] = val
-		} else {
-			newData, ok := data[key]
-			if ok {
-				newMap, ok := newData.(map[string]interface{})
-				if ok {
-					data = newMap
-				} else {
-					return
-				}
-			} else {
-				newMap := map[string]interface{}{}
-				data[key] = newMap
-				data = newMap
-			}
+	for i, key := range keys[:len(keys)-1] {
+		child, ok := data[key]
+		if !ok || child == nil {
+			newMap := map[string]interface{}{}
+			data[key] = newMap
+			data = newMap
+			continue
+		}
+		if data, ok = asMap(child); !ok {
+			return fmt.Errorf("can not set %s, %s is a %T and not a map", strings.Join(keys, "."), strings.Join(keys[:i+1], "."), child)
 		}
 	}
+
+	data[keys[len(keys)-1]] = val
+	return nil
 }
@@ -0,0 +1,75 @@
+package data
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestPutValue(t *testing.T) {
+	tests := []struct {
+		name     string
+		data     map[string]interface{}
+		expected map[string]interface{}
+	}{
+		{
+			name:     "missing parents",
+			data:     map[string]interface{}{},
+			expected: map[string]interface{}{"a": map[string]interface{}{"b": "value"}},
+		},
+		{
+			name:     "nil parent",
+			data:     map[string]interface{}{"a": nil},
+			expected: map[string]interface{}{"a": map[string]interface{}{"b": "value"}},
+		},
+		{
+			name:     "object parent",
+			data:     map[string]interface{}{"a": Object{"c": "d"}},
+			expected: map[string]interface{}{"a": Object{"b": "value", "c": "d"}},
+		},
+		{
+			name:     "non-map parent",
+			data:     map[string]interface{}{"a": "string"},
+			expected: map[string]interface{}{"a": "string"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			PutValue(test.data, "value", "a", "b")
+			if !reflect.DeepEqual(test.data, test.expected) {
+				t.Fatalf("expected %v, got %v", test.expected, test.data)
+			}
+		})
+	}
+}
+
+func TestPutValueE(t *testing.T) {
+	data := map[string]interface{}{"a": map[string]interface{}{"b": []interface{}{}}}
+	err := PutValueE(data, "value", "a", "b", "c")
+	if err == nil || err.Error() != "can not set a.b.c, a.b is a []interface {} and not a map" {
+		t.Fatalf("unexpected error %v", err)
+	}
+
+	if err := PutValueE(data, "value", "a", "c"); err != nil {
+		t.Fatal(err)
+	}
+	if GetValueN(data, "a", "c") != "value" {
+		t.Fatalf("expected value to be set, got %v", data)
+	}
+}
+
+func TestGetAndRemoveValueObjectParent(t *testing.T) {
+	data := map[string]interface{}{"a": Object{"b": "value"}}
+	if v, ok := GetValue(data, "a", "b"); !ok || v != "value" {
+		t.Fatalf("expected value, got %v %v", v, ok)
+	}
+	if v, ok := RemoveValue(data, "a", "b"); !ok || v != "value" {
+		t.Fatalf("expected removed value, got %v %v", v, ok)
+	}
+	if _, ok := GetValue(data, "a", "b"); ok {
+		t.Fatalf("expected value to be removed, got %v", data)
+	}
+	if _, ok := GetValue(map[string]interface{}{"a": nil}, "a", "b"); ok {
+		t.Fatal("expected nothing to be found below a nil parent")
+	}
+}
@@ -0,0 +1,62 @@
+package data
+
+import "strconv"
+
+// WalkFunc is called for every value visited by Walk. Slice elements have
+// their index as path element. The path is reused between calls, copy it if
+// it's needed after the function returns. Returning true stops the walk.
+type WalkFunc func(path []string, value interface{}) (stop bool)
+
+// Walk calls fn for every value nested in obj, depth first and with the keys
+// of maps in sorted order. Maps and slices are passed to fn before their
+// contents.
+func Walk(obj Object, fn WalkFunc) {
+	walkMap(nil, obj, fn)
+}
+
+func walk(path []string, value interface{}, fn WalkFunc) bool {
+	if fn(path, value) {
+		return true
+	}
+
+	if m, ok := asMap(value); ok {
+		return walkMap(path, m, fn)
+	}
+
+	switch t := value.(type) {
+	case []interface{}:
+		for i, item := range t {
+			if walk(append(path, strconv.Itoa(i)), item, fn) {
+				return true
+			}
+		}
+	case []map[string]interface{}:
+		for i, item := range t {
+			if walk(append(path, strconv.Itoa(i)), item, fn) {
+				return true
+			}
+		}
+	case List:
+		for i, item := range t {
+			if walk(append(path, strconv.Itoa(i)), item, fn) {
+				return true
+			}
+		}
+	case []Object:
+		for i, item := range t {
+			if walk(append(path, strconv.Itoa(i)), item, fn) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func walkMap(path []string, m map[string]interface{}, fn WalkFunc) bool {
+	for _, k := range Object(m).SortedKeys() {
+		if walk(append(path, k), m[k], fn) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,58 @@
+package data
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestWalk(t *testing.T) {
+	obj := Object{
+		"spec": map[string]interface{}{
+			"replicas":   int64(1),
+			"containers": []interface{}{map[string]interface{}{"name": "web"}},
+		},
+		"kind": "App",
+	}
+
+	var visited []string
+	Walk(obj, func(path []string, value interface{}) bool {
+		if _, ok := asMap(value); ok {
+			visited = append(visited, strings.Join(path, "."))
+		} else if _, ok := value.([]interface{}); ok {
+			visited = append(visited, strings.Join(path, ".")+"[]")
+		} else {
+			visited = append(visited, fmt.Sprintf("%s=%v", strings.Join(path, "."), value))
+		}
+		return false
+	})
+
+	expected := []string{
+		"kind=App",
+		"spec",
+		"spec.containers[]",
+		"spec.containers.0",
+		"spec.containers.0.name=web",
+		"spec.replicas=1",
+	}
+	if !reflect.DeepEqual(visited, expected) {
+		t.Fatalf("expected %v, got %v", expected, visited)
+	}
+}
+
+func TestWalkStop(t *testing.T) {
+	obj := Object{
+		"a": map[string]interface{}{"b": "found", "c": "after"},
+		"d": "after",
+	}
+
+	var visited []string
+	Walk(obj, func(path []string, value interface{}) bool {
+		visited = append(visited, strings.Join(path, "."))
+		return value == "found"
+	})
+	if expected := []string{"a", "a.b"}; !reflect.DeepEqual(visited, expected) {
+		t.Fatalf("expected %v, got %v", expected, visited)
+	}
+}
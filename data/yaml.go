@@ -0,0 +1,309 @@
+package data
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+	"reflect"
+
+	"github.com/acorn-io/schemer/data/convert"
+	"gopkg.in/yaml.v3"
+)
+
+// FromYAML decodes a YAML document into an Object. Integers are decoded as
+// int64, or json.Number if they don't fit, floats as float64. Timestamps are
+// kept as strings and keys that are not strings are converted to their
+// string form. Documents that expand aliases excessively are rejected like
+// yaml.Unmarshal does.
+func FromYAML(content []byte) (Object, error) {
+	return decodeYAML(content, Limits{})
+}
+
+func decodeYAML(content []byte, limits Limits) (Object, error) {
+	var node yaml.Node
+	if err := yaml.Unmarshal(content, &node); err != nil {
+		return nil, err
+	}
+	if node.Kind == 0 {
+		return nil, nil
+	}
+
+	d := &yamlDecoder{limits: limits}
+	v, err := d.decode(&node)
+	if err != nil {
+		return nil, err
+	}
+	if v == nil {
+		return nil, nil
+	}
+	m, ok := v.(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("YAML document is a %T, not an object", v)
+	}
+	return m, nil
+}
+
+const (
+	// the alias guard of yaml.v3, the share of nodes decoded through aliases
+	// that is allowed shrinks from 99% to 10% as the document grows
+	aliasRatioRangeLow  = 400000
+	aliasRatioRangeHigh = 4000000
+	aliasRatioRange     = float64(aliasRatioRangeHigh - aliasRatioRangeLow)
+)
+
+// errExcessiveAliasing is the error yaml.Unmarshal returns for alias bombs.
+var errExcessiveAliasing = errors.New("yaml: document contains excessive aliasing")
+
+func allowedAliasRatio(decodeCount int) float64 {
+	switch {
+	case decodeCount <= aliasRatioRangeLow:
+		return 0.99
+	case decodeCount >= aliasRatioRangeHigh:
+		return 0.10
+	default:
+		return 0.99 - 0.89*(float64(decodeCount-aliasRatioRangeLow)/aliasRatioRange)
+	}
+}
+
+// yamlDecoder converts YAML nodes to values. Aliases are expanded, so every
+// decoded node is counted to stop documents that expand exponentially.
+type yamlDecoder struct {
+	limits      Limits
+	keys        int
+	depth       int
+	decodeCount int
+	aliasCount  int
+	aliasDepth  int
+}
+
+// enter checks the depth limit for a nested object or list, the returned
+// function must be called when leaving it.
+func (d *yamlDecoder) enter() (func(), error) {
+	d.depth++
+	if d.limits.MaxDepth > 0 && d.depth > d.limits.MaxDepth {
+		return nil, &LimitError{Kind: LimitDepth, Max: d.limits.MaxDepth}
+	}
+	return func() {
+		d.depth--
+	}, nil
+}
+
+// addKey checks the key limit for a value added to an object or list.
+func (d *yamlDecoder) addKey() error {
+	d.keys++
+	if d.limits.MaxKeys > 0 && d.keys > d.limits.MaxKeys {
+		return &LimitError{Kind: LimitKeys, Max: d.limits.MaxKeys}
+	}
+	return nil
+}
+
+func (d *yamlDecoder) decode(node *yaml.Node) (interface{}, error) {
+	d.decodeCount++
+	if d.aliasDepth > 0 {
+		d.aliasCount++
+	}
+	if d.aliasCount > 100 && d.decodeCount > 1000 && float64(d.aliasCount)/float64(d.decodeCount) > allowedAliasRatio(d.decodeCount) {
+		return nil, errExcessiveAliasing
+	}
+
+	switch node.Kind {
+	case yaml.DocumentNode:
+		if len(node.Content) == 0 {
+			return nil, nil
+		}
+		return d.decode(node.Content[0])
+	case yaml.AliasNode:
+		d.aliasDepth++
+		defer func() {
+			d.aliasDepth--
+		}()
+		return d.decode(node.Alias)
+	case yaml.SequenceNode:
+		leave, err := d.enter()
+		if err != nil {
+			return nil, err
+		}
+		defer leave()
+
+		result := make([]interface{}, 0, len(node.Content))
+		for _, item := range node.Content {
+			if err := d.addKey(); err != nil {
+				return nil, err
+			}
+			v, err := d.decode(item)
+			if err != nil {
+				return nil, err
+			}
+			result = append(result, v)
+		}
+		return result, nil
+	case yaml.MappingNode:
+		leave, err := d.enter()
+		if err != nil {
+			return nil, err
+		}
+		defer leave()
+
+		result := make(map[string]interface{}, len(node.Content)/2)
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			key, value := node.Content[i], node.Content[i+1]
+			if key.Tag == "!!merge" {
+				if err := d.merge(result, value); err != nil {
+					return nil, err
+				}
+				continue
+			}
+			if err := d.addKey(); err != nil {
+				return nil, err
+			}
+			v, err := d.decode(value)
+			if err != nil {
+				return nil, err
+			}
+			result[key.Value] = v
+		}
+		return result, nil
+	case yaml.ScalarNode:
+		return fromYAMLScalar(node)
+	}
+	return nil, fmt.Errorf("unsupported YAML node at line %d", node.Line)
+}
+
+// merge applies a "<<" merge key, keys already set take precedence.
+func (d *yamlDecoder) merge(result map[string]interface{}, node *yaml.Node) error {
+	sources := []*yaml.Node{node}
+	if node.Kind == yaml.SequenceNode {
+		sources = node.Content
+	}
+	for _, source := range sources {
+		v, err := d.decode(source)
+		if err != nil {
+			return err
+		}
+		m, ok := v.(map[string]interface{})
+		if !ok {
+			return fmt.Errorf("YAML merge at line %d is not an object", node.Line)
+		}
+		for k, v := range m {
+			if _, ok := result[k]; !ok {
+				if err := d.addKey(); err != nil {
+					return err
+				}
+				result[k] = v
+			}
+		}
+	}
+	return nil
+}
+
+func fromYAMLScalar(node *yaml.Node) (interface{}, error) {
+	switch node.ShortTag() {
+	case "!!null":
+		return nil, nil
+	case "!!int":
+		var i int64
+		if err := node.Decode(&i); err == nil {
+			return i, nil
+		}
+		n, ok := new(big.Int).SetString(node.Value, 0)
+		if !ok {
+			return nil, fmt.Errorf("invalid integer %q at line %d", node.Value, node.Line)
+		}
+		return json.Number(n.String()), nil
+	case "!!float":
+		if n, ok := new(big.Int).SetString(node.Value, 10); ok {
+			// an integer too large for int64
+			return json.Number(n.String()), nil
+		}
+		var f float64
+		if err := node.Decode(&f); err != nil {
+			return nil, err
+		}
+		return f, nil
+	case "!!bool":
+		var v bool
+		if err := node.Decode(&v); err != nil {
+			return nil, err
+		}
+		return v, nil
+	}
+	return node.Value, nil
+}
+
+// ToYAML encodes o as YAML with sorted keys. Values of type json.Number are
+// written as plain numbers.
+func (o Object) ToYAML() ([]byte, error) {
+	return encodeYAML(map[string]interface{}(o), nil)
+}
+
+func encodeYAML(v interface{}, order *KeyOrder) ([]byte, error) {
+	node, err := toYAMLNode(v, order)
+	if err != nil {
+		return nil, err
+	}
+
+	buf := &bytes.Buffer{}
+	enc := yaml.NewEncoder(buf)
+	enc.SetIndent(2)
+	if err := enc.Encode(node); err != nil {
+		return nil, err
+	}
+	if err := enc.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// toYAMLNode converts v to a YAML node, keys are written in the given order
+// and in sorted order if order is nil or doesn't know them.
+func toYAMLNode(v interface{}, order *KeyOrder) (*yaml.Node, error) {
+	if m, ok := asMap(v); ok {
+		node := &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map"}
+		for _, k := range order.keys(m) {
+			value, err := toYAMLNode(m[k], order.field(k))
+			if err != nil {
+				return nil, err
+			}
+			node.Content = append(node.Content, &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: k}, value)
+		}
+		return node, nil
+	}
+
+	switch t := v.(type) {
+	case nil, NullValue:
+		return &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!null", Value: "null"}, nil
+	case json.Number:
+		// without a tag the number is written as a plain scalar
+		return &yaml.Node{Kind: yaml.ScalarNode, Value: string(t)}, nil
+	case float64:
+		if !math.IsNaN(t) && !math.IsInf(t, 0) {
+			return &yaml.Node{Kind: yaml.ScalarNode, Value: convert.FormatFloat(t, 64)}, nil
+		}
+	case float32:
+		if f := float64(t); !math.IsNaN(f) && !math.IsInf(f, 0) {
+			return &yaml.Node{Kind: yaml.ScalarNode, Value: convert.FormatFloat(f, 32)}, nil
+		}
+	}
+
+	rv := reflect.ValueOf(v)
+	if rv.Kind() == reflect.Slice && rv.Type().Elem().Kind() != reflect.Uint8 {
+		node := &yaml.Node{Kind: yaml.SequenceNode, Tag: "!!seq"}
+		for i := 0; i < rv.Len(); i++ {
+			item, err := toYAMLNode(rv.Index(i).Interface(), order.item(i))
+			if err != nil {
+				return nil, err
+			}
+			node.Content = append(node.Content, item)
+		}
+		return node, nil
+	}
+
+	node := &yaml.Node{}
+	if err := node.Encode(v); err != nil {
+		return nil, err
+	}
+	return node, nil
+}
@@ -0,0 +1,46 @@
+package data
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+// aliasBomb returns a "billion laughs" document with the given number of
+// levels, every level aliases the previous one ten times.
+func aliasBomb(levels int) string {
+	buf := &strings.Builder{}
+	buf.WriteString("a0: &a0 [\"lol\"]\n")
+	for i := 1; i <= levels; i++ {
+		fmt.Fprintf(buf, "a%d: &a%d [*a%d%s]\n", i, i, i-1, strings.Repeat(fmt.Sprintf(", *a%d", i-1), 9))
+	}
+	return buf.String()
+}
+
+func TestFromYAMLAliasBomb(t *testing.T) {
+	start := time.Now()
+	_, err := FromYAML([]byte(aliasBomb(7)))
+	if !errors.Is(err, errExcessiveAliasing) {
+		t.Fatalf("expected excessive aliasing error, got %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("rejecting the document took %s", elapsed)
+	}
+}
+
+func TestFromYAMLAliases(t *testing.T) {
+	obj, err := FromYAML([]byte(aliasBomb(2) + "base: &base {a: 1, b: 2}\nmerged:\n  <<: *base\n  b: 3\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := len(obj["a2"].([]interface{})); got != 10 {
+		t.Errorf("expected 10 items, got %d", got)
+	}
+	expected := map[string]interface{}{"a": int64(1), "b": int64(3)}
+	if !reflect.DeepEqual(obj["merged"], expected) {
+		t.Errorf("expected %v, got %v", expected, obj["merged"])
+	}
+}
@@ -0,0 +1,111 @@
+package schemas
+
+import (
+	"maps"
+
+	"github.com/acorn-io/schemer/data"
+	"github.com/acorn-io/schemer/data/convert"
+	"github.com/acorn-io/schemer/definition"
+)
+
+type schemaDefaulter struct {
+	schema  *Schema
+	schemas *Schemas
+}
+
+// Defaulter returns a convert.Defaulter that sets the defaults of the
+// schema's fields, including the fields of nested schemas, for use with
+// convert.Decode. Pass the InternalSchema to default internal data.
+func (s *Schema) Defaulter(schemas *Schemas) convert.Defaulter {
+	return schemaDefaulter{
+		schema:  s,
+		schemas: schemas,
+	}
+}
+
+func (d schemaDefaulter) Default(obj map[string]interface{}) map[string]interface{} {
+	result, _ := d.apply(obj)
+	return result
+}
+
+// apply returns obj with defaults and true if any were set, obj is copied
+// before it's changed.
+func (d schemaDefaulter) apply(obj map[string]interface{}) (map[string]interface{}, bool) {
+	result := obj
+	changed := false
+	set := func(name string, value interface{}) {
+		if !changed {
+			result = maps.Clone(obj)
+			if result == nil {
+				result = map[string]interface{}{}
+			}
+			changed = true
+		}
+		result[name] = value
+	}
+
+	for name, field := range d.schema.ResourceFields {
+		value, ok := obj[name]
+		if value == nil {
+			// an explicit null is kept for nullable fields
+			if field.Default != nil && !(ok && field.IsNullable()) {
+				set(name, data.DeepCopyValue(field.Default))
+			}
+			continue
+		}
+		if value, ok := d.applyField(field.Type, value); ok {
+			set(name, value)
+		}
+	}
+	return result, changed
+}
+
+func (d schemaDefaulter) applyField(fieldType string, value interface{}) (interface{}, bool) {
+	fieldType = definition.NonNullableType(fieldType)
+	switch {
+	case definition.IsArrayType(fieldType):
+		items, ok := value.([]interface{})
+		if !ok {
+			return value, false
+		}
+		var result []interface{}
+		for i, item := range items {
+			item, ok := d.applyField(definition.SubType(fieldType), item)
+			if !ok {
+				continue
+			}
+			if result == nil {
+				result = append([]interface{}{}, items...)
+			}
+			result[i] = item
+		}
+		return result, result != nil
+	case definition.IsMapType(fieldType):
+		m, ok := value.(map[string]interface{})
+		if !ok {
+			return value, false
+		}
+		var result map[string]interface{}
+		for k, v := range m {
+			v, ok := d.applyField(definition.SubType(fieldType), v)
+			if !ok {
+				continue
+			}
+			if result == nil {
+				result = maps.Clone(m)
+			}
+			result[k] = v
+		}
+		return result, result != nil
+	}
+
+	m, ok := value.(map[string]interface{})
+	if !ok {
+		return value, false
+	}
+	schema := d.schemas.Schema(fieldType)
+	if schema == nil {
+		return value, false
+	}
+	return schemaDefaulter{schema: schema, schemas: d.schemas}.apply(m)
+}
@@ -0,0 +1,55 @@
+package definition
+
+import (
+	"strings"
+
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// Named returns the type with the given name, like "string" or the ID of a
+// schema.
+func Named(name string) *Type {
+	return &Type{Name: name}
+}
+
+// Array returns the type of arrays of elem.
+func Array(elem *Type) *Type {
+	return &Type{Name: arrayName, Elem: elem}
+}
+
+// Map returns the type of maps with values of type elem.
+func Map(elem *Type) *Type {
+	return &Type{Name: mapName, Elem: elem}
+}
+
+// Nullable returns the type of values of type elem or null.
+func Nullable(elem *Type) *Type {
+	return &Type{Name: nullableName, Elem: elem}
+}
+
+// Enum returns the type of strings that can have one of values.
+func Enum(values ...string) *Type {
+	return &Type{Name: enumName, Values: values}
+}
+
+// Ref returns the type of references to the schema with the given ID.
+func Ref(target string) *Type {
+	return &Type{Name: referenceName, Elem: Named(target)}
+}
+
+// QualifiedRef returns the type of references to a kind of another API
+// group, like "reference[apps/v1/deployment]".
+func QualifiedRef(gvk schema.GroupVersionKind) *Type {
+	parts := []string{gvk.Version, gvk.Kind}
+	if gvk.Group != "" {
+		parts = append([]string{gvk.Group}, parts...)
+	}
+	return Ref(strings.Join(parts, "/"))
+}
+
+// Validate returns an error if t can not be parsed from its string form,
+// for example because a name is empty or a type doesn't take a parameter.
+func (t *Type) Validate() error {
+	_, err := Parse(t.String())
+	return err
+}
@@ -0,0 +1,127 @@
+package definition
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strconv"
+)
+
+// Constraint restricts the values of a type, like "maxLength=63" in
+// "string{maxLength=63}".
+type Constraint struct {
+	Key   string
+	Value string
+}
+
+var (
+	constraintKey  = regexp.MustCompile(`^[a-zA-Z]+=`)
+	constraintKeys = []string{"min", "max", "minLength", "maxLength", "pattern"}
+)
+
+// checkConstraint validates the value of a constraint, the supported keys are
+// min, max, minLength, maxLength and pattern.
+func checkConstraint(c Constraint) error {
+	switch c.Key {
+	case "min", "max":
+		if _, err := strconv.ParseInt(c.Value, 10, 64); err != nil {
+			return fmt.Errorf("%s must be an integer", c.Key)
+		}
+	case "minLength", "maxLength":
+		if n, err := strconv.ParseInt(c.Value, 10, 64); err != nil || n < 0 {
+			return fmt.Errorf("%s must be a positive integer", c.Key)
+		}
+	case "pattern":
+		if _, err := regexp.Compile(c.Value); err != nil {
+			return fmt.Errorf("invalid pattern: %w", err)
+		}
+	default:
+		return fmt.Errorf("unknown constraint %s", c.Key)
+	}
+	return nil
+}
+
+// parseConstraints parses "{key=value,...}". A value ends at a comma that is
+// followed by the next key or at a closing brace at the end of the type or
+// before a closing bracket, so patterns can contain commas and braces.
+func (p *parser) parseConstraints() ([]Constraint, error) {
+	open := p.pos
+	p.pos++
+
+	var constraints []Constraint
+	for {
+		start := p.pos
+		key := constraintKey.FindString(p.s[p.pos:])
+		if key == "" {
+			return nil, p.errorf(p.pos, "expected key=value")
+		}
+		key = key[:len(key)-1]
+		p.pos += len(key) + 1
+
+		valueStart := p.pos
+		for {
+			if p.pos >= len(p.s) {
+				return nil, p.errorf(open, "missing } to close constraints")
+			}
+			if p.next(',') && constraintKey.MatchString(p.s[p.pos+1:]) {
+				break
+			}
+			if p.next('}') && (p.pos+1 == len(p.s) || p.s[p.pos+1] == ']') {
+				break
+			}
+			p.pos++
+		}
+
+		c := Constraint{Key: key, Value: p.s[valueStart:p.pos]}
+		if err := checkConstraint(c); err != nil {
+			parseErr := p.errorf(start, "%v", err)
+			if !slices.Contains(constraintKeys, key) {
+				parseErr.Suggestion = Suggest(key, constraintKeys)
+			}
+			return nil, parseErr
+		}
+		for _, existing := range constraints {
+			if existing.Key == key {
+				return nil, p.errorf(start, "duplicate constraint %s", key)
+			}
+		}
+		constraints = append(constraints, c)
+
+		if p.next('}') {
+			p.pos++
+			return constraints, nil
+		}
+		p.pos++
+	}
+}
+
+// Constraint returns the value of the constraint with the given key.
+func (t *Type) Constraint(key string) (string, bool) {
+	for _, c := range t.Constraints {
+		if c.Key == key {
+			return c.Value, true
+		}
+	}
+	return "", false
+}
+
+// WithConstraint returns a copy of t with the constraint set, like
+// Named("int").WithConstraint("min", "1") for "int{min=1}".
+func (t *Type) WithConstraint(key, value string) *Type {
+	result := *t
+	result.Constraints = nil
+	for _, c := range t.Constraints {
+		if c.Key != key {
+			result.Constraints = append(result.Constraints, c)
+		}
+	}
+	result.Constraints = append(result.Constraints, Constraint{Key: key, Value: value})
+	return &result
+}
+
+// WithoutConstraints returns a copy of t without constraints.
+func (t *Type) WithoutConstraints() *Type {
+	result := *t
+	result.Constraints = nil
+	return &result
+}
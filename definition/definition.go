@@ -16,6 +16,19 @@ func IsReferenceType(fieldType string) bool {
 	return strings.HasPrefix(fieldType, "reference[") && strings.HasSuffix(fieldType, "]")
 }
 
+func IsNullableType(fieldType string) bool {
+	return strings.HasPrefix(fieldType, "nullable[") && strings.HasSuffix(fieldType, "]")
+}
+
+// NonNullableType returns the type wrapped in "nullable[...]" or fieldType if
+// it isn't nullable.
+func NonNullableType(fieldType string) string {
+	if IsNullableType(fieldType) {
+		return SubType(fieldType)
+	}
+	return fieldType
+}
+
 func SubType(fieldType string) string {
 	i := strings.Index(fieldType, "[")
 	if i <= 0 || i >= len(fieldType)-1 {
@@ -0,0 +1,69 @@
+package definition
+
+import "fmt"
+
+// ParseError is returned by Parse for invalid types.
+type ParseError struct {
+	// Type is the type that failed to parse.
+	Type string
+	// Pos is the byte offset of the error in Type.
+	Pos int
+	// Msg describes the error.
+	Msg string
+	// Suggestion is the name that was probably meant if a name is
+	// misspelled.
+	Suggestion string
+}
+
+func (e *ParseError) Error() string {
+	msg := fmt.Sprintf("invalid type %q at position %d: %s", e.Type, e.Pos, e.Msg)
+	if e.Suggestion != "" {
+		msg += fmt.Sprintf(", did you mean %s?", e.Suggestion)
+	}
+	return msg
+}
+
+// Suggest returns the candidate closest to name if it is likely a
+// misspelling of it, like "array" for "aray", or an empty string otherwise.
+func Suggest(name string, candidates []string) string {
+	maxDistance := max(1, len(name)/4)
+	var (
+		best         string
+		bestDistance = maxDistance + 1
+	)
+	for _, candidate := range candidates {
+		if candidate == name {
+			continue
+		}
+		if d := distance(name, candidate); d < bestDistance {
+			best, bestDistance = candidate, d
+		}
+	}
+	return best
+}
+
+// distance is the number of insertions, deletions, substitutions and
+// transpositions of adjacent characters needed to turn a into b.
+func distance(a, b string) int {
+	prev2 := make([]int, len(b)+1)
+	prev := make([]int, len(b)+1)
+	cur := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		cur[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			cur[j] = min(prev[j]+1, cur[j-1]+1, prev[j-1]+cost)
+			if i > 1 && j > 1 && a[i-1] == b[j-2] && a[i-2] == b[j-1] {
+				cur[j] = min(cur[j], prev2[j-2]+1)
+			}
+		}
+		prev2, prev, cur = prev, cur, prev2
+	}
+	return prev[len(b)]
+}
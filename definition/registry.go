@@ -0,0 +1,159 @@
+package definition
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"sort"
+	"sync"
+	"sync/atomic"
+
+	v1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+)
+
+// Constructor defines a type that can be used in field types, like "array"
+// or "quantity".
+type Constructor struct {
+	// Name is the name of the type in field types.
+	Name string
+	// Elem is true if the type takes a type parameter, like "array[string]".
+	Elem bool
+	// Values is true if the type takes a list of values, like
+	// "enum[Active|Inactive]". The list is optional.
+	Values bool
+	// Check validates a parsed type, for example to restrict the parameter.
+	Check func(t *Type) error
+	// Convert converts and validates the value of a field of the type, see
+	// validation.ConvertSimple.
+	Convert func(t *Type, value interface{}) (interface{}, error)
+	// OpenAPI returns the OpenAPI schema of the type. elem is the schema of
+	// the parameter of types that have one.
+	OpenAPI func(t *Type, elem *v1.JSONSchemaProps) (*v1.JSONSchemaProps, error)
+}
+
+var (
+	constructorsLock sync.Mutex
+	constructors     atomic.Pointer[map[string]Constructor]
+)
+
+func init() {
+	for _, name := range []string{arrayName, mapName, referenceName} {
+		Register(Constructor{Name: name, Elem: true})
+	}
+	Register(Constructor{
+		Name: nullableName,
+		Elem: true,
+		Check: func(t *Type) error {
+			if t.Elem.IsNullable() {
+				return errors.New("type is already nullable")
+			}
+			return nil
+		},
+		OpenAPI: func(t *Type, elem *v1.JSONSchemaProps) (*v1.JSONSchemaProps, error) {
+			result := *elem
+			result.Nullable = true
+			return &result, nil
+		},
+	})
+	Register(Constructor{
+		Name:   enumName,
+		Values: true,
+		OpenAPI: func(t *Type, elem *v1.JSONSchemaProps) (*v1.JSONSchemaProps, error) {
+			result := &v1.JSONSchemaProps{Type: "string"}
+			for _, value := range t.Values {
+				raw, err := json.Marshal(value)
+				if err != nil {
+					return nil, err
+				}
+				result.Enum = append(result.Enum, v1.JSON{Raw: raw})
+			}
+			return result, nil
+		},
+	})
+	for _, name := range []string{
+		"base64",
+		"boolean",
+		"date",
+		"dnsLabel",
+		"dnsLabelRestricted",
+		"float",
+		"hostname",
+		"int",
+		"intOrString",
+		"json",
+		"password",
+		"quantity",
+		"string",
+	} {
+		Register(Constructor{Name: name})
+	}
+}
+
+// Register adds a type to the grammar of field types. Registering a name
+// again replaces the type. The builtin types, like "array" and "string", are
+// handled by the packages of this module and only need Convert and OpenAPI
+// if they are replaced.
+func Register(c Constructor) {
+	if !isName(c.Name) {
+		panic(fmt.Sprintf("invalid type name %q", c.Name))
+	}
+
+	constructorsLock.Lock()
+	defer constructorsLock.Unlock()
+
+	next := map[string]Constructor{}
+	if current := constructors.Load(); current != nil {
+		maps.Copy(next, *current)
+	}
+	next[c.Name] = c
+	constructors.Store(&next)
+}
+
+// Lookup returns the registered type with the given name.
+func Lookup(name string) (Constructor, bool) {
+	current := constructors.Load()
+	if current == nil {
+		return Constructor{}, false
+	}
+	c, ok := (*current)[name]
+	return c, ok
+}
+
+// Names returns the sorted names of the registered types.
+func Names() []string {
+	current := constructors.Load()
+	if current == nil {
+		return nil
+	}
+	names := make([]string, 0, len(*current))
+	for name := range *current {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// constructorNames returns the sorted names of the registered types that
+// take a type parameter or values.
+func constructorNames() []string {
+	var names []string
+	for _, name := range Names() {
+		if c, _ := Lookup(name); c.Elem || c.Values {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+func isName(name string) bool {
+	if name == "" {
+		return false
+	}
+	for i := 0; i < len(name); i++ {
+		if !isNameChar(name[i]) || name[i] == '/' {
+			return false
+		}
+	}
+	return true
+}
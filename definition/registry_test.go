@@ -0,0 +1,32 @@
+package definition
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestRegister(t *testing.T) {
+	Register(Constructor{
+		Name: "secret",
+		Elem: true,
+		Check: func(t *Type) error {
+			if t.Elem.Name != "string" {
+				return errors.New("secrets can only hold strings")
+			}
+			return nil
+		},
+	})
+
+	if _, err := Parse("map[secret[string]]"); err != nil {
+		t.Fatal(err)
+	}
+	_, err := Parse("map[secret[int]]")
+	var parseErr *ParseError
+	if !errors.As(err, &parseErr) || parseErr.Pos != 4 {
+		t.Fatalf("unexpected error %v", err)
+	}
+	_, err = Parse("secrt[string]")
+	if !errors.As(err, &parseErr) || parseErr.Suggestion != "secret" {
+		t.Fatalf("unexpected error %v", err)
+	}
+}
@@ -0,0 +1,312 @@
+package definition
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/validation"
+)
+
+const (
+	arrayName     = "array"
+	mapName       = "map"
+	referenceName = "reference"
+	nullableName  = "nullable"
+	enumName      = "enum"
+)
+
+// Type is a parsed field type. Types with a parameter, like
+// "map[array[reference[project]]]", have the parameter in Elem.
+type Type struct {
+	// Name is "array", "map" or "reference" for types with a parameter and
+	// the name of the type, like "string" or "project", otherwise.
+	Name string
+	// Elem is the type of the values of arrays and maps and the target of
+	// references.
+	Elem *Type
+	// Values are the values of types like "enum[Active|Inactive]".
+	Values []string
+	// Constraints are the constraints of types like "int{min=1,max=65535}"
+	// in the order they were written.
+	Constraints []Constraint
+}
+
+// Parse parses a field type like "array[string]". Arrays, maps and
+// references can be nested in each other to any depth. The target of a
+// reference can be qualified with an API group and version, like
+// "reference[apps/v1/deployment]" or "reference[v1/configMap]" for the core
+// group.
+func Parse(fieldType string) (*Type, error) {
+	p := &parser{s: fieldType}
+	t, err := p.parseType("")
+	if err != nil {
+		return nil, err
+	}
+	if p.pos < len(p.s) {
+		return nil, p.errorf(p.pos, "unexpected %q", p.s[p.pos:])
+	}
+	return t, nil
+}
+
+type parser struct {
+	s   string
+	pos int
+}
+
+func (p *parser) errorf(pos int, format string, args ...interface{}) *ParseError {
+	return &ParseError{
+		Type: p.s,
+		Pos:  pos,
+		Msg:  fmt.Sprintf(format, args...),
+	}
+}
+
+func (p *parser) next(c byte) bool {
+	return p.pos < len(p.s) && p.s[p.pos] == c
+}
+
+func (p *parser) parseType(parent string) (*Type, error) {
+	start := p.pos
+	t, err := p.parseBase(parent)
+	if err != nil {
+		return nil, err
+	}
+	if p.next('{') {
+		t.Constraints, err = p.parseConstraints()
+		if err != nil {
+			return nil, err
+		}
+	}
+	return t, p.check(t, start)
+}
+
+// parseBase parses a type without its constraints.
+func (p *parser) parseBase(parent string) (*Type, error) {
+	start := p.pos
+	for p.pos < len(p.s) && isNameChar(p.s[p.pos]) {
+		p.pos++
+	}
+	name := p.s[start:p.pos]
+	if name == "" {
+		if p.pos < len(p.s) && !p.next('[') && !p.next(']') {
+			return nil, p.errorf(p.pos, "unexpected %q", p.s[p.pos])
+		}
+		return nil, p.errorf(p.pos, "missing type name")
+	}
+	if strings.Contains(name, "/") {
+		if parent != referenceName {
+			return nil, p.errorf(start, "only the target of a reference can have a group and version")
+		}
+		if err := validateQualifiedName(name); err != nil {
+			return nil, p.errorf(start, "%v", err)
+		}
+	}
+
+	t := &Type{Name: name}
+	c, _ := Lookup(name)
+	if !p.next('[') {
+		if c.Elem {
+			return nil, p.errorf(p.pos, "%s requires a type parameter, like %s[string]", name, name)
+		}
+		return t, nil
+	}
+	if c.Values {
+		p.pos++
+		values, err := p.parseValues(name)
+		if err != nil {
+			return nil, err
+		}
+		t.Values = values
+		return t, nil
+	}
+	if !c.Elem {
+		err := p.errorf(start, "%s does not take a type parameter", name)
+		err.Suggestion = Suggest(name, constructorNames())
+		return nil, err
+	}
+	p.pos++
+
+	elem, err := p.parseType(name)
+	if err != nil {
+		return nil, err
+	}
+	if !p.next(']') {
+		return nil, p.errorf(p.pos, "missing ] to close %s[", name)
+	}
+	p.pos++
+	t.Elem = elem
+	return t, nil
+}
+
+// parseValues parses the values of types like "enum[a|b]" up to the closing
+// bracket.
+func (p *parser) parseValues(name string) ([]string, error) {
+	var (
+		values []string
+		start  = p.pos
+	)
+	for {
+		if p.pos >= len(p.s) {
+			return nil, p.errorf(p.pos, "missing ] to close %s[", name)
+		}
+		c := p.s[p.pos]
+		if c != '|' && c != ']' {
+			if c == '[' {
+				return nil, p.errorf(p.pos, "unexpected %q", c)
+			}
+			p.pos++
+			continue
+		}
+
+		value := p.s[start:p.pos]
+		if value == "" {
+			return nil, p.errorf(p.pos, "missing value")
+		}
+		if slices.Contains(values, value) {
+			return nil, p.errorf(start, "duplicate value %s", value)
+		}
+		values = append(values, value)
+		p.pos++
+		if c == ']' {
+			return values, nil
+		}
+		start = p.pos
+	}
+}
+
+// check calls the Check function of the registered type of t.
+func (p *parser) check(t *Type, start int) error {
+	c, ok := Lookup(t.Name)
+	if !ok || c.Check == nil {
+		return nil
+	}
+	if err := c.Check(t); err != nil {
+		return p.errorf(start, "%v", err)
+	}
+	return nil
+}
+
+func isNameChar(c byte) bool {
+	return c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9' ||
+		c == '_' || c == '-' || c == '.' || c == '/'
+}
+
+func validateQualifiedName(name string) error {
+	parts := strings.Split(name, "/")
+	if len(parts) > 3 {
+		return fmt.Errorf("%s: expected group/version/kind", name)
+	}
+	if parts[len(parts)-1] == "" {
+		return fmt.Errorf("%s: missing kind", name)
+	}
+	if errs := validation.IsDNS1035Label(parts[len(parts)-2]); len(errs) > 0 {
+		return fmt.Errorf("%s: invalid version: %s", name, strings.Join(errs, ", "))
+	}
+	if len(parts) == 3 {
+		if errs := validation.IsDNS1123Subdomain(parts[0]); len(errs) > 0 {
+			return fmt.Errorf("%s: invalid group: %s", name, strings.Join(errs, ", "))
+		}
+	}
+	return nil
+}
+
+// hasElem returns true if t is a registered type with a parameter.
+func (t *Type) hasElem() bool {
+	c, ok := Lookup(t.Name)
+	return ok && c.Elem
+}
+
+func (t *Type) IsArray() bool {
+	return t.Name == arrayName
+}
+
+func (t *Type) IsMap() bool {
+	return t.Name == mapName
+}
+
+func (t *Type) IsReference() bool {
+	return t.Name == referenceName
+}
+
+// GroupVersionKind returns the target of a reference. Group and version are
+// only set if the reference is qualified.
+func (t *Type) GroupVersionKind() schema.GroupVersionKind {
+	if !t.IsReference() || t.Elem == nil {
+		return schema.GroupVersionKind{}
+	}
+	parts := strings.Split(t.Elem.Name, "/")
+	switch len(parts) {
+	case 3:
+		return schema.GroupVersionKind{Group: parts[0], Version: parts[1], Kind: parts[2]}
+	case 2:
+		return schema.GroupVersionKind{Version: parts[0], Kind: parts[1]}
+	}
+	return schema.GroupVersionKind{Kind: t.Elem.Name}
+}
+
+// IsQualified returns true for references with an API group and version.
+func (t *Type) IsQualified() bool {
+	return t.GroupVersionKind().Version != ""
+}
+
+// Target returns the schema ID of the target of a reference, which is the
+// kind for qualified references.
+func (t *Type) Target() string {
+	return t.GroupVersionKind().Kind
+}
+
+// IsNullable returns true for types like "nullable[string]" whose values may
+// be null.
+func (t *Type) IsNullable() bool {
+	return t.Name == nullableName
+}
+
+// NonNullable returns the type wrapped by nullable types and t otherwise.
+func (t *Type) NonNullable() *Type {
+	if t.IsNullable() {
+		return t.Elem
+	}
+	return t
+}
+
+// IsContainer returns true for arrays and maps.
+func (t *Type) IsContainer() bool {
+	return t.IsArray() || t.IsMap()
+}
+
+// Unwrap returns the type of the values nested in arrays and maps, like
+// "reference[project]" for "map[array[reference[project]]]", and the
+// containers from the outermost to the innermost. Nullable types are
+// skipped.
+func (t *Type) Unwrap() (*Type, []*Type) {
+	var containers []*Type
+	t = t.NonNullable()
+	for t.IsContainer() {
+		containers = append(containers, t)
+		t = t.Elem.NonNullable()
+	}
+	return t, containers
+}
+
+func (t *Type) String() string {
+	var b strings.Builder
+	b.WriteString(t.Name)
+	if len(t.Values) > 0 {
+		b.WriteString("[" + strings.Join(t.Values, "|") + "]")
+	} else if t.Elem != nil {
+		b.WriteString("[" + t.Elem.String() + "]")
+	}
+	if len(t.Constraints) > 0 {
+		b.WriteString("{")
+		for i, c := range t.Constraints {
+			if i > 0 {
+				b.WriteString(",")
+			}
+			b.WriteString(c.Key + "=" + c.Value)
+		}
+		b.WriteString("}")
+	}
+	return b.String()
+}
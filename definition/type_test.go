@@ -0,0 +1,118 @@
+package definition
+
+import (
+	"errors"
+	"testing"
+
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+func TestParse(t *testing.T) {
+	for _, fieldType := range []string{
+		"string",
+		"array[string]",
+		"map[array[reference[project]]]",
+		"array[map[array[json]]]",
+		"reference[apps/v1/deployment]",
+		"array[reference[v1/configMap]]",
+		"nullable[map[nullable[string]]]",
+		"array[enum[Active|Inactive|Error]]",
+		"enum",
+		"string{maxLength=63,pattern=^[a-z0-9-]{1,3}$}",
+		"array[int{min=1,max=65535}]",
+		"nullable[enum[a|b]{minLength=1}]",
+	} {
+		parsed, err := Parse(fieldType)
+		if err != nil {
+			t.Fatalf("%s: %v", fieldType, err)
+		}
+		if parsed.String() != fieldType {
+			t.Fatalf("expected %s, got %s", fieldType, parsed)
+		}
+	}
+
+	parsed, _ := Parse("map[array[reference[project]]]")
+	elem, containers := parsed.Unwrap()
+	if !elem.IsReference() || elem.Elem.Name != "project" || len(containers) != 2 ||
+		!containers[0].IsMap() || !containers[1].IsArray() {
+		t.Fatalf("unexpected result %s %v", elem, containers)
+	}
+
+	for _, fieldType := range []string{
+		"",
+		"array",
+		"string[int]",
+		"array[string",
+		"array[string]]",
+		"map[]",
+		"apps/v1/deployment",
+		"reference[a/b/c/d]",
+		"reference[apps/V1/deployment]",
+		"reference[apps/v1/]",
+		"nullable[nullable[string]]",
+		"enum[a||b]",
+		"enum[a|a]",
+		"enum[a|b",
+		"string{maxLen=3}",
+		"int{min=a}",
+		"string{pattern=[}",
+		"string{maxLength=3",
+	} {
+		if _, err := Parse(fieldType); err == nil {
+			t.Fatalf("expected error for %q", fieldType)
+		}
+	}
+
+	parsed, _ = Parse("nullable[array[nullable[reference[project]]]]")
+	elem, containers = parsed.Unwrap()
+	if !elem.IsReference() || len(containers) != 1 || !parsed.IsNullable() {
+		t.Fatalf("unexpected result %s %v", elem, containers)
+	}
+
+	parsed, _ = Parse("reference[apps/v1/deployment]")
+	if gvk := parsed.GroupVersionKind(); gvk.Group != "apps" || gvk.Version != "v1" || gvk.Kind != "deployment" || !parsed.IsQualified() {
+		t.Fatalf("unexpected group, version and kind %v", gvk)
+	}
+	parsed, _ = Parse("reference[project]")
+	if parsed.IsQualified() || parsed.Target() != "project" {
+		t.Fatalf("unexpected target %s", parsed.Target())
+	}
+
+	_, err := Parse("map[aray[string]]")
+	var parseErr *ParseError
+	if !errors.As(err, &parseErr) || parseErr.Pos != 4 || parseErr.Suggestion != "array" {
+		t.Fatalf("unexpected error %v", err)
+	}
+}
+
+func TestBuilders(t *testing.T) {
+	typ := Array(Map(Ref("project")))
+	if typ.String() != "array[map[reference[project]]]" {
+		t.Fatalf("unexpected type %s", typ)
+	}
+	if err := typ.Validate(); err != nil {
+		t.Fatal(err)
+	}
+
+	typ = Map(QualifiedRef(schema.GroupVersionKind{Version: "v1", Kind: "configMap"}))
+	if typ.String() != "map[reference[v1/configMap]]" {
+		t.Fatalf("unexpected type %s", typ)
+	}
+
+	typ = Nullable(Enum("a", "b"))
+	if typ.String() != "nullable[enum[a|b]]" {
+		t.Fatalf("unexpected type %s", typ)
+	}
+
+	typ = Named("int").WithConstraint("min", "1").WithConstraint("max", "10")
+	if typ.String() != "int{min=1,max=10}" {
+		t.Fatalf("unexpected type %s", typ)
+	}
+	if max, _ := typ.Constraint("max"); max != "10" {
+		t.Fatalf("unexpected max %s", max)
+	}
+
+	if err := Array(Named("string[int]")).Validate(); err == nil {
+		t.Fatal("expected error for invalid name")
+	}
+}
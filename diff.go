@@ -0,0 +1,107 @@
+package schemas
+
+import (
+	"sort"
+)
+
+type Compatibility string
+
+const (
+	// FullyCompatible changes are both backward and forward compatible.
+	FullyCompatible Compatibility = "full"
+	// BackwardCompatible changes allow the new schema to read data written
+	// with the old schema.
+	BackwardCompatible Compatibility = "backward"
+	// ForwardCompatible changes allow the old schema to read data written
+	// with the new schema.
+	ForwardCompatible Compatibility = "forward"
+	Breaking          Compatibility = "breaking"
+)
+
+type FieldChange struct {
+	Name    string
+	OldType string
+	NewType string
+}
+
+type SchemaDiff struct {
+	Added         []string
+	Removed       []string
+	Retyped       []FieldChange
+	Compatibility Compatibility
+}
+
+func (d *SchemaDiff) Empty() bool {
+	return len(d.Added) == 0 && len(d.Removed) == 0 && len(d.Retyped) == 0
+}
+
+// Diff compares the resource fields of two versions of a schema.
+func Diff(old, new *Schema) *SchemaDiff {
+	var (
+		result   = &SchemaDiff{}
+		backward = true
+		forward  = true
+	)
+
+	for name, newField := range new.ResourceFields {
+		oldField, ok := old.ResourceFields[name]
+		if !ok {
+			result.Added = append(result.Added, name)
+			if requiredWithoutDefault(newField) {
+				backward = false
+			}
+			continue
+		}
+
+		if oldField.Type != newField.Type {
+			result.Retyped = append(result.Retyped, FieldChange{
+				Name:    name,
+				OldType: oldField.Type,
+				NewType: newField.Type,
+			})
+			backward = false
+			forward = false
+			continue
+		}
+
+		if requiredWithoutDefault(newField) && !requiredWithoutDefault(oldField) {
+			backward = false
+		}
+		if requiredWithoutDefault(oldField) && !requiredWithoutDefault(newField) {
+			forward = false
+		}
+	}
+
+	for name, oldField := range old.ResourceFields {
+		if _, ok := new.ResourceFields[name]; ok {
+			continue
+		}
+		result.Removed = append(result.Removed, name)
+		if requiredWithoutDefault(oldField) {
+			forward = false
+		}
+	}
+
+	sort.Strings(result.Added)
+	sort.Strings(result.Removed)
+	sort.Slice(result.Retyped, func(i, j int) bool {
+		return result.Retyped[i].Name < result.Retyped[j].Name
+	})
+
+	switch {
+	case backward && forward:
+		result.Compatibility = FullyCompatible
+	case backward:
+		result.Compatibility = BackwardCompatible
+	case forward:
+		result.Compatibility = ForwardCompatible
+	default:
+		result.Compatibility = Breaking
+	}
+
+	return result
+}
+
+func requiredWithoutDefault(f Field) bool {
+	return f.Required && f.Default == nil
+}
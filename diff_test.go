@@ -0,0 +1,88 @@
+package schemas
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestDiff(t *testing.T) {
+	base := map[string]Field{
+		"name":     {Type: "string", Required: true},
+		"replicas": {Type: "int"},
+	}
+	withField := func(name string, field Field) *Schema {
+		fields := map[string]Field{}
+		for k, v := range base {
+			fields[k] = v
+		}
+		fields[name] = field
+		return &Schema{ResourceFields: fields}
+	}
+	without := func(name string) *Schema {
+		fields := map[string]Field{}
+		for k, v := range base {
+			if k != name {
+				fields[k] = v
+			}
+		}
+		return &Schema{ResourceFields: fields}
+	}
+	old := &Schema{ResourceFields: base}
+
+	tests := []struct {
+		name     string
+		new      *Schema
+		expected SchemaDiff
+	}{
+		{
+			name:     "unchanged",
+			new:      old,
+			expected: SchemaDiff{Compatibility: FullyCompatible},
+		},
+		{
+			name:     "optional field added",
+			new:      withField("image", Field{Type: "string"}),
+			expected: SchemaDiff{Added: []string{"image"}, Compatibility: FullyCompatible},
+		},
+		{
+			name:     "required field added",
+			new:      withField("image", Field{Type: "string", Required: true}),
+			expected: SchemaDiff{Added: []string{"image"}, Compatibility: ForwardCompatible},
+		},
+		{
+			name:     "required field with default added",
+			new:      withField("image", Field{Type: "string", Required: true, Default: "nginx"}),
+			expected: SchemaDiff{Added: []string{"image"}, Compatibility: FullyCompatible},
+		},
+		{
+			name:     "required field removed",
+			new:      without("name"),
+			expected: SchemaDiff{Removed: []string{"name"}, Compatibility: BackwardCompatible},
+		},
+		{
+			name: "field retyped",
+			new:  withField("replicas", Field{Type: "string"}),
+			expected: SchemaDiff{
+				Retyped:       []FieldChange{{Name: "replicas", OldType: "int", NewType: "string"}},
+				Compatibility: Breaking,
+			},
+		},
+		{
+			name:     "field made required",
+			new:      withField("replicas", Field{Type: "int", Required: true}),
+			expected: SchemaDiff{Compatibility: ForwardCompatible},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			diff := Diff(old, tt.new)
+			if !reflect.DeepEqual(*diff, tt.expected) {
+				t.Fatalf("expected %+v, got %+v", tt.expected, *diff)
+			}
+			if diff.Empty() != (len(tt.expected.Added)+len(tt.expected.Removed)+len(tt.expected.Retyped) == 0) {
+				t.Fatalf("unexpected Empty() %v", diff.Empty())
+			}
+		})
+	}
+}
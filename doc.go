@@ -0,0 +1,138 @@
+package schemas
+
+import (
+	"go/ast"
+	"go/build"
+	"go/parser"
+	"go/token"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/sirupsen/logrus"
+)
+
+type packageDocs struct {
+	types  map[string]string
+	fields map[string]string
+}
+
+// docsKey identifies the source of a package, dir is empty if it's resolved
+// with go/build.
+type docsKey struct {
+	pkgPath string
+	dir     string
+}
+
+var (
+	docsLock  sync.Mutex
+	docsCache = map[docsKey]*packageDocs{}
+)
+
+// loadPackageDocs parses the source of the package to collect the doc comments
+// of its types and struct fields. The source is looked up in sourceDirs first
+// and then resolved with go/build. Failures are logged and result in no
+// descriptions since the source is often not available at runtime, they are
+// not cached so the source is looked up again on the next import.
+func loadPackageDocs(pkgPath string, sourceDirs map[string]string) *packageDocs {
+	docsLock.Lock()
+	defer docsLock.Unlock()
+
+	dir, ok := sourceDirs[pkgPath]
+	key := docsKey{pkgPath: pkgPath, dir: dir}
+	if docs, ok := docsCache[key]; ok {
+		return docs
+	}
+
+	docs := &packageDocs{
+		types:  map[string]string{},
+		fields: map[string]string{},
+	}
+
+	var files []string
+	if ok {
+		matches, err := filepath.Glob(filepath.Join(dir, "*.go"))
+		if err != nil {
+			logrus.Debugf("Failed to list source of package %s: %v", pkgPath, err)
+			return docs
+		}
+		for _, match := range matches {
+			if !strings.HasSuffix(match, "_test.go") {
+				files = append(files, match)
+			}
+		}
+	} else {
+		pkg, err := build.Default.Import(pkgPath, ".", 0)
+		if err != nil {
+			logrus.Debugf("Failed to find source of package %s: %v", pkgPath, err)
+			return docs
+		}
+		for _, file := range pkg.GoFiles {
+			files = append(files, filepath.Join(pkg.Dir, file))
+		}
+	}
+
+	failed := false
+	fset := token.NewFileSet()
+	for _, file := range files {
+		f, err := parser.ParseFile(fset, file, nil, parser.ParseComments)
+		if err != nil {
+			logrus.Debugf("Failed to parse %s: %v", file, err)
+			failed = true
+			continue
+		}
+		docs.add(f)
+	}
+
+	if !failed {
+		docsCache[key] = docs
+	}
+	return docs
+}
+
+func (p *packageDocs) add(f *ast.File) {
+	for _, decl := range f.Decls {
+		gen, ok := decl.(*ast.GenDecl)
+		if !ok || gen.Tok != token.TYPE {
+			continue
+		}
+		for _, spec := range gen.Specs {
+			typeSpec, ok := spec.(*ast.TypeSpec)
+			if !ok {
+				continue
+			}
+
+			doc := typeSpec.Doc
+			if doc == nil && len(gen.Specs) == 1 {
+				doc = gen.Doc
+			}
+			if text := commentText(doc); text != "" {
+				p.types[typeSpec.Name.Name] = text
+			}
+
+			structType, ok := typeSpec.Type.(*ast.StructType)
+			if !ok {
+				continue
+			}
+			for _, field := range structType.Fields.List {
+				text := commentText(field.Doc)
+				if text == "" {
+					text = commentText(field.Comment)
+				}
+				if text == "" {
+					continue
+				}
+				for _, name := range field.Names {
+					p.fields[typeSpec.Name.Name+"."+name.Name] = text
+				}
+			}
+		}
+	}
+}
+
+func commentText(c *ast.CommentGroup) string {
+	if c == nil {
+		return ""
+	}
+	return strings.TrimSpace(c.Text())
+}
@@ -0,0 +1,67 @@
+package schemas
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+type docApp struct {
+	Name string `json:"name"`
+}
+
+func writeDocSource(t *testing.T, comment string) string {
+	dir := t.TempDir()
+	source := "package schemas\n\n// " + comment + "\ntype docApp struct {\n\t// The name.\n\tName string\n}\n"
+	if err := os.WriteFile(filepath.Join(dir, "doc.go"), []byte(source), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	return dir
+}
+
+func TestImportDescriptionsSourceDirs(t *testing.T) {
+	pkgPath := "github.com/acorn-io/schemer"
+	for _, comment := range []string{"First description.", "Second description."} {
+		dir := writeDocSource(t, comment)
+		schema, err := EmptySchemas().ImportWithOptions(docApp{}, ImportOptions{
+			Descriptions: true,
+			SourceDirs:   map[string]string{pkgPath: dir},
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if schema.Description != comment {
+			t.Errorf("expected description %q, got %q", comment, schema.Description)
+		}
+		if d := schema.ResourceFields["name"].Description; d != "The name." {
+			t.Errorf("expected field description, got %q", d)
+		}
+	}
+}
+
+func TestImportDescriptionsFailureNotCached(t *testing.T) {
+	pkgPath := "github.com/acorn-io/schemer"
+	dir := t.TempDir()
+	broken := filepath.Join(dir, "doc.go")
+	if err := os.WriteFile(broken, []byte("package schemas\n\ntype docApp struct {"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	opts := ImportOptions{
+		Descriptions: true,
+		SourceDirs:   map[string]string{pkgPath: dir},
+	}
+	if _, err := EmptySchemas().ImportWithOptions(docApp{}, opts); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := os.WriteFile(broken, []byte("package schemas\n\n// Fixed.\ntype docApp struct{}\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	schema, err := EmptySchemas().ImportWithOptions(docApp{}, opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if schema.Description != "Fixed." {
+		t.Errorf("expected the fixed source to be read, got %q", schema.Description)
+	}
+}
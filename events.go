@@ -0,0 +1,111 @@
+package schemas
+
+import (
+	"context"
+	"slices"
+	"sync"
+)
+
+type EventType int
+
+const (
+	SchemaAdded EventType = iota
+	SchemaReplaced
+	SchemaRemoved
+	MappersModified
+)
+
+func (e EventType) String() string {
+	switch e {
+	case SchemaAdded:
+		return "added"
+	case SchemaReplaced:
+		return "replaced"
+	case SchemaRemoved:
+		return "removed"
+	case MappersModified:
+		return "mappersModified"
+	}
+	return "unknown"
+}
+
+// Event describes a change to the registry. Schema is nil for
+// MappersModified events, the new mappers only take effect once the schema
+// is imported or added again.
+type Event struct {
+	Type     EventType
+	SchemaID string
+	Schema   *Schema
+}
+
+type subscriber struct {
+	lock   sync.Mutex
+	queue  []Event
+	notify chan struct{}
+}
+
+// Subscribe returns a channel that receives every change made to the registry
+// after the call, in the order the changes were made. Events are queued per
+// subscriber, so a slow reader never blocks the registry. The channel is
+// closed once ctx is done.
+func (s *Schemas) Subscribe(ctx context.Context) <-chan Event {
+	sub := &subscriber{
+		notify: make(chan struct{}, 1),
+	}
+
+	s.Lock()
+	s.subscribers = append(s.subscribers, sub)
+	s.Unlock()
+
+	result := make(chan Event)
+	go func() {
+		defer close(result)
+		defer s.unsubscribe(sub)
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-sub.notify:
+			}
+
+			sub.lock.Lock()
+			events := sub.queue
+			sub.queue = nil
+			sub.lock.Unlock()
+
+			for _, event := range events {
+				select {
+				case <-ctx.Done():
+					return
+				case result <- event:
+				}
+			}
+		}
+	}()
+
+	return result
+}
+
+func (s *Schemas) unsubscribe(sub *subscriber) {
+	s.Lock()
+	defer s.Unlock()
+	s.subscribers = slices.DeleteFunc(s.subscribers, func(other *subscriber) bool {
+		return other == sub
+	})
+}
+
+// publish queues the event for all subscribers, the caller must hold the
+// registry lock so events are queued in the order they happened.
+func (s *Schemas) publish(event Event) {
+	for _, sub := range s.subscribers {
+		sub.lock.Lock()
+		sub.queue = append(sub.queue, event)
+		sub.lock.Unlock()
+
+		select {
+		case sub.notify <- struct{}{}:
+		default:
+		}
+	}
+}
@@ -0,0 +1,60 @@
+package schemas
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func receive(t *testing.T, events <-chan Event) Event {
+	t.Helper()
+	select {
+	case event := <-events:
+		return event
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for event")
+	}
+	return Event{}
+}
+
+func TestSubscribe(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	s := EmptySchemas()
+	s.MustAddSchema(Schema{ID: "before"})
+	events := s.Subscribe(ctx)
+
+	// the events are queued, so the changes don't wait for the reader
+	s.MustAddSchema(Schema{ID: "app"})
+	s.MustAddSchema(Schema{ID: "app", Description: "replaced"})
+	s.AddMapper("app", benchNoop{})
+	s.RemoveSchema(Schema{ID: "app"})
+
+	var received []string
+	for i := 0; i < 4; i++ {
+		event := receive(t, events)
+		received = append(received, event.Type.String()+" "+event.SchemaID)
+		if event.Type == SchemaReplaced && event.Schema.Description != "replaced" {
+			t.Errorf("expected the new schema in the replaced event, got %+v", event.Schema)
+		}
+		if event.Type == MappersModified && event.Schema != nil {
+			t.Errorf("expected no schema in the mappersModified event, got %+v", event.Schema)
+		}
+	}
+	expected := []string{"added app", "replaced app", "mappersModified app", "removed app"}
+	if !reflect.DeepEqual(received, expected) {
+		t.Fatalf("expected %v, got %v", expected, received)
+	}
+
+	cancel()
+	for range events {
+	}
+	s.Lock()
+	subscribers := len(s.subscribers)
+	s.Unlock()
+	if subscribers != 0 {
+		t.Fatalf("expected the subscriber to be removed, got %d", subscribers)
+	}
+}
@@ -0,0 +1,95 @@
+package schemas
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/acorn-io/schemer/data"
+	"github.com/acorn-io/schemer/data/convert"
+)
+
+// enumField is a field of type "enum[a|b]", or arrays and maps of it, whose
+// values are checked in ToInternal.
+type enumField struct {
+	values     []string
+	containers []fieldKind
+}
+
+// enumValues returns the values of fieldType if its values are enums with
+// inline values.
+func enumValues(fieldType string) ([]string, []fieldKind, bool) {
+	elem, containers, err := parseFieldType(fieldType)
+	if err != nil || elem.Name != "enum" || len(elem.Values) == 0 {
+		return nil, nil, false
+	}
+	return elem.Values, containers, true
+}
+
+// setTypeOptions copies the values of types like "enum[a|b]" to the options
+// and the constraints of types like "string{maxLength=63}" to the fields,
+// unless the fields already set them.
+func setTypeOptions(fields map[string]Field) {
+	for name, field := range fields {
+		elem, containers, err := parseFieldType(field.Type)
+		if err != nil || len(containers) > 0 {
+			continue
+		}
+		if elem.Name == "enum" && len(field.Options) == 0 {
+			field.Options = slices.Clone(elem.Values)
+		}
+		for _, c := range elem.Constraints {
+			switch c.Key {
+			case "min":
+				field.Min = constraintInt(field.Min, c.Value)
+			case "max":
+				field.Max = constraintInt(field.Max, c.Value)
+			case "minLength":
+				field.MinLength = constraintInt(field.MinLength, c.Value)
+			case "maxLength":
+				field.MaxLength = constraintInt(field.MaxLength, c.Value)
+			case "pattern":
+				if field.Pattern == "" {
+					field.Pattern = c.Value
+				}
+			}
+		}
+		fields[name] = field
+	}
+}
+
+// constraintInt returns current if it's set and value otherwise, values are
+// validated by the definition package.
+func constraintInt(current *int64, value string) *int64 {
+	if current != nil {
+		return current
+	}
+	n, err := strconv.ParseInt(value, 10, 64)
+	if err != nil {
+		return nil
+	}
+	return &n
+}
+
+func (t *typeMapper) checkEnums(obj data.Object) error {
+	for name, enum := range t.enums {
+		value, ok := obj[name]
+		if !ok || value == nil {
+			continue
+		}
+		_, err := mapNested(value, enum.containers, func(value interface{}) (interface{}, error) {
+			if value == nil {
+				return value, nil
+			}
+			if str := convert.ToString(value); !slices.Contains(enum.values, str) {
+				return nil, fmt.Errorf("invalid value %q for field %s, expected one of %s", str, name, strings.Join(enum.values, ", "))
+			}
+			return value, nil
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
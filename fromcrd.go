@@ -0,0 +1,229 @@
+package schemas
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"slices"
+	"sort"
+
+	"github.com/acorn-io/schemer/data/convert"
+	apiextv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+)
+
+// FromCRD converts the openAPIV3Schema of the storage version, or the first
+// served version, of crd into a schema. Nested objects are typed as json
+// because there is no registry to hold their schemas, use Schemas.AddCRD to
+// get typed sub-schemas.
+func FromCRD(crd *apiextv1.CustomResourceDefinition) (*Schema, error) {
+	props, err := crdSchema(crd)
+	if err != nil {
+		return nil, err
+	}
+
+	schema := newCRDSchema(crd)
+	for name, prop := range props.Properties {
+		if skipCRDField(name) {
+			continue
+		}
+		field, err := crdField(nil, schema.ID, name, prop, slices.Contains(props.Required, name))
+		if err != nil {
+			return nil, err
+		}
+		schema.ResourceFields[name] = field
+	}
+	schema.FieldOrder = schema.OrderedFieldNames()
+
+	return schema, nil
+}
+
+// AddCRD converts the openAPIV3Schema of the storage version, or the first
+// served version, of crd into a schema and adds it to the registry. Nested
+// objects with properties are added as schemas named after their parent and
+// field name.
+func (s *Schemas) AddCRD(crd *apiextv1.CustomResourceDefinition) (*Schema, error) {
+	props, err := crdSchema(crd)
+	if err != nil {
+		return nil, err
+	}
+
+	schema := newCRDSchema(crd)
+	for name, prop := range props.Properties {
+		if skipCRDField(name) {
+			continue
+		}
+		field, err := crdField(s, schema.ID, name, prop, slices.Contains(props.Required, name))
+		if err != nil {
+			return nil, err
+		}
+		schema.ResourceFields[name] = field
+	}
+	schema.FieldOrder = schema.OrderedFieldNames()
+
+	if err := s.AddSchema(*schema); err != nil {
+		return nil, err
+	}
+	return s.Schema(schema.ID), nil
+}
+
+func newCRDSchema(crd *apiextv1.CustomResourceDefinition) *Schema {
+	return &Schema{
+		ID:                convert.LowerTitle(crd.Spec.Names.Kind),
+		CodeName:          crd.Spec.Names.Kind,
+		PkgName:           crd.Spec.Group,
+		PluralName:        crd.Spec.Names.Plural,
+		ResourceFields:    map[string]Field{},
+		CollectionMethods: []string{MethodGet, MethodPost},
+		ResourceMethods:   []string{MethodGet, MethodPut, MethodDelete},
+	}
+}
+
+func skipCRDField(name string) bool {
+	return name == "apiVersion" || name == "kind" || name == "metadata"
+}
+
+func crdSchema(crd *apiextv1.CustomResourceDefinition) (*apiextv1.JSONSchemaProps, error) {
+	var version *apiextv1.CustomResourceDefinitionVersion
+	for i, v := range crd.Spec.Versions {
+		if v.Storage {
+			version = &crd.Spec.Versions[i]
+			break
+		}
+		if v.Served && version == nil {
+			version = &crd.Spec.Versions[i]
+		}
+	}
+
+	if version == nil {
+		return nil, fmt.Errorf("CRD %s has no served version", crd.Name)
+	}
+	if version.Schema == nil || version.Schema.OpenAPIV3Schema == nil {
+		return nil, fmt.Errorf("version %s of CRD %s has no openAPIV3Schema", version.Name, crd.Name)
+	}
+	return version.Schema.OpenAPIV3Schema, nil
+}
+
+func crdField(s *Schemas, parentID, name string, prop apiextv1.JSONSchemaProps, required bool) (Field, error) {
+	field := Field{
+		Description: prop.Description,
+		Nullable:    prop.Nullable,
+		Required:    required,
+		Create:      true,
+		Update:      true,
+		MinLength:   prop.MinLength,
+		MaxLength:   prop.MaxLength,
+		CodeName:    convert.Capitalize(name),
+	}
+
+	fieldType, err := crdType(s, parentID+convert.Capitalize(name), prop)
+	if err != nil {
+		return field, fmt.Errorf("field %s of %s: %w", name, parentID, err)
+	}
+	field.Type = fieldType
+
+	if prop.Default != nil {
+		if err := json.Unmarshal(prop.Default.Raw, &field.Default); err != nil {
+			return field, fmt.Errorf("invalid default of field %s of %s: %w", name, parentID, err)
+		}
+	}
+
+	if fieldType == "enum" {
+		for _, value := range prop.Enum {
+			var option string
+			if err := json.Unmarshal(value.Raw, &option); err == nil && option != "" {
+				field.Options = append(field.Options, option)
+			}
+		}
+	}
+
+	if fieldType == "int" {
+		if prop.Minimum != nil {
+			field.Min = &[]int64{int64(math.Ceil(*prop.Minimum))}[0]
+		}
+		if prop.Maximum != nil {
+			field.Max = &[]int64{int64(math.Floor(*prop.Maximum))}[0]
+		}
+	}
+
+	return field, nil
+}
+
+func crdType(s *Schemas, id string, prop apiextv1.JSONSchemaProps) (string, error) {
+	if prop.XIntOrString {
+		if prop.Pattern == convert.QuantityPattern {
+			return "quantity", nil
+		}
+		return "intOrString", nil
+	}
+
+	switch prop.Type {
+	case "string":
+		if prop.Format == "date-time" {
+			return "date", nil
+		}
+		if len(prop.Enum) > 0 {
+			return "enum", nil
+		}
+		return "string", nil
+	case "integer":
+		return "int", nil
+	case "number":
+		return "float", nil
+	case "boolean":
+		return "boolean", nil
+	case "array":
+		if prop.Items == nil || prop.Items.Schema == nil {
+			return "array[json]", nil
+		}
+		subType, err := crdType(s, id, *prop.Items.Schema)
+		if err != nil {
+			return "", err
+		}
+		return fmt.Sprintf("array[%s]", subType), nil
+	case "object", "":
+		if len(prop.Properties) > 0 {
+			return crdObjectType(s, id, prop)
+		}
+		if prop.AdditionalProperties != nil && prop.AdditionalProperties.Schema != nil {
+			subType, err := crdType(s, id, *prop.AdditionalProperties.Schema)
+			if err != nil {
+				return "", err
+			}
+			return fmt.Sprintf("map[%s]", subType), nil
+		}
+		return "json", nil
+	}
+
+	return "", fmt.Errorf("unsupported type %s", prop.Type)
+}
+
+func crdObjectType(s *Schemas, id string, prop apiextv1.JSONSchemaProps) (string, error) {
+	if s == nil {
+		return "json", nil
+	}
+
+	schema := Schema{
+		ID:             id,
+		ResourceFields: map[string]Field{},
+	}
+
+	names := make([]string, 0, len(prop.Properties))
+	for name := range prop.Properties {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		field, err := crdField(s, id, name, prop.Properties[name], slices.Contains(prop.Required, name))
+		if err != nil {
+			return "", err
+		}
+		schema.ResourceFields[name] = field
+	}
+	schema.FieldOrder = names
+
+	if err := s.AddSchema(schema); err != nil {
+		return "", err
+	}
+	return id, nil
+}
@@ -0,0 +1,136 @@
+package schemas
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	apiextv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+)
+
+const testCRD = `{
+	"metadata": {"name": "apps.example.com"},
+	"spec": {
+		"group": "example.com",
+		"names": {"kind": "App", "plural": "apps"},
+		"versions": [
+			{"name": "v1alpha1", "served": true, "schema": {"openAPIV3Schema": {"type": "object"}}},
+			{"name": "v1", "served": true, "storage": true, "schema": {"openAPIV3Schema": {
+				"type": "object",
+				"required": ["spec"],
+				"properties": {
+					"apiVersion": {"type": "string"},
+					"kind": {"type": "string"},
+					"metadata": {"type": "object"},
+					"spec": {
+						"type": "object",
+						"required": ["image"],
+						"properties": {
+							"image": {"type": "string", "description": "The image", "minLength": 1},
+							"replicas": {"type": "integer", "minimum": 0.5, "maximum": 10.5, "default": 1},
+							"policy": {"type": "string", "enum": ["Always", "Never"]},
+							"created": {"type": "string", "format": "date-time"},
+							"port": {"x-kubernetes-int-or-string": true},
+							"memory": {"x-kubernetes-int-or-string": true, "pattern": "^(\\+|-)?(([0-9]+(\\.[0-9]*)?)|(\\.[0-9]+))(([KMGTPE]i)|[numkMGTPE]|([eE](\\+|-)?(([0-9]+(\\.[0-9]*)?)|(\\.[0-9]+))))?$"},
+							"args": {"type": "array", "items": {"type": "string"}},
+							"env": {"type": "object", "additionalProperties": {"type": "string"}},
+							"volumes": {"type": "array", "items": {"type": "object", "properties": {"name": {"type": "string"}}}},
+							"extra": {"type": "object", "x-kubernetes-preserve-unknown-fields": true}
+						}
+					}
+				}
+			}}}
+		]
+	}
+}`
+
+func newTestCRD(t *testing.T) *apiextv1.CustomResourceDefinition {
+	crd := &apiextv1.CustomResourceDefinition{}
+	if err := json.Unmarshal([]byte(testCRD), crd); err != nil {
+		t.Fatal(err)
+	}
+	return crd
+}
+
+func TestFromCRD(t *testing.T) {
+	schema, err := FromCRD(newTestCRD(t))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if schema.ID != "app" || schema.PluralName != "apps" || schema.PkgName != "example.com" {
+		t.Fatalf("unexpected schema %s %s %s", schema.ID, schema.PluralName, schema.PkgName)
+	}
+	if !reflect.DeepEqual(schema.FieldOrder, []string{"spec"}) {
+		t.Fatalf("expected only the spec field, got %v", schema.FieldOrder)
+	}
+	if spec := schema.ResourceFields["spec"]; spec.Type != "json" || !spec.Required {
+		t.Fatalf("expected a required json spec without a registry, got %+v", spec)
+	}
+}
+
+func TestAddCRD(t *testing.T) {
+	s := EmptySchemas()
+	schema, err := s.AddCRD(newTestCRD(t))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if schema.ResourceFields["spec"].Type != "appSpec" {
+		t.Fatalf("expected spec of type appSpec, got %s", schema.ResourceFields["spec"].Type)
+	}
+
+	spec := s.Schema("appSpec")
+	if spec == nil {
+		t.Fatal("expected schema appSpec")
+	}
+
+	types := map[string]string{}
+	for name, field := range spec.ResourceFields {
+		types[name] = field.Type
+	}
+	expected := map[string]string{
+		"image":    "string",
+		"replicas": "int",
+		"policy":   "enum",
+		"created":  "date",
+		"port":     "intOrString",
+		"memory":   "quantity",
+		"args":     "array[string]",
+		"env":      "map[string]",
+		"volumes":  "array[appSpecVolumes]",
+		"extra":    "json",
+	}
+	if !reflect.DeepEqual(types, expected) {
+		t.Fatalf("expected types %v, got %v", expected, types)
+	}
+	if s.Schema("appSpecVolumes") == nil {
+		t.Fatal("expected schema appSpecVolumes")
+	}
+
+	image := spec.ResourceFields["image"]
+	if !image.Required || image.Description != "The image" || image.MinLength == nil || *image.MinLength != 1 {
+		t.Fatalf("unexpected field image %+v", image)
+	}
+	replicas := spec.ResourceFields["replicas"]
+	if replicas.Required || *replicas.Min != 1 || *replicas.Max != 10 || replicas.Default != float64(1) {
+		t.Fatalf("unexpected field replicas %+v", replicas)
+	}
+	if policy := spec.ResourceFields["policy"]; !reflect.DeepEqual(policy.Options, []string{"Always", "Never"}) {
+		t.Fatalf("unexpected options %v", policy.Options)
+	}
+}
+
+func TestCRDErrors(t *testing.T) {
+	crd := newTestCRD(t)
+	crd.Spec.Versions = crd.Spec.Versions[:1]
+	crd.Spec.Versions[0].Served = false
+	if _, err := FromCRD(crd); err == nil || err.Error() != "CRD apps.example.com has no served version" {
+		t.Fatalf("unexpected error %v", err)
+	}
+
+	crd = newTestCRD(t)
+	crd.Spec.Versions[1].Schema.OpenAPIV3Schema.Properties["spec"] = apiextv1.JSONSchemaProps{Type: "null"}
+	if _, err := FromCRD(crd); err == nil || err.Error() != "field spec of app: unsupported type null" {
+		t.Fatalf("unexpected error %v", err)
+	}
+}
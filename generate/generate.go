@@ -0,0 +1,150 @@
+// Package generate writes CRDs, OpenAPI documents and JSON Schemas for Go
+// types. It backs the schemer command, which compiles a small program that
+// passes the types of the requested packages to Write.
+package generate
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"reflect"
+
+	"github.com/acorn-io/schemer/crd"
+	"github.com/acorn-io/schemer/openapi"
+	v1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+type Format string
+
+const (
+	FormatCRD        Format = "crd"
+	FormatOpenAPI    Format = "openapi"
+	FormatJSONSchema Format = "jsonschema"
+)
+
+// Type is a Go type to generate a document for. Group, version and kind
+// default to the names guessed by crd.CRD.
+type Type struct {
+	Object     interface{}
+	Group      string
+	Version    string
+	Kind       string
+	Plural     string
+	Namespaced bool
+	Status     bool
+}
+
+// Write writes the document of the given format for types to out. CRDs are
+// written as YAML, OpenAPI documents and JSON Schemas as JSON.
+func Write(out io.Writer, format Format, types []Type) error {
+	switch format {
+	case FormatCRD:
+		return crd.Print(out, nil, toCRDs(types))
+	case FormatOpenAPI:
+		doc, err := openAPIDocument(types)
+		if err != nil {
+			return err
+		}
+		return writeJSON(out, doc)
+	case FormatJSONSchema:
+		doc, err := jsonSchemaDocument(types)
+		if err != nil {
+			return err
+		}
+		return writeJSON(out, doc)
+	}
+	return fmt.Errorf("unknown format %q, expected %s, %s or %s", format, FormatCRD, FormatOpenAPI, FormatJSONSchema)
+}
+
+func toCRDs(types []Type) []crd.CRD {
+	result := make([]crd.CRD, 0, len(types))
+	for _, t := range types {
+		result = append(result, crd.CRD{
+			GVK: schema.GroupVersionKind{
+				Group:   t.Group,
+				Version: t.Version,
+				Kind:    t.Kind,
+			},
+			PluralName:   t.Plural,
+			NonNamespace: !t.Namespaced,
+			Status:       t.Status,
+			SchemaObject: t.Object,
+		}.WithColumnsFromStruct(t.Object))
+	}
+	return result
+}
+
+// schemas returns the OpenAPI schemas of types by kind.
+func schemas(types []Type) (map[string]*v1.JSONSchemaProps, error) {
+	result := map[string]*v1.JSONSchemaProps{}
+	for _, t := range types {
+		props, err := openapi.ToOpenAPIFromStruct(t.Object)
+		if err != nil {
+			return nil, err
+		}
+		kind := t.Kind
+		if kind == "" {
+			kind = reflect.Indirect(reflect.ValueOf(t.Object)).Type().Name()
+		}
+		result[kind] = props
+	}
+	return result, nil
+}
+
+func openAPIDocument(types []Type) (map[string]interface{}, error) {
+	props, err := schemas(types)
+	if err != nil {
+		return nil, err
+	}
+	return map[string]interface{}{
+		"openapi": "3.0.3",
+		"info": map[string]interface{}{
+			"title":   "schemer",
+			"version": "v1",
+		},
+		"paths": map[string]interface{}{},
+		"components": map[string]interface{}{
+			"schemas": props,
+		},
+	}, nil
+}
+
+// jsonSchemaDocument returns the JSON Schema of a single type or a document
+// with the schemas of all types in $defs.
+func jsonSchemaDocument(types []Type) (map[string]interface{}, error) {
+	props, err := schemas(types)
+	if err != nil {
+		return nil, err
+	}
+
+	defs := map[string]interface{}{}
+	for kind, p := range props {
+		def, err := openapi.ToJSONSchema(p)
+		if err != nil {
+			return nil, err
+		}
+		defs[kind] = def
+	}
+
+	if len(defs) == 1 {
+		for _, def := range defs {
+			result := def.(map[string]interface{})
+			result["$schema"] = openapi.JSONSchemaDraft
+			return result, nil
+		}
+	}
+	return map[string]interface{}{
+		"$schema": openapi.JSONSchemaDraft,
+		"$defs":   defs,
+	}, nil
+}
+
+func writeJSON(out io.Writer, doc interface{}) error {
+	b, err := json.MarshalIndent(doc, "", "  ")
+	if err != nil {
+		return err
+	}
+	_, err = out.Write(append(b, '\n'))
+	return err
+}
@@ -0,0 +1,64 @@
+package generate
+
+import (
+	"bytes"
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/acorn-io/schemer/openapi"
+)
+
+type app struct {
+	Name  string   `json:"name"`
+	Count *int     `json:"count,omitempty"`
+	Tags  []string `json:"tags,omitempty"`
+}
+
+type project struct {
+	Apps []app `json:"apps"`
+}
+
+func TestJSONSchema(t *testing.T) {
+	buf := &bytes.Buffer{}
+	if err := Write(buf, FormatJSONSchema, []Type{{Object: app{}}}); err != nil {
+		t.Fatal(err)
+	}
+
+	var doc map[string]interface{}
+	if err := json.Unmarshal(buf.Bytes(), &doc); err != nil {
+		t.Fatal(err)
+	}
+	if doc["$schema"] != openapi.JSONSchemaDraft {
+		t.Fatalf("expected $schema %s, got %v", openapi.JSONSchemaDraft, doc["$schema"])
+	}
+	count := doc["properties"].(map[string]interface{})["count"].(map[string]interface{})
+	if _, ok := count["nullable"]; ok {
+		t.Fatalf("expected nullable to be removed, got %v", count)
+	}
+	if !reflect.DeepEqual(count["type"], []interface{}{"integer", "null"}) {
+		t.Fatalf("expected nullable integer, got %v", count["type"])
+	}
+
+	buf.Reset()
+	if err := Write(buf, FormatJSONSchema, []Type{{Object: app{}, Kind: "App"}, {Object: project{}}}); err != nil {
+		t.Fatal(err)
+	}
+	doc = nil
+	if err := json.Unmarshal(buf.Bytes(), &doc); err != nil {
+		t.Fatal(err)
+	}
+	defs, _ := doc["$defs"].(map[string]interface{})
+	if _, ok := defs["App"]; !ok {
+		t.Fatalf("expected App in $defs, got %v", defs)
+	}
+	if _, ok := defs["project"]; !ok {
+		t.Fatalf("expected project in $defs, got %v", defs)
+	}
+}
+
+func TestUnknownFormat(t *testing.T) {
+	if err := Write(&bytes.Buffer{}, "xml", []Type{{Object: app{}}}); err == nil {
+		t.Fatal("expected error for unknown format")
+	}
+}
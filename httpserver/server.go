@@ -0,0 +1,256 @@
+// Package httpserver serves the schemas of a registry over HTTP so clients can
+// discover the types of an API:
+//
+//	GET /v1/schemas       the collection of all schemas
+//	GET /v1/schemas/{id}  a single schema
+//
+// Schemas are returned as JSON by default, or as JSON Schema if the client
+// accepts application/schema+json.
+package httpserver
+
+import (
+	"encoding/json"
+	"fmt"
+	"mime"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+
+	schemas "github.com/acorn-io/schemer"
+	"github.com/acorn-io/schemer/data/convert"
+	"github.com/acorn-io/schemer/openapi"
+)
+
+const (
+	ContentTypeJSON       = "application/json"
+	ContentTypeJSONSchema = "application/schema+json"
+
+	schemasPath = "/v1/schemas"
+)
+
+// Server is an http.Handler serving the schemas of a registry. Methods are
+// reported as filtered by the method filters of the registry for the
+// request's context, and schemas the caller can't get are not found.
+type Server struct {
+	schemas *schemas.Schemas
+}
+
+func New(schemas *schemas.Schemas) *Server {
+	return &Server{
+		schemas: schemas,
+	}
+}
+
+func (s *Server) ServeHTTP(rw http.ResponseWriter, req *http.Request) {
+	if req.Method != http.MethodGet && req.Method != http.MethodHead {
+		rw.Header().Set("Allow", "GET, HEAD")
+		writeError(rw, http.StatusMethodNotAllowed, "MethodNotAllowed", fmt.Sprintf("method %s is not allowed", req.Method))
+		return
+	}
+
+	// the escaped path keeps %2F in schema IDs from splitting the path, the ID
+	// is unescaped by get
+	id, ok := strings.CutPrefix(req.URL.EscapedPath(), schemasPath)
+	switch {
+	case !ok:
+		writeError(rw, http.StatusNotFound, "NotFound", fmt.Sprintf("path %s not found", req.URL.Path))
+	case id == "" || id == "/":
+		s.list(rw, req)
+	case strings.HasPrefix(id, "/") && !strings.Contains(id[1:], "/"):
+		s.get(rw, req, id[1:])
+	default:
+		writeError(rw, http.StatusNotFound, "NotFound", fmt.Sprintf("path %s not found", req.URL.Path))
+	}
+}
+
+func (s *Server) list(rw http.ResponseWriter, req *http.Request) {
+	var visible []*schemas.Schema
+	for _, schema := range s.schemas.Schemas() {
+		if s.visible(req, schema) {
+			visible = append(visible, schema)
+		}
+	}
+	sort.Slice(visible, func(i, j int) bool {
+		return visible[i].ID < visible[j].ID
+	})
+
+	if negotiate(req) == ContentTypeJSONSchema {
+		defs := map[string]interface{}{}
+		for _, schema := range visible {
+			def, err := jsonSchema(schema.ID, s.schemas)
+			if err != nil {
+				writeError(rw, http.StatusInternalServerError, "ServerError", err.Error())
+				return
+			}
+			defs[schema.ID] = def
+		}
+		writeJSON(rw, req, ContentTypeJSONSchema, map[string]interface{}{
+			"$schema": openapi.JSONSchemaDraft,
+			"$defs":   defs,
+		})
+		return
+	}
+
+	data := make([]interface{}, 0, len(visible))
+	for _, schema := range visible {
+		obj, err := s.toObject(req, schema)
+		if err != nil {
+			writeError(rw, http.StatusInternalServerError, "ServerError", err.Error())
+			return
+		}
+		data = append(data, obj)
+	}
+	writeJSON(rw, req, ContentTypeJSON, map[string]interface{}{
+		"type":         "collection",
+		"resourceType": "schema",
+		"links": map[string]string{
+			"self": baseURL(req) + schemasPath,
+		},
+		"data": data,
+	})
+}
+
+func (s *Server) get(rw http.ResponseWriter, req *http.Request, id string) {
+	id, err := url.PathUnescape(id)
+	if err != nil {
+		writeError(rw, http.StatusBadRequest, "InvalidPath", err.Error())
+		return
+	}
+
+	schema := s.schemas.Schema(id)
+	if schema == nil || !s.visible(req, schema) {
+		writeError(rw, http.StatusNotFound, "NotFound", fmt.Sprintf("schema %s not found", id))
+		return
+	}
+
+	if negotiate(req) == ContentTypeJSONSchema {
+		def, err := jsonSchema(schema.ID, s.schemas)
+		if err != nil {
+			writeError(rw, http.StatusInternalServerError, "ServerError", err.Error())
+			return
+		}
+		def["$schema"] = openapi.JSONSchemaDraft
+		writeJSON(rw, req, ContentTypeJSONSchema, def)
+		return
+	}
+
+	obj, err := s.toObject(req, schema)
+	if err != nil {
+		writeError(rw, http.StatusInternalServerError, "ServerError", err.Error())
+		return
+	}
+	writeJSON(rw, req, ContentTypeJSON, obj)
+}
+
+// visible returns true if the caller may see schema. Schemas without methods
+// only describe types and are visible to everybody, the others are hidden
+// like in watchstream if the method filters don't allow the caller to get
+// them.
+func (s *Server) visible(req *http.Request, schema *schemas.Schema) bool {
+	if len(schema.CollectionMethods) == 0 && len(schema.ResourceMethods) == 0 {
+		return true
+	}
+	return s.schemas.Allowed(req.Context(), schema, schemas.MethodGet, true) ||
+		s.schemas.Allowed(req.Context(), schema, schemas.MethodGet, false)
+}
+
+// toObject returns the schema as a resource with an id, a self link and the
+// methods allowed for the caller.
+func (s *Server) toObject(req *http.Request, schema *schemas.Schema) (map[string]interface{}, error) {
+	obj, err := convert.EncodeToMap(schema)
+	if err != nil {
+		return nil, err
+	}
+
+	collectionMethods, resourceMethods := s.schemas.AllowedMethods(req.Context(), schema)
+	obj["id"] = schema.ID
+	obj["type"] = "schema"
+	obj["collectionMethods"] = collectionMethods
+	obj["resourceMethods"] = resourceMethods
+
+	links := map[string]interface{}{}
+	for k, v := range schema.Links {
+		links[k] = v
+	}
+	links["self"] = baseURL(req) + schemasPath + "/" + url.PathEscape(schema.ID)
+	obj["links"] = links
+	return obj, nil
+}
+
+func jsonSchema(id string, schemas *schemas.Schemas) (map[string]interface{}, error) {
+	props, err := openapi.ToOpenAPI(id, schemas)
+	if err != nil {
+		return nil, err
+	}
+	return openapi.ToJSONSchema(props)
+}
+
+// negotiate returns the content type to respond with. The ?format=jsonschema
+// query parameter can be used by clients that can't set the Accept header.
+func negotiate(req *http.Request) string {
+	if req.URL.Query().Get("format") == "jsonschema" {
+		return ContentTypeJSONSchema
+	}
+
+	best, bestQuality := ContentTypeJSON, 0.0
+	for _, accept := range strings.Split(req.Header.Get("Accept"), ",") {
+		mediaType, params, err := mime.ParseMediaType(strings.TrimSpace(accept))
+		if err != nil {
+			continue
+		}
+		quality := 1.0
+		if q, ok := params["q"]; ok {
+			if quality, err = strconv.ParseFloat(q, 64); err != nil {
+				continue
+			}
+		}
+		if quality <= bestQuality {
+			continue
+		}
+		switch mediaType {
+		case ContentTypeJSONSchema:
+			best, bestQuality = ContentTypeJSONSchema, quality
+		case ContentTypeJSON, "application/*", "*/*":
+			best, bestQuality = ContentTypeJSON, quality
+		}
+	}
+	return best
+}
+
+func baseURL(req *http.Request) string {
+	scheme := "http"
+	if proto := req.Header.Get("X-Forwarded-Proto"); proto != "" {
+		scheme = proto
+	} else if req.TLS != nil {
+		scheme = "https"
+	}
+	return scheme + "://" + req.Host
+}
+
+func writeJSON(rw http.ResponseWriter, req *http.Request, contentType string, obj interface{}) {
+	b, err := json.Marshal(obj)
+	if err != nil {
+		writeError(rw, http.StatusInternalServerError, "ServerError", err.Error())
+		return
+	}
+	rw.Header().Set("Content-Type", contentType)
+	rw.Header().Add("Vary", "Accept")
+	rw.WriteHeader(http.StatusOK)
+	if req.Method != http.MethodHead {
+		_, _ = rw.Write(b)
+	}
+}
+
+func writeError(rw http.ResponseWriter, status int, code, message string) {
+	b, _ := json.Marshal(map[string]interface{}{
+		"type":    "error",
+		"status":  status,
+		"code":    code,
+		"message": message,
+	})
+	rw.Header().Set("Content-Type", ContentTypeJSON)
+	rw.WriteHeader(status)
+	_, _ = rw.Write(b)
+}
@@ -0,0 +1,145 @@
+package httpserver
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	schemas "github.com/acorn-io/schemer"
+)
+
+type app struct {
+	Name     string `json:"name"`
+	Replicas *int   `json:"replicas,omitempty"`
+}
+
+func newServer(t *testing.T) *httptest.Server {
+	s := schemas.EmptySchemas()
+	if _, err := s.Import(app{}); err != nil {
+		t.Fatal(err)
+	}
+	server := httptest.NewServer(New(s))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func get(t *testing.T, url, accept string) (*http.Response, map[string]interface{}) {
+	req, err := http.NewRequest(http.MethodGet, url, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if accept != "" {
+		req.Header.Set("Accept", accept)
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+
+	var body map[string]interface{}
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		t.Fatal(err)
+	}
+	return resp, body
+}
+
+func TestServer(t *testing.T) {
+	server := newServer(t)
+
+	resp, body := get(t, server.URL+"/v1/schemas", "")
+	if resp.StatusCode != http.StatusOK || resp.Header.Get("Content-Type") != ContentTypeJSON {
+		t.Fatalf("unexpected response %d %s", resp.StatusCode, resp.Header.Get("Content-Type"))
+	}
+	data, _ := body["data"].([]interface{})
+	if len(data) != 1 || data[0].(map[string]interface{})["id"] != "app" {
+		t.Fatalf("expected schema app, got %v", body["data"])
+	}
+
+	_, body = get(t, server.URL+"/v1/schemas/app", "")
+	if body["links"].(map[string]interface{})["self"] != server.URL+"/v1/schemas/app" {
+		t.Fatalf("unexpected links %v", body["links"])
+	}
+	if _, ok := body["resourceFields"].(map[string]interface{})["replicas"]; !ok {
+		t.Fatalf("expected field replicas, got %v", body["resourceFields"])
+	}
+
+	resp, body = get(t, server.URL+"/v1/schemas/app", "application/json;q=0.5, application/schema+json")
+	if resp.Header.Get("Content-Type") != ContentTypeJSONSchema {
+		t.Fatalf("expected JSON Schema, got %s", resp.Header.Get("Content-Type"))
+	}
+	if _, ok := body["properties"].(map[string]interface{})["replicas"]; !ok {
+		t.Fatalf("expected property replicas, got %v", body)
+	}
+
+	resp, body = get(t, server.URL+"/v1/schemas/missing", "")
+	if resp.StatusCode != http.StatusNotFound || body["code"] != "NotFound" {
+		t.Fatalf("expected not found, got %d %v", resp.StatusCode, body)
+	}
+}
+
+func TestServerEscapedIDs(t *testing.T) {
+	s := schemas.EmptySchemas()
+	for _, id := range []string{"a/b", "100%", "100%25"} {
+		s.MustAddSchema(schemas.Schema{ID: id})
+	}
+	server := httptest.NewServer(New(s))
+	t.Cleanup(server.Close)
+
+	for path, id := range map[string]string{
+		"a%2Fb":    "a/b",
+		"100%25":   "100%",
+		"100%2525": "100%25",
+	} {
+		resp, body := get(t, server.URL+"/v1/schemas/"+path, "")
+		if resp.StatusCode != http.StatusOK || body["id"] != id {
+			t.Errorf("expected schema %s for %s, got %d %v", id, path, resp.StatusCode, body)
+		}
+		links, _ := body["links"].(map[string]interface{})
+		if self := links["self"]; self != server.URL+"/v1/schemas/"+path {
+			t.Errorf("unexpected self link %v for %s", self, path)
+		}
+	}
+
+	resp, body := get(t, server.URL+"/v1/schemas/a/b", "")
+	if resp.StatusCode != http.StatusNotFound {
+		t.Fatalf("expected not found for an unescaped slash, got %d %v", resp.StatusCode, body)
+	}
+}
+
+func TestServerHidesDisallowedSchemas(t *testing.T) {
+	s := schemas.EmptySchemas()
+	for _, id := range []string{"app", "secret"} {
+		s.MustAddSchema(schemas.Schema{
+			ID:                id,
+			CollectionMethods: []string{schemas.MethodGet},
+			ResourceMethods:   []string{schemas.MethodGet},
+		})
+	}
+	s.AddMethodFilter(func(ctx context.Context, schema *schemas.Schema, collectionMethods, resourceMethods []string) ([]string, []string) {
+		if schema.ID == "secret" {
+			return nil, nil
+		}
+		return collectionMethods, resourceMethods
+	})
+	server := httptest.NewServer(New(s))
+	t.Cleanup(server.Close)
+
+	_, body := get(t, server.URL+"/v1/schemas", "")
+	data, _ := body["data"].([]interface{})
+	if len(data) != 1 || data[0].(map[string]interface{})["id"] != "app" {
+		t.Fatalf("expected only schema app, got %v", body["data"])
+	}
+
+	_, body = get(t, server.URL+"/v1/schemas", ContentTypeJSONSchema)
+	if defs, _ := body["$defs"].(map[string]interface{}); len(defs) != 1 || defs["app"] == nil {
+		t.Fatalf("expected only definition app, got %v", body["$defs"])
+	}
+
+	resp, body := get(t, server.URL+"/v1/schemas/secret", "")
+	if resp.StatusCode != http.StatusNotFound || body["code"] != "NotFound" {
+		t.Fatalf("expected not found, got %d %v", resp.StatusCode, body)
+	}
+}
@@ -0,0 +1,143 @@
+package schemas
+
+import (
+	"fmt"
+	"path"
+	"reflect"
+	"strings"
+
+	"github.com/acorn-io/schemer/data/convert"
+)
+
+// CollisionStrategy decides what happens when the ID generated for a type is
+// already used by a schema of a different Go type.
+type CollisionStrategy int
+
+const (
+	// CollisionReuse returns the existing schema, which is the behavior of
+	// Import.
+	CollisionReuse CollisionStrategy = iota
+	// CollisionError fails the import.
+	CollisionError
+	// CollisionPackagePrefix prefixes the ID with the last element of the
+	// package path of the type, for example "v1Pod".
+	CollisionPackagePrefix
+	// CollisionSuffix appends the first free number starting at 2 to the ID.
+	CollisionSuffix
+)
+
+// EmbeddedStrategy decides how anonymous embedded structs without a json name
+// are imported. metav1.TypeMeta is always squashed.
+type EmbeddedStrategy int
+
+const (
+	// EmbedSquash adds the fields of the embedded struct to the parent.
+	EmbedSquash EmbeddedStrategy = iota
+	// EmbedSubObject imports the embedded struct as a field named after the
+	// type.
+	EmbedSubObject
+	// EmbedSkip ignores embedded structs.
+	EmbedSkip
+)
+
+type ImportOptions struct {
+	// Prefix is prepended to every generated ID, for example a group or
+	// version.
+	Prefix string
+	// NameFunc converts the Go type name to the ID, for example
+	// convert.ToCamel. It defaults to convert.LowerTitle.
+	NameFunc func(name string) string
+	// FieldNameFunc converts the Go name of fields without a name in their
+	// tag to the field name, for example convert.ToCamel. It defaults to
+	// convert.LowerTitle with a trailing "ID" written as "Id".
+	FieldNameFunc func(name string) string
+	// PluralFunc generates the plural name of the schema from its ID. It
+	// defaults to name.GuessPluralName.
+	PluralFunc func(id string) string
+	// OnCollision is the strategy used when the generated ID is taken.
+	OnCollision CollisionStrategy
+	// Descriptions sets the Description of schemas and fields from the doc
+	// comments of the Go types. The source of the packages is located with
+	// go/build unless it is listed in SourceDirs by package path.
+	Descriptions bool
+	SourceDirs   map[string]string
+	// TagNames is the priority list of struct tags used to name fields. It
+	// defaults to json.
+	TagNames []string
+	// Embedded is the strategy for anonymous embedded structs.
+	Embedded EmbeddedStrategy
+	// ErrorOnFieldCollision fails the import if two squashed structs define
+	// the same field, otherwise the field read last wins.
+	ErrorOnFieldCollision bool
+}
+
+func (s *Schemas) fieldName(name string) string {
+	if s.importOptions.FieldNameFunc != nil {
+		return s.importOptions.FieldNameFunc(name)
+	}
+	fieldName := convert.LowerTitle(name)
+	if strings.HasSuffix(fieldName, "ID") {
+		fieldName = strings.TrimSuffix(fieldName, "ID") + "Id"
+	}
+	return fieldName
+}
+
+// importTypeName returns the ID for a type being imported. Names set with
+// TypeName take precedence over the import options. Generated names that
+// differ from the default are remembered so later lookups by type find the
+// schema.
+func (s *Schemas) importTypeName(t reflect.Type) (string, error) {
+	s.RLock()
+	typeName, ok := s.typeNames[t]
+	s.RUnlock()
+	if ok {
+		return typeName, nil
+	}
+
+	opts := s.importOptions
+	typeName = convert.LowerTitle(t.Name())
+	if opts.NameFunc != nil {
+		typeName = opts.NameFunc(t.Name())
+	}
+	typeName = opts.Prefix + typeName
+
+	if s.collides(typeName, t) {
+		switch opts.OnCollision {
+		case CollisionError:
+			return "", fmt.Errorf("schema %s for type %v is already used by another type", typeName, t)
+		case CollisionPackagePrefix:
+			prefixed := convert.LowerTitle(path.Base(t.PkgPath())) + convert.Capitalize(typeName)
+			if s.collides(prefixed, t) {
+				return "", fmt.Errorf("schema %s for type %v is already used by another type", prefixed, t)
+			}
+			typeName = prefixed
+		case CollisionSuffix:
+			for i := 2; ; i++ {
+				candidate := fmt.Sprintf("%s%d", typeName, i)
+				if !s.collides(candidate, t) {
+					typeName = candidate
+					break
+				}
+			}
+		}
+	}
+
+	if typeName != convert.LowerTitle(t.Name()) {
+		s.Lock()
+		s.typeNames[t] = typeName
+		s.Unlock()
+	}
+
+	return typeName, nil
+}
+
+// collides returns true if a schema with the ID exists that was imported from
+// a different Go type. Schemas that were not imported from a type never
+// collide.
+func (s *Schemas) collides(id string, t reflect.Type) bool {
+	existing := s.doSchema(id, true)
+	if existing == nil || existing.PkgName == "" {
+		return false
+	}
+	return existing.PkgName != t.PkgPath() || existing.CodeName != t.Name()
+}
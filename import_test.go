@@ -0,0 +1,163 @@
+package schemas
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+type importFirst struct {
+	Name string `json:"name"`
+}
+
+type importSecond struct {
+	Size int `json:"size"`
+}
+
+func TestImportNaming(t *testing.T) {
+	s := EmptySchemas()
+	schema, err := s.ImportWithOptions(importFirst{}, ImportOptions{
+		Prefix:     "v1",
+		NameFunc:   strings.ToUpper,
+		PluralFunc: func(id string) string { return id + "-list" },
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if schema.ID != "v1IMPORTFIRST" || schema.PluralName != "v1IMPORTFIRST-list" {
+		t.Fatalf("unexpected names %s %s", schema.ID, schema.PluralName)
+	}
+	// the generated name is remembered for the type
+	if s.SchemaFor(getType(importFirst{})) != schema {
+		t.Fatal("expected the schema to be found by type")
+	}
+}
+
+func TestImportCollision(t *testing.T) {
+	sameName := func(string) string { return "widget" }
+
+	tests := []struct {
+		name     string
+		strategy CollisionStrategy
+		id       string
+		err      bool
+	}{
+		{name: "reuse", strategy: CollisionReuse, id: "widget"},
+		{name: "error", strategy: CollisionError, err: true},
+		{name: "suffix", strategy: CollisionSuffix, id: "widget2"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := EmptySchemas()
+			first, err := s.ImportWithOptions(importFirst{}, ImportOptions{NameFunc: sameName})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			second, err := s.ImportWithOptions(importSecond{}, ImportOptions{NameFunc: sameName, OnCollision: tt.strategy})
+			if tt.err {
+				if err == nil {
+					t.Fatal("expected error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if second.ID != tt.id {
+				t.Fatalf("expected ID %s, got %s", tt.id, second.ID)
+			}
+			if tt.strategy == CollisionReuse && second != first {
+				t.Fatal("expected the existing schema to be returned")
+			}
+			if tt.strategy != CollisionReuse && (second.CodeName != "importSecond" || first.CodeName != "importFirst") {
+				t.Fatalf("expected two schemas, got %s and %s", first.CodeName, second.CodeName)
+			}
+		})
+	}
+}
+
+type ImportBase struct {
+	Name string `json:"name"`
+}
+
+// ImportOther names its fields with yaml tags, vet rejects repeated json
+// names in embedded structs.
+type ImportOther struct {
+	Name  string `yaml:"name"`
+	Owner string `yaml:"owner"`
+}
+
+type importEmbedding struct {
+	ImportBase
+	Image string `json:"image"`
+}
+
+type importColliding struct {
+	ImportBase
+	ImportOther
+}
+
+func TestImportEmbedded(t *testing.T) {
+	tests := []struct {
+		name     string
+		strategy EmbeddedStrategy
+		fields   []string
+	}{
+		{name: "squash", strategy: EmbedSquash, fields: []string{"name", "image"}},
+		{name: "sub-object", strategy: EmbedSubObject, fields: []string{"importBase", "image"}},
+		{name: "skip", strategy: EmbedSkip, fields: []string{"image"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			schema, err := EmptySchemas().ImportWithOptions(importEmbedding{}, ImportOptions{Embedded: tt.strategy})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if fields := schema.OrderedFieldNames(); !reflect.DeepEqual(fields, tt.fields) {
+				t.Fatalf("expected fields %v, got %v", tt.fields, fields)
+			}
+		})
+	}
+}
+
+func TestImportFieldCollision(t *testing.T) {
+	opts := ImportOptions{TagNames: []string{"json", "yaml"}}
+	schema, err := EmptySchemas().ImportWithOptions(importColliding{}, opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if fields := schema.OrderedFieldNames(); !reflect.DeepEqual(fields, []string{"name", "owner"}) {
+		t.Fatalf("expected fields name and owner, got %v", fields)
+	}
+
+	opts.ErrorOnFieldCollision = true
+	_, err = EmptySchemas().ImportWithOptions(importColliding{}, opts)
+	if err == nil || !strings.Contains(err.Error(), "field importColliding.name") {
+		t.Fatalf("expected collision error for name, got %v", err)
+	}
+}
+
+type importYAML struct {
+	Name    string `yaml:"displayName" json:"name"`
+	Skipped string `yaml:"-"`
+	Image   string `json:"image"`
+}
+
+func TestImportTagNames(t *testing.T) {
+	schema, err := EmptySchemas().ImportWithOptions(importYAML{}, ImportOptions{TagNames: []string{"yaml", "json"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if fields := schema.OrderedFieldNames(); !reflect.DeepEqual(fields, []string{"displayName", "image"}) {
+		t.Fatalf("expected fields displayName and image, got %v", fields)
+	}
+
+	schema, err = EmptySchemas().Import(importYAML{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if fields := schema.OrderedFieldNames(); !reflect.DeepEqual(fields, []string{"name", "skipped", "image"}) {
+		t.Fatalf("expected json names by default, got %v", fields)
+	}
+}
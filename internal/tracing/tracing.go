@@ -0,0 +1,30 @@
+// Package tracing holds the OpenTelemetry helpers shared by the packages of
+// the module. Spans are only recorded if the application configures a tracer
+// provider with otel.SetTracerProvider.
+package tracing
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// Start starts a span with the tracer of the instrumentation tracerName.
+func Start(ctx context.Context, tracerName, spanName string, attrs ...attribute.KeyValue) (context.Context, trace.Span) {
+	return otel.Tracer(tracerName).Start(ctx, spanName, trace.WithAttributes(attrs...))
+}
+
+// End records err on span and ends it, span may be nil.
+func End(span trace.Span, err error) {
+	if span == nil {
+		return
+	}
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
@@ -0,0 +1,67 @@
+package kubestore
+
+import (
+	"fmt"
+
+	schemas "github.com/acorn-io/schemer"
+	"github.com/acorn-io/schemer/data/convert"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// Attributes of a schema that identify its Kubernetes resource.
+const (
+	AttributeGroup      = "group"
+	AttributeVersion    = "version"
+	AttributeKind       = "kind"
+	AttributeResource   = "resource"
+	AttributeNamespaced = "namespaced"
+)
+
+// Resource is the Kubernetes resource a schema is stored as.
+type Resource struct {
+	GroupVersionKind schema.GroupVersionKind
+	// Resource is the plural name of the resource, like "deployments".
+	Resource   string
+	Namespaced bool
+}
+
+func (r Resource) GroupVersionResource() schema.GroupVersionResource {
+	return r.GroupVersionKind.GroupVersion().WithResource(r.Resource)
+}
+
+// SetResource records the resource of schema in its attributes.
+func SetResource(s *schemas.Schema, r Resource) {
+	if s.Attributes == nil {
+		s.Attributes = map[string]interface{}{}
+	}
+	s.Attributes[AttributeGroup] = r.GroupVersionKind.Group
+	s.Attributes[AttributeVersion] = r.GroupVersionKind.Version
+	s.Attributes[AttributeKind] = r.GroupVersionKind.Kind
+	s.Attributes[AttributeResource] = r.Resource
+	s.Attributes[AttributeNamespaced] = r.Namespaced
+}
+
+// ResourceFor returns the resource recorded in the attributes of schema. The
+// kind defaults to the code name and the resource to the plural name of the
+// schema.
+func ResourceFor(s *schemas.Schema) (Resource, error) {
+	r := Resource{
+		GroupVersionKind: schema.GroupVersionKind{
+			Group:   convert.ToString(s.Attributes[AttributeGroup]),
+			Version: convert.ToString(s.Attributes[AttributeVersion]),
+			Kind:    convert.ToString(s.Attributes[AttributeKind]),
+		},
+		Resource:   convert.ToString(s.Attributes[AttributeResource]),
+		Namespaced: convert.ToBool(s.Attributes[AttributeNamespaced]),
+	}
+	if r.GroupVersionKind.Kind == "" {
+		r.GroupVersionKind.Kind = s.CodeName
+	}
+	if r.Resource == "" {
+		r.Resource = s.PluralName
+	}
+	if r.GroupVersionKind.Version == "" || r.Resource == "" || r.GroupVersionKind.Kind == "" {
+		return r, fmt.Errorf("schema %s has no Kubernetes resource, see SetResource", s.ID)
+	}
+	return r, nil
+}
@@ -0,0 +1,203 @@
+// Package kubestore implements schemas.Store with the Kubernetes dynamic
+// client. The resource of a schema is read from its attributes, see
+// SetResource.
+package kubestore
+
+import (
+	"context"
+
+	schemas "github.com/acorn-io/schemer"
+	"github.com/acorn-io/schemer/data"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/dynamic"
+)
+
+type Store struct {
+	client dynamic.Interface
+}
+
+var _ schemas.Store = (*Store)(nil)
+
+func New(client dynamic.Interface) *Store {
+	return &Store{
+		client: client,
+	}
+}
+
+func (s *Store) resource(schema *schemas.Schema, namespace string) (dynamic.ResourceInterface, Resource, error) {
+	r, err := ResourceFor(schema)
+	if err != nil {
+		return nil, r, err
+	}
+	client := s.client.Resource(r.GroupVersionResource())
+	if r.Namespaced && namespace != "" {
+		return client.Namespace(namespace), r, nil
+	}
+	return client, r, nil
+}
+
+func (s *Store) List(ctx context.Context, schema *schemas.Schema, opts schemas.ListOptions) (*schemas.ObjectList, error) {
+	client, _, err := s.resource(schema, opts.Namespace)
+	if err != nil {
+		return nil, err
+	}
+
+	list, err := client.List(ctx, metav1.ListOptions{
+		LabelSelector: opts.LabelSelector,
+		Limit:         opts.Limit,
+		Continue:      opts.Continue,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	result := &schemas.ObjectList{
+		Objects:  make(data.List, 0, len(list.Items)),
+		Continue: list.GetContinue(),
+		Revision: list.GetResourceVersion(),
+	}
+	for i := range list.Items {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		result.Objects = append(result.Objects, fromInternal(ctx, schema, &list.Items[i]))
+	}
+	return result, nil
+}
+
+func (s *Store) Get(ctx context.Context, schema *schemas.Schema, namespace, name string) (data.Object, error) {
+	client, _, err := s.resource(schema, namespace)
+	if err != nil {
+		return nil, err
+	}
+	obj, err := client.Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+	return fromInternal(ctx, schema, obj), nil
+}
+
+func (s *Store) Create(ctx context.Context, schema *schemas.Schema, obj data.Object) (data.Object, error) {
+	u, err := toInternal(ctx, schema, obj)
+	if err != nil {
+		return nil, err
+	}
+	client, r, err := s.resource(schema, u.GetNamespace())
+	if err != nil {
+		return nil, err
+	}
+	u.SetGroupVersionKind(r.GroupVersionKind)
+
+	created, err := client.Create(ctx, u, metav1.CreateOptions{})
+	if err != nil {
+		return nil, err
+	}
+	return fromInternal(ctx, schema, created), nil
+}
+
+func (s *Store) Update(ctx context.Context, schema *schemas.Schema, obj data.Object) (data.Object, error) {
+	u, err := toInternal(ctx, schema, obj)
+	if err != nil {
+		return nil, err
+	}
+	client, r, err := s.resource(schema, u.GetNamespace())
+	if err != nil {
+		return nil, err
+	}
+	u.SetGroupVersionKind(r.GroupVersionKind)
+
+	updated, err := client.Update(ctx, u, metav1.UpdateOptions{})
+	if err != nil {
+		return nil, err
+	}
+	return fromInternal(ctx, schema, updated), nil
+}
+
+func (s *Store) Delete(ctx context.Context, schema *schemas.Schema, namespace, name string) error {
+	client, _, err := s.resource(schema, namespace)
+	if err != nil {
+		return err
+	}
+	return client.Delete(ctx, name, metav1.DeleteOptions{})
+}
+
+func (s *Store) Watch(ctx context.Context, schema *schemas.Schema, opts schemas.WatchOptions) (<-chan schemas.WatchEvent, error) {
+	client, _, err := s.resource(schema, opts.Namespace)
+	if err != nil {
+		return nil, err
+	}
+
+	w, err := client.Watch(ctx, metav1.ListOptions{
+		LabelSelector:   opts.LabelSelector,
+		ResourceVersion: opts.Revision,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	result := make(chan schemas.WatchEvent)
+	go func() {
+		defer close(result)
+		defer w.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case event, ok := <-w.ResultChan():
+				if !ok {
+					return
+				}
+				watchEvent, ok := toWatchEvent(ctx, schema, event)
+				if !ok {
+					continue
+				}
+				select {
+				case result <- watchEvent:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}
+	}()
+	return result, nil
+}
+
+func toWatchEvent(ctx context.Context, schema *schemas.Schema, event watch.Event) (schemas.WatchEvent, bool) {
+	u, ok := event.Object.(*unstructured.Unstructured)
+	if !ok {
+		return schemas.WatchEvent{}, false
+	}
+
+	switch event.Type {
+	case watch.Added:
+		return schemas.WatchEvent{Type: schemas.WatchAdded, Object: fromInternal(ctx, schema, u)}, true
+	case watch.Modified:
+		return schemas.WatchEvent{Type: schemas.WatchModified, Object: fromInternal(ctx, schema, u)}, true
+	case watch.Deleted:
+		return schemas.WatchEvent{Type: schemas.WatchDeleted, Object: fromInternal(ctx, schema, u)}, true
+	case watch.Error:
+		return schemas.WatchEvent{Type: schemas.WatchError, Object: data.FromUnstructured(u)}, true
+	}
+	return schemas.WatchEvent{}, false
+}
+
+// fromInternal maps u in place to the external form of schema.
+func fromInternal(ctx context.Context, schema *schemas.Schema, u *unstructured.Unstructured) data.Object {
+	if schema.Mapper != nil {
+		schemas.FromInternalUnstructured(ctx, schema.Mapper, u)
+	}
+	return data.FromUnstructured(u)
+}
+
+// toInternal returns a copy of obj mapped to the stored form of schema.
+func toInternal(ctx context.Context, schema *schemas.Schema, obj data.Object) (*unstructured.Unstructured, error) {
+	u := obj.DeepCopy().ToUnstructured()
+	if schema.Mapper != nil {
+		if err := schemas.ToInternalUnstructured(ctx, schema.Mapper, u); err != nil {
+			return nil, err
+		}
+	}
+	return u, nil
+}
@@ -0,0 +1,72 @@
+package kubestore
+
+import (
+	"context"
+	"testing"
+
+	schemas "github.com/acorn-io/schemer"
+	"github.com/acorn-io/schemer/data"
+	"github.com/acorn-io/schemer/mappers"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic/fake"
+)
+
+func TestStore(t *testing.T) {
+	ctx := context.Background()
+	gvk := schema.GroupVersionKind{Group: "example.io", Version: "v1", Kind: "App"}
+	client := fake.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(), map[schema.GroupVersionResource]string{
+		gvk.GroupVersion().WithResource("apps"): "AppList",
+	})
+
+	appSchema := &schemas.Schema{
+		ID:     "app",
+		Mapper: mappers.Move{From: "spec/replicas", To: "scale"},
+	}
+	SetResource(appSchema, Resource{GroupVersionKind: gvk, Resource: "apps", Namespaced: true})
+
+	store := New(client)
+	created, err := store.Create(ctx, appSchema, data.Object{
+		"metadata": map[string]interface{}{
+			"name":      "test",
+			"namespace": "default",
+		},
+		"scale": 3,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if created.Int64("scale") != 3 {
+		t.Fatalf("expected scale 3, got %v", created)
+	}
+
+	stored, err := client.Resource(gvk.GroupVersion().WithResource("apps")).Namespace("default").Get(ctx, "test", metav1.GetOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if data.FromUnstructured(stored).Int64("spec", "replicas") != 3 || stored.GetKind() != "App" {
+		t.Fatalf("expected stored object with spec.replicas 3, got %v", stored.Object)
+	}
+
+	list, err := store.List(ctx, appSchema, schemas.ListOptions{Namespace: "default"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(list.Objects) != 1 || list.Objects[0].Int64("scale") != 3 {
+		t.Fatalf("expected one mapped object, got %v", list.Objects)
+	}
+
+	if err := store.Delete(ctx, appSchema, "default", "test"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := store.Get(ctx, appSchema, "default", "test"); err == nil {
+		t.Fatal("expected error getting deleted object")
+	}
+}
+
+func TestResourceFor(t *testing.T) {
+	if _, err := ResourceFor(&schemas.Schema{ID: "app"}); err == nil {
+		t.Fatal("expected error for schema without resource")
+	}
+}
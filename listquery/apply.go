@@ -0,0 +1,202 @@
+package listquery
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"hash/fnv"
+	"sort"
+	"strings"
+
+	"github.com/acorn-io/schemer/data"
+)
+
+var ErrInvalidContinue = errors.New("invalid continue token")
+
+type Result struct {
+	Objects data.List
+	// Continue is set if there are more objects, pass it as the continue
+	// parameter of the same query to get the next page.
+	Continue string
+	// Total is the number of objects matching the filters.
+	Total int
+}
+
+// Apply returns the objects matching the filters, sorted and limited to the
+// page of the query. objs is not modified.
+func (q *Query) Apply(objs data.List) (*Result, error) {
+	var matched data.List
+	for _, obj := range objs {
+		if q.Match(obj) {
+			matched = append(matched, obj)
+		}
+	}
+	return q.page(matched)
+}
+
+// Match returns true if obj matches all filters of the query.
+func (q *Query) Match(obj data.Object) bool {
+	for _, f := range q.Filters {
+		if !f.match(obj) {
+			return false
+		}
+	}
+	return true
+}
+
+func (f Filter) match(obj data.Object) bool {
+	found := values(map[string]interface{}(obj), f.path)
+	switch f.Modifier {
+	case Null:
+		return len(found) == 0
+	case NotNull:
+		return len(found) > 0
+	case Ne:
+		for _, v := range found {
+			if f.matchValue(Eq, v) {
+				return false
+			}
+		}
+		return true
+	}
+	for _, v := range found {
+		if f.matchValue(f.Modifier, v) {
+			return true
+		}
+	}
+	return false
+}
+
+func (f Filter) matchValue(modifier Modifier, v interface{}) bool {
+	v, ok := normalize(f.kind, v)
+	if !ok {
+		return false
+	}
+	for _, value := range f.values {
+		var match bool
+		switch modifier {
+		case Eq:
+			match = compare(v, value) == 0
+		case Lt:
+			match = compare(v, value) < 0
+		case Lte:
+			match = compare(v, value) <= 0
+		case Gt:
+			match = compare(v, value) > 0
+		case Gte:
+			match = compare(v, value) >= 0
+		case Prefix:
+			match = strings.HasPrefix(v.(string), value.(string))
+		}
+		if match {
+			return true
+		}
+	}
+	return false
+}
+
+func (q *Query) page(matched data.List) (*Result, error) {
+	q.sort(matched)
+
+	offset, err := q.offset()
+	if err != nil {
+		return nil, err
+	}
+
+	result := &Result{
+		Total: len(matched),
+	}
+	if offset >= len(matched) {
+		return result, nil
+	}
+	end := len(matched)
+	if q.Limit > 0 && offset+q.Limit < end {
+		end = offset + q.Limit
+		result.Continue = q.continueToken(end)
+	}
+	result.Objects = matched[offset:end]
+	return result, nil
+}
+
+func (q *Query) sort(objs data.List) {
+	if len(q.Sort) == 0 {
+		return
+	}
+
+	keys := make(map[int][]interface{}, len(objs))
+	for i, obj := range objs {
+		for _, key := range q.Sort {
+			var value interface{}
+			if found := values(map[string]interface{}(obj), key.path); len(found) > 0 {
+				value, _ = normalize(key.kind, found[0])
+			}
+			keys[i] = append(keys[i], value)
+		}
+	}
+
+	index := make([]int, len(objs))
+	for i := range index {
+		index[i] = i
+	}
+	sort.SliceStable(index, func(a, b int) bool {
+		for k, key := range q.Sort {
+			va, vb := keys[index[a]][k], keys[index[b]][k]
+			// missing values sort last in both directions
+			switch {
+			case va == nil && vb == nil:
+				continue
+			case va == nil:
+				return false
+			case vb == nil:
+				return true
+			}
+			cmp := compare(va, vb)
+			if key.Desc {
+				cmp = -cmp
+			}
+			if cmp != 0 {
+				return cmp < 0
+			}
+		}
+		return false
+	})
+
+	sorted := make(data.List, len(objs))
+	for i, j := range index {
+		sorted[i] = objs[j]
+	}
+	copy(objs, sorted)
+}
+
+type continueToken struct {
+	Offset int    `json:"o"`
+	Query  uint32 `json:"q"`
+}
+
+func (q *Query) hash() uint32 {
+	h := fnv.New32a()
+	_, _ = h.Write([]byte(q.String()))
+	return h.Sum32()
+}
+
+func (q *Query) continueToken(offset int) string {
+	b, _ := json.Marshal(continueToken{Offset: offset, Query: q.hash()})
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+// offset returns the offset of the page in the continue token, the token must
+// have been returned for the same filters and sort keys.
+func (q *Query) offset() (int, error) {
+	if q.Continue == "" {
+		return 0, nil
+	}
+	b, err := base64.RawURLEncoding.DecodeString(q.Continue)
+	if err != nil {
+		return 0, ErrInvalidContinue
+	}
+	var token continueToken
+	if err := json.Unmarshal(b, &token); err != nil || token.Offset < 0 || token.Query != q.hash() {
+		return 0, ErrInvalidContinue
+	}
+	return token.Offset, nil
+}
@@ -0,0 +1,134 @@
+package listquery
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	schemas "github.com/acorn-io/schemer"
+	"github.com/acorn-io/schemer/data"
+)
+
+// Index maps the values of fields to the positions of the objects that have
+// them, so equality filters on large lists only evaluate the objects with a
+// matching value. An Index is read-only and safe for concurrent queries, build
+// a new one when the list changes.
+type Index struct {
+	objects data.List
+	fields  map[string]fieldIndex
+}
+
+type fieldIndex struct {
+	kind      valueKind
+	positions map[interface{}][]int
+}
+
+// NewIndex indexes the values of the given fields of objs, fields are dotted
+// paths like the fields of filters.
+func NewIndex(s *schemas.Schemas, schema *schemas.Schema, objs data.List, fields ...string) (*Index, error) {
+	idx := &Index{
+		objects: objs,
+		fields:  map[string]fieldIndex{},
+	}
+	for _, field := range fields {
+		kind, ok := fieldKind(s, schema, field)
+		if !ok {
+			return nil, fmt.Errorf("can't index unknown field %s", field)
+		}
+		if kind == objectKind {
+			return nil, fmt.Errorf("can't index object field %s", field)
+		}
+		fi := fieldIndex{
+			kind:      kind,
+			positions: map[interface{}][]int{},
+		}
+		path := strings.Split(field, ".")
+		for i, obj := range objs {
+			seen := map[interface{}]bool{}
+			for _, v := range values(map[string]interface{}(obj), path) {
+				key, ok := normalize(kind, v)
+				if !ok || seen[key] {
+					continue
+				}
+				seen[key] = true
+				fi.positions[key] = append(fi.positions[key], i)
+			}
+		}
+		idx.fields[field] = fi
+	}
+	return idx, nil
+}
+
+// ApplyIndex is like Apply for the indexed objects. Equality filters on
+// indexed fields select the candidates, the remaining filters are evaluated
+// only against them.
+func (q *Query) ApplyIndex(idx *Index) (*Result, error) {
+	candidates, ok := q.candidates(idx)
+	if !ok {
+		return q.Apply(idx.objects)
+	}
+
+	var matched data.List
+	for _, i := range candidates {
+		if obj := idx.objects[i]; q.Match(obj) {
+			matched = append(matched, obj)
+		}
+	}
+	return q.page(matched)
+}
+
+// candidates returns the sorted positions of the objects matching all equality
+// filters on indexed fields, ok is false if there are no such filters.
+func (q *Query) candidates(idx *Index) ([]int, bool) {
+	var (
+		result []int
+		found  bool
+	)
+	for _, f := range q.Filters {
+		fi, indexed := idx.fields[f.Field]
+		if !indexed || f.Modifier != Eq || fi.kind != f.kind {
+			continue
+		}
+
+		var positions []int
+		for _, value := range f.values {
+			positions = append(positions, fi.positions[value]...)
+		}
+		positions = unique(positions)
+
+		if !found {
+			result, found = positions, true
+		} else {
+			result = intersect(result, positions)
+		}
+	}
+	return result, found
+}
+
+func unique(positions []int) []int {
+	sort.Ints(positions)
+	result := positions[:0]
+	for i, p := range positions {
+		if i == 0 || p != positions[i-1] {
+			result = append(result, p)
+		}
+	}
+	return result
+}
+
+func intersect(a, b []int) []int {
+	var result []int
+	for i, j := 0, 0; i < len(a) && j < len(b); {
+		switch {
+		case a[i] < b[j]:
+			i++
+		case a[i] > b[j]:
+			j++
+		default:
+			result = append(result, a[i])
+			i++
+			j++
+		}
+	}
+	return result
+}
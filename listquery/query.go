@@ -0,0 +1,339 @@
+// Package listquery filters, sorts and paginates collections of mapped
+// objects with list parameters like
+//
+//	?spec.replicas_gte=2&name_prefix=web&sort=-spec.replicas,name&limit=10
+//
+// Parameters are checked against the fields of a schema and filter values are
+// converted to the field types, so "replicas_gt=10" compares numbers.
+package listquery
+
+import (
+	"fmt"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+
+	schemas "github.com/acorn-io/schemer"
+	"github.com/acorn-io/schemer/data/convert"
+	"github.com/acorn-io/schemer/definition"
+)
+
+// Names of the parameters that aren't filters.
+const (
+	ParamSort     = "sort"
+	ParamLimit    = "limit"
+	ParamContinue = "continue"
+)
+
+// Modifier is the operator of a filter, it's appended to the field name with
+// an underscore, like "name_ne=test". Filters without a modifier use Eq.
+type Modifier string
+
+const (
+	Eq      Modifier = "eq"
+	Ne      Modifier = "ne"
+	Lt      Modifier = "lt"
+	Lte     Modifier = "lte"
+	Gt      Modifier = "gt"
+	Gte     Modifier = "gte"
+	Prefix  Modifier = "prefix"
+	Null    Modifier = "null"
+	NotNull Modifier = "notnull"
+)
+
+var modifiers = []Modifier{Eq, Ne, Lt, Lte, Gt, Gte, Prefix, Null, NotNull}
+
+type valueKind int
+
+const (
+	stringKind valueKind = iota
+	numberKind
+	boolKind
+	// objectKind fields can only be filtered with null and notnull
+	objectKind
+)
+
+// Filter matches objects whose field compares to one of the values with the
+// modifier. Fields of arrays match if any item matches.
+type Filter struct {
+	Field    string
+	Modifier Modifier
+	Values   []string
+
+	path   []string
+	kind   valueKind
+	values []interface{}
+}
+
+type SortKey struct {
+	Field string
+	Desc  bool
+
+	path []string
+	kind valueKind
+}
+
+type Query struct {
+	Filters []Filter
+	Sort    []SortKey
+	// Limit is the maximum number of objects returned, 0 returns all.
+	Limit    int
+	Continue string
+}
+
+// Parse returns the query of the list parameters in values. Parameters that
+// don't name a field of schema, like "namespace", are ignored. Nested fields
+// are separated by dots, like "spec.replicas".
+func Parse(s *schemas.Schemas, schema *schemas.Schema, values url.Values) (*Query, error) {
+	q := &Query{}
+
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		switch key {
+		case ParamSort:
+			for _, value := range values[key] {
+				for _, field := range strings.Split(value, ",") {
+					if field == "" {
+						continue
+					}
+					key := SortKey{Field: strings.TrimPrefix(field, "-"), Desc: strings.HasPrefix(field, "-")}
+					kind, ok := fieldKind(s, schema, key.Field)
+					if !ok {
+						return nil, fmt.Errorf("invalid sort: unknown field %s", key.Field)
+					}
+					if kind == objectKind {
+						return nil, fmt.Errorf("invalid sort: can't sort by object field %s", key.Field)
+					}
+					key.path, key.kind = strings.Split(key.Field, "."), kind
+					q.Sort = append(q.Sort, key)
+				}
+			}
+		case ParamLimit:
+			limit, err := strconv.Atoi(values.Get(key))
+			if err != nil || limit < 0 {
+				return nil, fmt.Errorf("invalid limit %q", values.Get(key))
+			}
+			q.Limit = limit
+		case ParamContinue:
+			q.Continue = values.Get(key)
+		default:
+			filter, ok, err := parseFilter(s, schema, key, values[key])
+			if err != nil {
+				return nil, err
+			}
+			if ok {
+				q.Filters = append(q.Filters, filter)
+			}
+		}
+	}
+
+	return q, nil
+}
+
+func parseFilter(s *schemas.Schemas, schema *schemas.Schema, key string, values []string) (Filter, bool, error) {
+	filter := Filter{Field: key, Modifier: Eq, Values: values}
+	kind, ok := fieldKind(s, schema, key)
+	if !ok {
+		i := strings.LastIndexByte(key, '_')
+		if i < 0 {
+			return filter, false, nil
+		}
+		if kind, ok = fieldKind(s, schema, key[:i]); !ok {
+			return filter, false, nil
+		}
+		filter.Field, filter.Modifier = key[:i], Modifier(key[i+1:])
+		if !isModifier(filter.Modifier) {
+			return filter, false, fmt.Errorf("invalid filter %s: unknown modifier %s", key, filter.Modifier)
+		}
+	}
+	filter.path, filter.kind = strings.Split(filter.Field, "."), kind
+
+	if filter.Modifier == Null || filter.Modifier == NotNull {
+		return filter, true, nil
+	}
+	if filter.kind == objectKind {
+		return filter, false, fmt.Errorf("invalid filter %s: object fields only support %s and %s", key, Null, NotNull)
+	}
+	if filter.Modifier == Prefix {
+		filter.kind = stringKind
+	}
+	for _, value := range values {
+		v, err := parseValue(filter.kind, value)
+		if err != nil {
+			return filter, false, fmt.Errorf("invalid filter %s: %w", key, err)
+		}
+		filter.values = append(filter.values, v)
+	}
+	return filter, true, nil
+}
+
+func isModifier(m Modifier) bool {
+	for _, modifier := range modifiers {
+		if m == modifier {
+			return true
+		}
+	}
+	return false
+}
+
+func parseValue(kind valueKind, value string) (interface{}, error) {
+	switch kind {
+	case numberKind:
+		f, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return nil, fmt.Errorf("%q is not a number", value)
+		}
+		return f, nil
+	case boolKind:
+		b, err := strconv.ParseBool(value)
+		if err != nil {
+			return nil, fmt.Errorf("%q is not a boolean", value)
+		}
+		return b, nil
+	}
+	return value, nil
+}
+
+// fieldKind resolves the dotted path of a field through the schemas of
+// nested objects and returns how its values are compared. Paths into json
+// fields are not checked.
+func fieldKind(s *schemas.Schemas, schema *schemas.Schema, path string) (valueKind, bool) {
+	parts := strings.Split(path, ".")
+	for i := 0; i < len(parts); i++ {
+		if schema == nil {
+			return stringKind, false
+		}
+		field, ok := schema.ResourceFields[parts[i]]
+		if !ok {
+			return stringKind, false
+		}
+		t, err := definition.Parse(field.Type)
+		if err != nil {
+			return stringKind, false
+		}
+
+		t = t.NonNullable()
+		for t.IsContainer() {
+			if t.IsMap() {
+				// the next part is a key of the map
+				if i+1 == len(parts) {
+					return objectKind, true
+				}
+				i++
+			}
+			t = t.Elem.NonNullable()
+		}
+
+		_, builtin := definition.Lookup(t.Name)
+		switch {
+		case t.Name == "json":
+			if i == len(parts)-1 {
+				return objectKind, true
+			}
+			return stringKind, true
+		case builtin:
+			return kindOf(t), i == len(parts)-1
+		case i == len(parts)-1:
+			return objectKind, true
+		}
+		schema = s.Schema(t.Name)
+	}
+	return stringKind, false
+}
+
+func kindOf(t *definition.Type) valueKind {
+	switch t.Name {
+	case "int", "float":
+		return numberKind
+	case "boolean":
+		return boolKind
+	}
+	return stringKind
+}
+
+// values returns the values of the field at path, the items of arrays on the
+// way are flattened.
+func values(v interface{}, path []string) []interface{} {
+	if items, ok := v.([]interface{}); ok {
+		var result []interface{}
+		for _, item := range items {
+			result = append(result, values(item, path)...)
+		}
+		return result
+	}
+	if len(path) == 0 {
+		if v == nil {
+			return nil
+		}
+		return []interface{}{v}
+	}
+	m := convert.ToMapInterface(v)
+	if m == nil {
+		return nil
+	}
+	return values(m[path[0]], path[1:])
+}
+
+// normalize converts a value of an object to the kind of its field, ok is
+// false if it can't be converted.
+func normalize(kind valueKind, v interface{}) (interface{}, bool) {
+	switch kind {
+	case numberKind:
+		f, err := convert.ToFloat(v)
+		return f, err == nil
+	case boolKind:
+		b, err := convert.ToBoolE(v)
+		return b, err == nil
+	}
+	return convert.ToString(v), true
+}
+
+func compare(a, b interface{}) int {
+	switch a := a.(type) {
+	case float64:
+		b, _ := b.(float64)
+		switch {
+		case a < b:
+			return -1
+		case a > b:
+			return 1
+		}
+		return 0
+	case bool:
+		b, _ := b.(bool)
+		switch {
+		case a == b:
+			return 0
+		case !a:
+			return -1
+		}
+		return 1
+	case string:
+		b, _ := b.(string)
+		return strings.Compare(a, b)
+	}
+	return 0
+}
+
+// String returns the canonical form of the filters and sort keys, it's used
+// to check that a continue token belongs to the query.
+func (q *Query) String() string {
+	var parts []string
+	for _, f := range q.Filters {
+		parts = append(parts, f.Field+"_"+string(f.Modifier)+"="+strings.Join(f.Values, "|"))
+	}
+	for _, s := range q.Sort {
+		if s.Desc {
+			parts = append(parts, "sort=-"+s.Field)
+		} else {
+			parts = append(parts, "sort="+s.Field)
+		}
+	}
+	return strings.Join(parts, "&")
+}
@@ -0,0 +1,145 @@
+package listquery
+
+import (
+	"errors"
+	"net/url"
+	"strings"
+	"testing"
+
+	schemas "github.com/acorn-io/schemer"
+	"github.com/acorn-io/schemer/data"
+)
+
+type querySpec struct {
+	Replicas int      `json:"replicas"`
+	Paused   bool     `json:"paused"`
+	Tags     []string `json:"tags"`
+}
+
+type queryApp struct {
+	Name string    `json:"name"`
+	Spec querySpec `json:"spec"`
+}
+
+func parse(t *testing.T, query string) (*Query, *schemas.Schemas, *schemas.Schema) {
+	s := schemas.EmptySchemas()
+	schema, err := s.Import(queryApp{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	values, err := url.ParseQuery(query)
+	if err != nil {
+		t.Fatal(err)
+	}
+	q, err := Parse(s, schema, values)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return q, s, schema
+}
+
+func apps() data.List {
+	app := func(name string, replicas int, tags ...interface{}) data.Object {
+		return data.Object{
+			"name": name,
+			"spec": map[string]interface{}{"replicas": replicas, "tags": tags},
+		}
+	}
+	return data.List{
+		app("web", 3, "frontend"),
+		app("api", 10, "backend"),
+		app("worker", 1, "backend", "batch"),
+		{"name": "empty"},
+	}
+}
+
+func names(objs data.List) []string {
+	var result []string
+	for _, obj := range objs {
+		result = append(result, obj.String("name"))
+	}
+	return result
+}
+
+func TestQuery(t *testing.T) {
+	for query, expected := range map[string]string{
+		"spec.replicas_gt=2&sort=name":            "api,web",
+		"spec.replicas_lte=3&sort=-spec.replicas": "web,worker",
+		"spec.tags=backend&sort=spec.replicas":    "worker,api",
+		"spec.tags_ne=backend&sort=name":          "empty,web",
+		"name_prefix=w&sort=name":                 "web,worker",
+		"spec_null=true":                          "empty",
+		"sort=spec.replicas":                      "worker,web,api,empty",
+		"namespace=default&name=api":              "api",
+	} {
+		q, _, _ := parse(t, query)
+		result, err := q.Apply(apps())
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := names(result.Objects); strings.Join(got, ",") != expected {
+			t.Errorf("%s: expected %s, got %v", query, expected, got)
+		}
+	}
+}
+
+func TestInvalidQuery(t *testing.T) {
+	s := schemas.EmptySchemas()
+	schema, err := s.Import(queryApp{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, query := range []string{
+		"spec.replicas=many",
+		"name_like=w",
+		"sort=missing",
+		"limit=-1",
+		"spec=x",
+	} {
+		values, _ := url.ParseQuery(query)
+		if _, err := Parse(s, schema, values); err == nil {
+			t.Errorf("%s: expected error", query)
+		}
+	}
+}
+
+func TestPagination(t *testing.T) {
+	q, _, _ := parse(t, "sort=name&limit=3")
+	result, err := q.Apply(apps())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Join(names(result.Objects), ",") != "api,empty,web" || result.Continue == "" || result.Total != 4 {
+		t.Fatalf("unexpected first page %v %q", names(result.Objects), result.Continue)
+	}
+
+	q.Continue = result.Continue
+	result, err = q.Apply(apps())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Join(names(result.Objects), ",") != "worker" || result.Continue != "" {
+		t.Fatalf("unexpected second page %v %q", names(result.Objects), result.Continue)
+	}
+
+	other, _, _ := parse(t, "sort=-name&limit=3")
+	other.Continue = q.Continue
+	if _, err := other.Apply(apps()); !errors.Is(err, ErrInvalidContinue) {
+		t.Fatalf("expected invalid continue token, got %v", err)
+	}
+}
+
+func TestIndex(t *testing.T) {
+	q, s, schema := parse(t, "spec.tags=backend&spec.replicas=10")
+	idx, err := NewIndex(s, schema, apps(), "spec.tags", "spec.replicas")
+	if err != nil {
+		t.Fatal(err)
+	}
+	result, err := q.ApplyIndex(idx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Join(names(result.Objects), ",") != "api" {
+		t.Fatalf("expected api, got %v", names(result.Objects))
+	}
+}
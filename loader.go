@@ -0,0 +1,78 @@
+package schemas
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/acorn-io/schemer/definition"
+)
+
+// SchemaLoader returns the schema for name or nil if the loader doesn't know
+// the schema.
+type SchemaLoader func(name string) (*Schema, error)
+
+// isBuiltinType returns true for the types registered in the definition
+// package, like "string" and "array".
+func isBuiltinType(name string) bool {
+	_, ok := definition.Lookup(name)
+	return ok
+}
+
+// AddLoader registers a loader that is called when a schema that is not in
+// the registry is looked up. Loaders are called in the order they were added
+// and the first schema returned is added to the registry.
+func (s *Schemas) AddLoader(loader SchemaLoader) *Schemas {
+	s.Lock()
+	defer s.Unlock()
+	s.loaders = append(s.loaders, loader)
+	return s
+}
+
+// LoadSchema looks up the schema by name and falls back to the registered
+// loaders if it isn't found.
+func (s *Schemas) LoadSchema(name string) (*Schema, error) {
+	if schema := s.doSchema(name, true); schema != nil {
+		return schema, nil
+	}
+
+	if t, err := definition.Parse(name); err == nil && isBuiltinType(t.Name) {
+		return nil, nil
+	}
+
+	s.Lock()
+	if len(s.loaders) == 0 || s.loading[name] {
+		s.Unlock()
+		return nil, nil
+	}
+	if s.loading == nil {
+		s.loading = map[string]bool{}
+	}
+	s.loading[name] = true
+	loaders := slices.Clone(s.loaders)
+	s.Unlock()
+
+	defer func() {
+		s.Lock()
+		delete(s.loading, name)
+		s.Unlock()
+	}()
+
+	for _, loader := range loaders {
+		schema, err := loader(name)
+		if err != nil {
+			return nil, err
+		}
+		if schema == nil {
+			continue
+		}
+		if schema.ID == "" {
+			schema.ID = name
+		}
+		if err := s.AddSchema(*schema); err != nil {
+			return nil, fmt.Errorf("failed to add loaded schema %s: %w", name, err)
+		}
+		return s.doSchema(schema.ID, true), nil
+	}
+
+	return nil, nil
+}
@@ -0,0 +1,70 @@
+package schemas
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestLoadSchema(t *testing.T) {
+	var calls []string
+	s := EmptySchemas()
+	s.AddLoader(func(name string) (*Schema, error) {
+		calls = append(calls, name)
+		switch name {
+		case "widget":
+			return &Schema{ResourceFields: map[string]Field{"part": {Type: "part"}}}, nil
+		case "part":
+			return &Schema{ID: "part"}, nil
+		case "broken":
+			return nil, errors.New("broken")
+		}
+		return nil, nil
+	})
+
+	schema, err := s.LoadSchema("widget")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if schema == nil || schema.ID != "widget" {
+		t.Fatalf("expected widget to be loaded, got %v", schema)
+	}
+	// loaded schemas are added to the registry, so the loader is not called
+	// again
+	if s.Schema("widget") != schema || s.Schema("part") == nil {
+		t.Fatal("expected widget and part to be in the registry")
+	}
+	calls = nil
+	s.Schema("widget")
+	if len(calls) != 0 {
+		t.Fatalf("expected no loader calls for a loaded schema, got %v", calls)
+	}
+
+	// builtin types are never loaded
+	if schema, err := s.LoadSchema("string"); err != nil || schema != nil || len(calls) != 0 {
+		t.Fatalf("expected builtin type to be skipped, got %v %v %v", schema, err, calls)
+	}
+
+	if schema, err := s.LoadSchema("unknown"); err != nil || schema != nil {
+		t.Fatalf("expected nil for an unknown schema, got %v %v", schema, err)
+	}
+	if _, err := s.LoadSchema("broken"); err == nil {
+		t.Fatal("expected the error of the loader")
+	}
+}
+
+func TestLoadSchemaOrder(t *testing.T) {
+	s := EmptySchemas()
+	s.AddLoader(func(name string) (*Schema, error) {
+		return nil, nil
+	})
+	s.AddLoader(func(name string) (*Schema, error) {
+		return &Schema{ID: name, Description: "second"}, nil
+	})
+	s.AddLoader(func(name string) (*Schema, error) {
+		return &Schema{ID: name, Description: "third"}, nil
+	})
+
+	if schema := s.Schema("widget"); schema == nil || schema.Description != "second" {
+		t.Fatalf("expected the first loader returning a schema to win, got %v", schema)
+	}
+}
@@ -1,7 +1,12 @@
 package schemas
 
 import (
+	"context"
 	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"sort"
 
 	"github.com/acorn-io/schemer/data"
 	"github.com/acorn-io/schemer/data/convert"
@@ -14,18 +19,86 @@ type Mapper interface {
 	ModifySchema(schema *Schema, schemas *Schemas) error
 }
 
+// ContextMapper is implemented by mappers that need a context.Context while
+// mapping, for example to resolve references or check authorization. Mappers
+// that only implement Mapper are still invoked through the context-aware
+// pipeline, they just don't receive the context.
+type ContextMapper interface {
+	Mapper
+	FromInternalContext(ctx context.Context, data data.Object)
+	ToInternalContext(ctx context.Context, data data.Object) error
+}
+
+// CloneableMapper is implemented by mappers that keep state computed in
+// ModifySchema. Schemas.Clone gives the clone its own copy of them, so
+// modifying schemas of the clone doesn't change the state used by the
+// original.
+type CloneableMapper interface {
+	Mapper
+	CloneMapper() Mapper
+}
+
+// FromInternalContext calls the context-aware variant of the mapper if it has
+// one and falls back to FromInternal otherwise.
+func FromInternalContext(ctx context.Context, mapper Mapper, data data.Object) {
+	if m, ok := mapper.(ContextMapper); ok {
+		m.FromInternalContext(ctx, data)
+		return
+	}
+	mapper.FromInternal(data)
+}
+
+// ToInternalContext calls the context-aware variant of the mapper if it has
+// one and falls back to ToInternal otherwise.
+func ToInternalContext(ctx context.Context, mapper Mapper, data data.Object) error {
+	if m, ok := mapper.(ContextMapper); ok {
+		return m.ToInternalContext(ctx, data)
+	}
+	return mapper.ToInternal(data)
+}
+
+// MapperPhase orders the mappers registered for a schema. DefaultMapper always
+// runs before and DefaultPostMapper after all phases.
+type MapperPhase int
+
+const (
+	PhasePre MapperPhase = iota
+	PhaseMain
+	PhasePost
+)
+
+type phasedMapper struct {
+	phase  MapperPhase
+	mapper Mapper
+	ref    *mapperRef
+}
+
 type Mappers []Mapper
 
 func (m Mappers) FromInternal(data data.Object) {
+	m.FromInternalContext(context.Background(), data)
+}
+
+func (m Mappers) FromInternalContext(ctx context.Context, data data.Object) {
 	for _, mapper := range m {
-		mapper.FromInternal(data)
+		if ctx.Err() != nil {
+			return
+		}
+		traceFromInternal(ctx, mapper, data)
 	}
 }
 
 func (m Mappers) ToInternal(data data.Object) error {
+	return m.ToInternalContext(context.Background(), data)
+}
+
+func (m Mappers) ToInternalContext(ctx context.Context, data data.Object) error {
 	var errs []error
 	for i := len(m) - 1; i >= 0; i-- {
-		errs = append(errs, m[i].ToInternal(data))
+		if err := ctx.Err(); err != nil {
+			return errors.Join(append(errs, err)...)
+		}
+		errs = addError(errs, traceToInternal(ctx, m[i], data))
 	}
 	return errors.Join(errs...)
 }
@@ -39,42 +112,168 @@ func (m Mappers) ModifySchema(schema *Schema, schemas *Schemas) error {
 	return nil
 }
 
-type typeMapper struct {
-	Mappers         []Mapper
-	root            bool
-	typeName        string
-	subSchemas      map[string]*Schema
-	subArraySchemas map[string]*Schema
-	subMapSchemas   map[string]*Schema
+type fieldKind int
+
+const (
+	arrayField fieldKind = iota
+	mapField
+)
+
+// fieldOp is a precomputed step of a typeMapper, it applies the mapper of the
+// sub-schema to the objects stored in a single field.
+type fieldOp struct {
+	name string
+	// containers are the arrays and maps the objects are nested in, from the
+	// outermost to the innermost.
+	containers []fieldKind
+	schema     *Schema
 }
 
-func (t *typeMapper) FromInternal(data data.Object) {
-	for fieldName, schema := range t.subSchemas {
-		if schema.Mapper == nil {
-			continue
+// parseFieldType returns the type of the values of fieldType and the arrays
+// and maps they are nested in.
+func parseFieldType(fieldType string) (*definition.Type, []fieldKind, error) {
+	t, err := definition.Parse(fieldType)
+	if err != nil {
+		return nil, nil, err
+	}
+	elem, containers := t.Unwrap()
+	kinds := make([]fieldKind, 0, len(containers))
+	for _, container := range containers {
+		if container.IsArray() {
+			kinds = append(kinds, arrayField)
+		} else {
+			kinds = append(kinds, mapField)
 		}
-		schema.Mapper.FromInternal(data.Map(fieldName))
 	}
+	return elem, kinds, nil
+}
 
-	for fieldName, schema := range t.subMapSchemas {
-		if schema.Mapper == nil {
-			continue
+// checkFieldType fails if fieldType doesn't parse or its values have a type
+// that is a misspelling of a builtin type, like "strng", and not a schema.
+func checkFieldType(schemas *Schemas, fieldType string) error {
+	t, err := definition.Parse(fieldType)
+	if err != nil {
+		return err
+	}
+
+	elem, containers := t.Unwrap()
+	if elem.IsReference() || isBuiltinType(elem.Name) {
+		return nil
+	}
+	suggestion := definition.Suggest(elem.Name, definition.Names())
+	if suggestion == "" || schemas.Schema(elem.Name) != nil {
+		return nil
+	}
+
+	pos := 0
+	for _, container := range containers {
+		pos += len(container.Name) + 1
+	}
+	return &definition.ParseError{
+		Type:       fieldType,
+		Pos:        pos,
+		Msg:        "unknown type " + elem.Name,
+		Suggestion: suggestion,
+	}
+}
+
+// mapNested calls fn for the values nested in value through containers and
+// replaces them with the result. It returns the first error of fn.
+func mapNested(value interface{}, containers []fieldKind, fn func(interface{}) (interface{}, error)) (interface{}, error) {
+	if len(containers) == 0 {
+		return fn(value)
+	}
+
+	switch containers[0] {
+	case arrayField:
+		items, ok := value.([]interface{})
+		if !ok {
+			strs := convert.ToStringSlice(value)
+			if strs == nil {
+				return value, nil
+			}
+			items = make([]interface{}, len(strs))
+			for i, str := range strs {
+				items[i] = str
+			}
+		}
+		for i, item := range items {
+			v, err := mapNested(item, containers[1:], fn)
+			if err != nil {
+				return nil, err
+			}
+			items[i] = v
+		}
+		return items, nil
+	case mapField:
+		m, ok := value.(map[string]interface{})
+		if !ok {
+			return value, nil
 		}
-		for _, fieldData := range data.Map(fieldName).Values() {
-			schema.Mapper.FromInternal(fieldData)
+		for key, item := range m {
+			v, err := mapNested(item, containers[1:], fn)
+			if err != nil {
+				return nil, err
+			}
+			m[key] = v
 		}
 	}
+	return value, nil
+}
 
-	for fieldName, schema := range t.subArraySchemas {
-		if schema.Mapper == nil {
-			continue
+type typeMapper struct {
+	Mappers    []Mapper
+	root       bool
+	typeName   string
+	plan       []fieldOp
+	references map[string]reference
+	enums      map[string]enumField
+	schemas    *Schemas
+}
+
+func (t *typeMapper) deepCopy(owner *Schemas, schemas map[*Schema]*Schema, cloneMapper func(Mapper) Mapper) *typeMapper {
+	plan := slices.Clone(t.plan)
+	for i, op := range plan {
+		if schemaCopy, ok := schemas[op.schema]; ok {
+			plan[i].schema = schemaCopy
 		}
-		for _, fieldData := range data.Slice(fieldName) {
-			schema.Mapper.FromInternal(fieldData)
+	}
+
+	mappers := make([]Mapper, len(t.Mappers))
+	for i, mapper := range t.Mappers {
+		mappers[i] = cloneMapper(mapper)
+	}
+
+	return &typeMapper{
+		Mappers:    mappers,
+		root:       t.root,
+		typeName:   t.typeName,
+		plan:       plan,
+		references: maps.Clone(t.references),
+		enums:      maps.Clone(t.enums),
+		schemas:    owner,
+	}
+}
+
+func (t *typeMapper) FromInternal(data data.Object) {
+	t.FromInternalContext(context.Background(), data)
+}
+
+func (t *typeMapper) FromInternalContext(ctx context.Context, obj data.Object) {
+	for _, op := range t.plan {
+		mapper := op.schema.Mapper
+		if mapper == nil {
+			continue
 		}
+
+		_, _ = mapNested(obj[op.name], op.containers, func(value interface{}) (interface{}, error) {
+			fieldData, _ := value.(map[string]interface{})
+			FromInternalContext(ctx, mapper, fieldData)
+			return value, nil
+		})
 	}
 
-	Mappers(t.Mappers).FromInternal(data)
+	Mappers(t.Mappers).FromInternalContext(ctx, obj)
 }
 
 func addError(errors []error, err error) []error {
@@ -85,63 +284,85 @@ func addError(errors []error, err error) []error {
 }
 
 func (t *typeMapper) ToInternal(data data.Object) error {
+	return t.ToInternalContext(context.Background(), data)
+}
+
+func (t *typeMapper) ToInternalContext(ctx context.Context, obj data.Object) error {
 	var errs []error
-	errs = addError(errs, Mappers(t.Mappers).ToInternal(data))
+	errs = addError(errs, Mappers(t.Mappers).ToInternalContext(ctx, obj))
 
-	for fieldName, schema := range t.subArraySchemas {
-		if schema.Mapper == nil {
+	for _, op := range t.plan {
+		mapper := op.schema.Mapper
+		if mapper == nil {
 			continue
 		}
-		for _, fieldData := range data.Slice(fieldName) {
-			errs = addError(errs, schema.Mapper.ToInternal(fieldData))
-		}
-	}
 
-	for fieldName, schema := range t.subMapSchemas {
-		if schema.Mapper == nil {
-			continue
-		}
-		for _, fieldData := range data.Map(fieldName) {
-			errs = addError(errs, schema.Mapper.ToInternal(convert.ToMapInterface(fieldData)))
-		}
+		_, _ = mapNested(obj[op.name], op.containers, func(value interface{}) (interface{}, error) {
+			errs = addError(errs, ToInternalContext(ctx, mapper, convert.ToMapInterface(value)))
+			return value, nil
+		})
 	}
 
-	for fieldName, schema := range t.subSchemas {
-		if schema.Mapper == nil {
-			continue
-		}
-		errs = addError(errs, schema.Mapper.ToInternal(data.Map(fieldName)))
-	}
+	errs = addError(errs, t.resolveReferences(ctx, obj))
+	errs = addError(errs, t.checkEnums(obj))
 
 	return errors.Join(errs...)
 }
 
 func (t *typeMapper) ModifySchema(schema *Schema, schemas *Schemas) error {
-	t.subSchemas = map[string]*Schema{}
-	t.subArraySchemas = map[string]*Schema{}
-	t.subMapSchemas = map[string]*Schema{}
+	t.plan = nil
+	t.references = map[string]reference{}
+	t.enums = map[string]enumField{}
 	t.typeName = schema.ID
+	t.schemas = schemas
+
+	if err := validateActions(schema, schemas); err != nil {
+		return err
+	}
+	if err := validateLinks(schema); err != nil {
+		return err
+	}
 
 	mapperSchema := schema
 	if schema.InternalSchema != nil {
 		mapperSchema = schema.InternalSchema
+		setTypeOptions(mapperSchema.ResourceFields)
 	}
+	setTypeOptions(schema.ResourceFields)
 	for name, field := range mapperSchema.ResourceFields {
-		fieldType := field.Type
-		targetMap := t.subSchemas
-		if definition.IsArrayType(fieldType) {
-			fieldType = definition.SubType(fieldType)
-			targetMap = t.subArraySchemas
-		} else if definition.IsMapType(fieldType) {
-			fieldType = definition.SubType(fieldType)
-			targetMap = t.subMapSchemas
+		if err := checkFieldType(schemas, field.Type); err != nil {
+			return fmt.Errorf("field %s on schema %s: %w", name, schema.ID, err)
+		}
+		if values, containers, ok := enumValues(field.Type); ok {
+			t.enums[name] = enumField{
+				values:     values,
+				containers: containers,
+			}
+		}
+		if ref, ok := toReference(field.Type); ok {
+			if ref.targetType != schema.ID && !ref.qualified() && schemas.Schema(ref.targetType) == nil {
+				return fmt.Errorf("failed to find schema %s referenced by field %s on schema %s", ref.targetType, name, schema.ID)
+			}
+			t.references[name] = ref
+			continue
 		}
 
-		schema := schemas.doSchema(fieldType, false)
-		if schema != nil {
-			targetMap[name] = schema
+		elem, containers, err := parseFieldType(field.Type)
+		if err != nil {
+			continue
+		}
+		if schema := schemas.Schema(elem.String()); schema != nil {
+			t.plan = append(t.plan, fieldOp{
+				name:       name,
+				containers: containers,
+				schema:     schema,
+			})
 		}
 	}
 
+	sort.Slice(t.plan, func(i, j int) bool {
+		return t.plan[i].name < t.plan[j].name
+	})
+
 	return Mappers(t.Mappers).ModifySchema(schema, schemas)
 }
@@ -0,0 +1,180 @@
+package schemas
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/acorn-io/schemer/data"
+)
+
+type benchPort struct {
+	Name string `json:"name"`
+	Port int    `json:"port"`
+}
+
+type benchContainer struct {
+	Image string               `json:"image"`
+	Ports []benchPort          `json:"ports"`
+	Env   map[string]benchPort `json:"env"`
+}
+
+type benchApp struct {
+	Name      string         `json:"name"`
+	Container benchContainer `json:"container"`
+}
+
+type benchNoop struct{}
+
+func (benchNoop) FromInternal(data.Object)             {}
+func (benchNoop) ToInternal(data.Object) error         { return nil }
+func (benchNoop) ModifySchema(*Schema, *Schemas) error { return nil }
+
+type ctxKey struct{}
+
+// ctxMapper sets the field "ctx" to the value of ctxKey in the context it's
+// called with.
+type ctxMapper struct {
+	benchNoop
+}
+
+func (ctxMapper) FromInternalContext(ctx context.Context, data data.Object) {
+	data["ctx"] = ctx.Value(ctxKey{})
+}
+
+func (ctxMapper) ToInternalContext(ctx context.Context, data data.Object) error {
+	data["ctx"] = ctx.Value(ctxKey{})
+	return nil
+}
+
+func TestMapperContext(t *testing.T) {
+	s := EmptySchemas()
+	s.AddMapperForType(benchPort{}, ctxMapper{})
+	schema, err := s.Import(benchApp{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx := context.WithValue(context.Background(), ctxKey{}, "value")
+	obj := benchObject()
+	FromInternalContext(ctx, schema.Mapper, obj)
+	for _, port := range obj.Map("container").Slice("ports") {
+		if port["ctx"] != "value" {
+			t.Fatalf("expected context in FromInternal, got %v", port)
+		}
+	}
+	if env := obj.Map("container").Map("env").Map("a"); env["ctx"] != "value" {
+		t.Fatalf("expected context in FromInternal, got %v", env)
+	}
+
+	obj = benchObject()
+	if err := ToInternalContext(ctx, schema.Mapper, obj); err != nil {
+		t.Fatal(err)
+	}
+	for _, port := range obj.Map("container").Slice("ports") {
+		if port["ctx"] != "value" {
+			t.Fatalf("expected context in ToInternal, got %v", port)
+		}
+	}
+
+	// mappers without the context variants are still called
+	obj = benchObject()
+	FromInternalContext(ctx, Mappers{prefixMapper{field: "name", prefix: "prefix-"}}, obj)
+	if obj["name"] != "prefix-app" {
+		t.Fatalf("expected plain mapper to be called, got %v", obj["name"])
+	}
+}
+
+func TestMappersCanceledContext(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	obj := data.Object{}
+	mappers := Mappers{ctxMapper{}}
+	mappers.FromInternalContext(ctx, obj)
+	if _, ok := obj["ctx"]; ok {
+		t.Fatal("expected no mapper to be called with a canceled context")
+	}
+	if err := mappers.ToInternalContext(ctx, obj); !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected context.Canceled, got %v", err)
+	}
+}
+
+// orderMapper appends its name to the field "order".
+type orderMapper struct {
+	benchNoop
+	name string
+}
+
+func (o orderMapper) FromInternal(data data.Object) {
+	order, _ := data["order"].([]string)
+	data["order"] = append(order, o.name)
+}
+
+func TestMapperPhases(t *testing.T) {
+	s := EmptySchemas()
+	s.AddMapperForTypeWithPhase(benchPort{}, PhasePost, orderMapper{name: "post"})
+	s.AddMapperForType(benchPort{}, orderMapper{name: "main1"})
+	s.AddMapperForTypeWithPhase(benchPort{}, PhasePre, orderMapper{name: "pre"})
+	s.AddMapperForType(benchPort{}, orderMapper{name: "main2"})
+	schema, err := s.Import(benchPort{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	obj := data.Object{}
+	schema.Mapper.FromInternal(obj)
+	if expected := []string{"pre", "main1", "main2", "post"}; !reflect.DeepEqual(obj["order"], expected) {
+		t.Fatalf("expected %v, got %v", expected, obj["order"])
+	}
+}
+
+func benchSchema(b *testing.B) *Schema {
+	s := EmptySchemas()
+	s.AddMapperForType(benchPort{}, benchNoop{})
+	s.AddMapperForType(benchApp{}, benchNoop{})
+	schema, err := s.Import(benchApp{})
+	if err != nil {
+		b.Fatal(err)
+	}
+	return schema
+}
+
+func benchObject() data.Object {
+	return data.Object{
+		"name": "app",
+		"container": map[string]interface{}{
+			"image": "nginx",
+			"ports": []interface{}{
+				map[string]interface{}{"name": "http", "port": 80},
+				map[string]interface{}{"name": "https", "port": 443},
+			},
+			"env": map[string]interface{}{
+				"a": map[string]interface{}{"name": "a", "port": 1},
+			},
+		},
+	}
+}
+
+func BenchmarkTypeMapperFromInternal(b *testing.B) {
+	schema := benchSchema(b)
+	obj := benchObject()
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		schema.Mapper.FromInternal(obj)
+	}
+}
+
+func BenchmarkTypeMapperToInternal(b *testing.B) {
+	schema := benchSchema(b)
+	obj := benchObject()
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if err := schema.Mapper.ToInternal(obj); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
@@ -0,0 +1,108 @@
+package mappers
+
+import (
+	"context"
+	"fmt"
+	"maps"
+
+	schemas "github.com/acorn-io/schemer"
+	"github.com/acorn-io/schemer/data"
+	"github.com/acorn-io/schemer/validation"
+)
+
+type existingKey struct{}
+
+// WithExisting returns a context carrying the currently stored version of the
+// object being written. Access uses it to tell creates from updates.
+func WithExisting(ctx context.Context, existing data.Object) context.Context {
+	return context.WithValue(ctx, existingKey{}, existing)
+}
+
+// ExistingFrom returns the object stored with WithExisting.
+func ExistingFrom(ctx context.Context) (data.Object, bool) {
+	existing, ok := ctx.Value(existingKey{}).(data.Object)
+	return existing, ok
+}
+
+// Access enforces the Create, Update and WriteOnce flags of the schema fields
+// on ToInternal. Without an existing object in the context the write is
+// treated as a create. Writes that are not allowed are reverted, or rejected
+// with validation.FieldNotWritable if Reject is set. Register it with
+// schemas.PhasePre so it sees the internal field names.
+type Access struct {
+	Reject bool
+
+	fields map[string]schemas.Field
+}
+
+// CloneMapper returns a copy of a, see schemas.CloneableMapper.
+func (a *Access) CloneMapper() schemas.Mapper {
+	return &Access{
+		Reject: a.Reject,
+		fields: maps.Clone(a.fields),
+	}
+}
+
+func (a *Access) FromInternal(obj data.Object) {
+}
+
+func (a *Access) ToInternal(obj data.Object) error {
+	return a.ToInternalContext(context.Background(), obj)
+}
+
+func (a *Access) FromInternalContext(ctx context.Context, obj data.Object) {
+}
+
+func (a *Access) ToInternalContext(ctx context.Context, obj data.Object) error {
+	existing, update := ExistingFrom(ctx)
+
+	for name, field := range a.fields {
+		value, ok := obj[name]
+		if !update {
+			if ok && !field.Create && !field.WriteOnce {
+				if err := a.deny(obj, existing, name, "set"); err != nil {
+					return err
+				}
+			}
+			continue
+		}
+
+		oldValue, oldOK := existing[name]
+		// stored objects hold int64 or json.Number and request bodies
+		// float64, so numbers are compared by value
+		if ok == oldOK && data.EqualValues(value, oldValue) {
+			continue
+		}
+		if field.Update {
+			continue
+		}
+		if field.WriteOnce && isEmpty(oldValue) {
+			continue
+		}
+		if err := a.deny(obj, existing, name, "changed"); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (a *Access) deny(obj, existing data.Object, name, action string) error {
+	if a.Reject {
+		return fmt.Errorf("field %s can not be %s: %w", name, action, validation.FieldNotWritable)
+	}
+	if v, ok := existing[name]; ok {
+		obj[name] = v
+	} else {
+		delete(obj, name)
+	}
+	return nil
+}
+
+func (a *Access) ModifySchema(schema *schemas.Schema, s *schemas.Schemas) error {
+	a.fields = map[string]schemas.Field{}
+	for name, field := range schema.ResourceFields {
+		a.fields[name] = field
+	}
+	return nil
+}
@@ -0,0 +1,99 @@
+package mappers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"reflect"
+	"testing"
+
+	schemas "github.com/acorn-io/schemer"
+	"github.com/acorn-io/schemer/data"
+	"github.com/acorn-io/schemer/validation"
+)
+
+func accessSchema() *schemas.Schema {
+	return &schemas.Schema{
+		ID: "app",
+		ResourceFields: map[string]schemas.Field{
+			"name":     {Type: "string", Create: true},
+			"replicas": {Type: "int", Create: true, Update: true},
+			"size":     {Type: "int", Create: true},
+			"secret":   {Type: "string", WriteOnce: true},
+			"status":   {Type: "string"},
+		},
+	}
+}
+
+func newAccess(t *testing.T, reject bool) *Access {
+	a := &Access{Reject: reject}
+	if err := a.ModifySchema(accessSchema(), schemas.EmptySchemas()); err != nil {
+		t.Fatal(err)
+	}
+	return a
+}
+
+func TestAccessCreate(t *testing.T) {
+	obj := data.Object{"name": "app", "secret": "s", "status": "ready"}
+	if err := newAccess(t, false).ToInternalContext(context.Background(), obj); err != nil {
+		t.Fatal(err)
+	}
+	expected := data.Object{"name": "app", "secret": "s"}
+	if !reflect.DeepEqual(obj, expected) {
+		t.Fatalf("expected %v, got %v", expected, obj)
+	}
+
+	obj = data.Object{"status": "ready"}
+	err := newAccess(t, true).ToInternalContext(context.Background(), obj)
+	if !errors.Is(err, validation.FieldNotWritable) {
+		t.Fatalf("expected FieldNotWritable, got %v", err)
+	}
+}
+
+func TestAccessUpdate(t *testing.T) {
+	existing := data.Object{"name": "app", "size": int64(3), "replicas": json.Number("1"), "secret": "", "status": "ready"}
+	ctx := WithExisting(context.Background(), existing)
+
+	// a request body decodes numbers as float64
+	obj := data.Object{"name": "other", "size": float64(3), "replicas": float64(2), "secret": "s", "status": "ready"}
+	if err := newAccess(t, false).ToInternalContext(ctx, obj); err != nil {
+		t.Fatal(err)
+	}
+	expected := data.Object{"name": "app", "size": float64(3), "replicas": float64(2), "secret": "s", "status": "ready"}
+	if !reflect.DeepEqual(obj, expected) {
+		t.Fatalf("expected %v, got %v", expected, obj)
+	}
+
+	// unchanged numbers are not rejected
+	obj = data.Object{"name": "app", "size": float64(3), "status": "ready", "secret": ""}
+	if err := newAccess(t, true).ToInternalContext(ctx, obj); err != nil {
+		t.Fatal(err)
+	}
+
+	existing["secret"] = "s"
+	obj = data.Object{"name": "app", "size": int64(3), "status": "ready", "secret": "changed"}
+	if err := newAccess(t, true).ToInternalContext(ctx, obj); !errors.Is(err, validation.FieldNotWritable) {
+		t.Fatalf("expected write once field to be rejected, got %v", err)
+	}
+}
+
+func TestAccessClone(t *testing.T) {
+	s := schemas.EmptySchemas()
+	s.MustAddSchema(*accessSchema())
+
+	a := newAccess(t, true)
+	clone := a.CloneMapper().(*Access)
+	schema := accessSchema()
+	schema.ResourceFields["status"] = schemas.Field{Type: "string", Create: true, Update: true}
+	if err := clone.ModifySchema(schema, s); err != nil {
+		t.Fatal(err)
+	}
+
+	obj := data.Object{"status": "ready"}
+	if err := clone.ToInternalContext(context.Background(), obj); err != nil {
+		t.Fatalf("expected the clone to allow status, got %v", err)
+	}
+	if err := a.ToInternalContext(context.Background(), obj); !errors.Is(err, validation.FieldNotWritable) {
+		t.Fatalf("expected the original to still reject status, got %v", err)
+	}
+}
@@ -0,0 +1,99 @@
+package mappers
+
+import (
+	"strings"
+
+	schemas "github.com/acorn-io/schemer"
+	"github.com/acorn-io/schemer/data"
+	"github.com/acorn-io/schemer/data/convert"
+)
+
+// AnnotationsToFields projects Kubernetes annotations into top-level fields.
+// Keys maps a single annotation to a string field. Prefixes maps an annotation
+// prefix to a map[string] field holding every matching annotation with the
+// prefix trimmed. ToInternal writes the fields back into metadata.annotations.
+type AnnotationsToFields struct {
+	Keys     map[string]string
+	Prefixes map[string]string
+}
+
+func (a AnnotationsToFields) FromInternal(obj data.Object) {
+	annotations := obj.Map("metadata", "annotations")
+	if len(annotations) == 0 {
+		return
+	}
+
+	for key, field := range a.Keys {
+		if v, ok := annotations[key]; ok {
+			obj[field] = convert.ToString(v)
+		}
+	}
+
+	for prefix, field := range a.Prefixes {
+		values := map[string]interface{}{}
+		for key, v := range annotations {
+			if name, ok := strings.CutPrefix(key, prefix); ok && name != "" {
+				values[name] = convert.ToString(v)
+			}
+		}
+		if len(values) > 0 {
+			obj[field] = values
+		}
+	}
+}
+
+func (a AnnotationsToFields) ToInternal(obj data.Object) error {
+	annotations := map[string]interface{}(obj.Map("metadata", "annotations"))
+	set := func(key string, value interface{}) {
+		if annotations == nil {
+			annotations = map[string]interface{}{}
+			data.PutValue(obj, annotations, "metadata", "annotations")
+		}
+		annotations[key] = convert.ToString(value)
+	}
+
+	for key, field := range a.Keys {
+		v, ok := obj[field]
+		if !ok {
+			continue
+		}
+		delete(obj, field)
+		if v == nil {
+			delete(annotations, key)
+		} else {
+			set(key, v)
+		}
+	}
+
+	for prefix, field := range a.Prefixes {
+		v, ok := obj[field]
+		if !ok {
+			continue
+		}
+		delete(obj, field)
+		for key := range annotations {
+			if strings.HasPrefix(key, prefix) {
+				delete(annotations, key)
+			}
+		}
+		for name, value := range convert.ToMapInterface(v) {
+			set(prefix+name, value)
+		}
+	}
+
+	return nil
+}
+
+func (a AnnotationsToFields) ModifySchema(schema *schemas.Schema, s *schemas.Schemas) error {
+	for _, field := range a.Keys {
+		if err := addField(schema, field, "string"); err != nil {
+			return err
+		}
+	}
+	for _, field := range a.Prefixes {
+		if err := addField(schema, field, "map[string]"); err != nil {
+			return err
+		}
+	}
+	return nil
+}
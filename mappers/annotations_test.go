@@ -0,0 +1,86 @@
+package mappers
+
+import (
+	"reflect"
+	"testing"
+
+	schemas "github.com/acorn-io/schemer"
+	"github.com/acorn-io/schemer/data"
+)
+
+type annotationsType struct {
+	Metadata map[string]interface{} `json:"metadata"`
+}
+
+func TestAnnotationsToFields(t *testing.T) {
+	mapper := AnnotationsToFields{
+		Keys:     map[string]string{"example.com/owner": "owner"},
+		Prefixes: map[string]string{"config.example.com/": "config"},
+	}
+	s := schemas.EmptySchemas().AddMapperForType(annotationsType{}, mapper)
+	schema, err := s.Import(annotationsType{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if schema.ResourceFields["owner"].Type != "string" || schema.ResourceFields["config"].Type != "map[string]" {
+		t.Fatalf("unexpected fields %v", schema.ResourceFields)
+	}
+
+	obj := data.Object{
+		"metadata": map[string]interface{}{
+			"annotations": map[string]interface{}{
+				"example.com/owner":         "team",
+				"config.example.com/level":  "debug",
+				"config.example.com/format": "json",
+				"other":                     "value",
+			},
+		},
+	}
+	schema.Mapper.FromInternal(obj)
+	if obj["owner"] != "team" {
+		t.Fatalf("expected owner team, got %v", obj["owner"])
+	}
+	if expected := map[string]interface{}{"level": "debug", "format": "json"}; !reflect.DeepEqual(obj["config"], expected) {
+		t.Fatalf("expected config %v, got %v", expected, obj["config"])
+	}
+
+	obj["owner"] = "other-team"
+	obj["config"] = map[string]interface{}{"level": "info"}
+	if err := schema.Mapper.ToInternal(obj); err != nil {
+		t.Fatal(err)
+	}
+	expected := data.Object{
+		"metadata": map[string]interface{}{
+			"annotations": map[string]interface{}{
+				"example.com/owner":        "other-team",
+				"config.example.com/level": "info",
+				"other":                    "value",
+			},
+		},
+	}
+	if !reflect.DeepEqual(obj, expected) {
+		t.Fatalf("expected %v, got %v", expected, obj)
+	}
+}
+
+func TestAnnotationsToFieldsToInternal(t *testing.T) {
+	mapper := AnnotationsToFields{Keys: map[string]string{"example.com/owner": "owner"}}
+
+	// annotations are created if the object has none
+	obj := data.Object{"owner": "team"}
+	if err := mapper.ToInternal(obj); err != nil {
+		t.Fatal(err)
+	}
+	if expected := (data.Object{"example.com/owner": "team"}); !reflect.DeepEqual(obj.Map("metadata", "annotations"), expected) {
+		t.Fatalf("expected annotations %v, got %v", expected, obj)
+	}
+
+	// null removes the annotation
+	obj["owner"] = nil
+	if err := mapper.ToInternal(obj); err != nil {
+		t.Fatal(err)
+	}
+	if len(obj.Map("metadata", "annotations")) != 0 {
+		t.Fatalf("expected the annotation to be removed, got %v", obj)
+	}
+}
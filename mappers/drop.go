@@ -0,0 +1,44 @@
+package mappers
+
+import (
+	"fmt"
+
+	schemas "github.com/acorn-io/schemer"
+	"github.com/acorn-io/schemer/data"
+)
+
+// Drop removes Field from the external representation and the schema. The
+// internal data is left untouched on ToInternal. If IfEmpty is set the field
+// stays in the schema and is only removed when its value is empty.
+type Drop struct {
+	Field   string
+	IfEmpty bool
+}
+
+func (d Drop) FromInternal(obj data.Object) {
+	path := splitPath(d.Field)
+	if d.IfEmpty {
+		if v, ok := data.GetValue(obj, path...); !ok || !isEmpty(v) {
+			return
+		}
+	}
+	data.RemoveValue(obj, path...)
+}
+
+func (d Drop) ToInternal(obj data.Object) error {
+	return nil
+}
+
+func (d Drop) ModifySchema(schema *schemas.Schema, s *schemas.Schemas) error {
+	fieldSchema, name, _, ok, err := getField(schema, s, d.Field)
+	if err != nil {
+		return err
+	} else if !ok {
+		return fmt.Errorf("failed to find field %s on schema %s", d.Field, schema.ID)
+	}
+
+	if !d.IfEmpty {
+		delete(fieldSchema.ResourceFields, name)
+	}
+	return nil
+}
@@ -0,0 +1,75 @@
+package mappers
+
+import (
+	"reflect"
+	"testing"
+
+	schemas "github.com/acorn-io/schemer"
+	"github.com/acorn-io/schemer/data"
+)
+
+type dropSpec struct {
+	Replicas int    `json:"replicas"`
+	Image    string `json:"image"`
+}
+
+type dropType struct {
+	Name   string   `json:"name"`
+	Status string   `json:"status"`
+	Spec   dropSpec `json:"spec"`
+}
+
+func TestDrop(t *testing.T) {
+	s := schemas.EmptySchemas().
+		AddMapperForType(dropType{}, Drop{Field: "status"}, Drop{Field: "spec/image", IfEmpty: true})
+	schema, err := s.Import(dropType{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, ok := schema.ResourceFields["status"]; ok {
+		t.Fatal("expected status to be removed from the schema")
+	}
+	if spec := s.Schema(schema.ResourceFields["spec"].Type); spec == nil || spec.ResourceFields["image"].Type == "" {
+		t.Fatal("expected spec/image to stay in the schema")
+	}
+
+	obj := data.Object{
+		"name":   "test",
+		"status": "ready",
+		"spec":   map[string]interface{}{"replicas": 1, "image": ""},
+	}
+	schema.Mapper.FromInternal(obj)
+	expected := data.Object{
+		"name": "test",
+		"spec": map[string]interface{}{"replicas": 1},
+	}
+	if !reflect.DeepEqual(obj, expected) {
+		t.Fatalf("expected %v, got %v", expected, obj)
+	}
+
+	obj = data.Object{
+		"name": "test",
+		"spec": map[string]interface{}{"image": "nginx"},
+	}
+	schema.Mapper.FromInternal(obj)
+	if obj.Map("spec")["image"] != "nginx" {
+		t.Fatalf("expected a set image to be kept, got %v", obj)
+	}
+
+	internal := data.Object{"name": "test", "status": "ready"}
+	if err := schema.Mapper.ToInternal(internal); err != nil {
+		t.Fatal(err)
+	}
+	if internal["status"] != "ready" {
+		t.Fatalf("expected ToInternal to leave the data untouched, got %v", internal)
+	}
+}
+
+func TestDropMissingField(t *testing.T) {
+	s := schemas.EmptySchemas().
+		AddMapperForType(dropType{}, Drop{Field: "missing"})
+	if _, err := s.Import(dropType{}); err == nil {
+		t.Fatal("expected error for a missing field")
+	}
+}
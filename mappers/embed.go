@@ -0,0 +1,97 @@
+package mappers
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+
+	schemas "github.com/acorn-io/schemer"
+	"github.com/acorn-io/schemer/data"
+)
+
+// Embed flattens the fields of the sub-object Field into the parent object on
+// FromInternal and rebuilds the sub-object on ToInternal. Fields listed in
+// Ignore are left in the sub-object, which then stays in the schema.
+type Embed struct {
+	Field  string
+	Ignore []string
+
+	embeddedFields []string
+}
+
+// CloneMapper returns a copy of e, see schemas.CloneableMapper.
+func (e *Embed) CloneMapper() schemas.Mapper {
+	return &Embed{
+		Field:          e.Field,
+		Ignore:         slices.Clone(e.Ignore),
+		embeddedFields: slices.Clone(e.embeddedFields),
+	}
+}
+
+func (e *Embed) FromInternal(obj data.Object) {
+	sub, ok := obj[e.Field].(map[string]interface{})
+	if !ok {
+		return
+	}
+
+	for _, name := range e.embeddedFields {
+		if v, ok := sub[name]; ok {
+			obj[name] = v
+			delete(sub, name)
+		}
+	}
+
+	if len(sub) == 0 {
+		delete(obj, e.Field)
+	}
+}
+
+func (e *Embed) ToInternal(obj data.Object) error {
+	sub, _ := obj[e.Field].(map[string]interface{})
+	if sub == nil {
+		sub = map[string]interface{}{}
+	}
+
+	for _, name := range e.embeddedFields {
+		if v, ok := obj[name]; ok {
+			sub[name] = v
+			delete(obj, name)
+		}
+	}
+
+	if len(sub) > 0 {
+		obj[e.Field] = sub
+	}
+	return nil
+}
+
+func (e *Embed) ModifySchema(schema *schemas.Schema, s *schemas.Schemas) error {
+	field, ok := schema.ResourceFields[e.Field]
+	if !ok {
+		return fmt.Errorf("failed to find field %s on schema %s", e.Field, schema.ID)
+	}
+
+	embeddedSchema := s.Schema(field.Type)
+	if embeddedSchema == nil {
+		return fmt.Errorf("failed to find schema %s for field %s on schema %s", field.Type, e.Field, schema.ID)
+	}
+
+	if len(e.Ignore) == 0 {
+		delete(schema.ResourceFields, e.Field)
+	}
+
+	e.embeddedFields = nil
+	for name, embeddedField := range embeddedSchema.ResourceFields {
+		if slices.Contains(e.Ignore, name) {
+			continue
+		}
+		if _, ok := schema.ResourceFields[name]; ok {
+			return fmt.Errorf("field %s of embedded field %s collides with existing field on schema %s", name, e.Field, schema.ID)
+		}
+		schema.ResourceFields[name] = *embeddedField.DeepCopy()
+		e.embeddedFields = append(e.embeddedFields, name)
+	}
+
+	sort.Strings(e.embeddedFields)
+	return nil
+}
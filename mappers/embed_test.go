@@ -0,0 +1,88 @@
+package mappers
+
+import (
+	"reflect"
+	"testing"
+
+	schemas "github.com/acorn-io/schemer"
+	"github.com/acorn-io/schemer/data"
+)
+
+type embedSpec struct {
+	Image    string `json:"image"`
+	Replicas int    `json:"replicas"`
+}
+
+type embedApp struct {
+	Name string    `json:"name"`
+	Spec embedSpec `json:"spec"`
+}
+
+func embedObject() data.Object {
+	return data.Object{
+		"name": "test",
+		"spec": map[string]interface{}{
+			"image":    "nginx",
+			"replicas": 1,
+		},
+	}
+}
+
+func TestEmbed(t *testing.T) {
+	s := schemas.EmptySchemas().
+		AddMapperForType(embedApp{}, &Embed{Field: "spec"})
+	schema, err := s.Import(embedApp{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, ok := schema.ResourceFields["spec"]; ok {
+		t.Fatal("expected spec to be removed from the schema")
+	}
+	if _, ok := schema.ResourceFields["image"]; !ok {
+		t.Fatal("expected image field on schema")
+	}
+
+	obj := embedObject()
+	schema.Mapper.FromInternal(obj)
+	external := data.Object{"name": "test", "image": "nginx", "replicas": 1}
+	if !reflect.DeepEqual(obj, external) {
+		t.Fatalf("expected %v, got %v", external, obj)
+	}
+
+	if err := schema.Mapper.ToInternal(obj); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(obj, embedObject()) {
+		t.Fatalf("expected %v, got %v", embedObject(), obj)
+	}
+}
+
+func TestEmbedClone(t *testing.T) {
+	s := schemas.EmptySchemas().
+		AddMapperForType(embedApp{}, &Embed{Field: "spec"})
+	schema, err := s.Import(embedApp{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// drop image from the embedded schema of the clone and rebuild the mapper
+	clone := s.Clone()
+	delete(clone.Schema("embedSpec").ResourceFields, "image")
+	cloneSchema := clone.Schema("embedApp")
+	if err := cloneSchema.Mapper.ModifySchema(cloneSchema.InternalSchema.DeepCopy(), clone); err != nil {
+		t.Fatal(err)
+	}
+
+	obj := embedObject()
+	cloneSchema.Mapper.FromInternal(obj)
+	if _, ok := obj["image"]; ok {
+		t.Fatalf("expected image to stay in spec of the clone, got %v", obj)
+	}
+
+	obj = embedObject()
+	schema.Mapper.FromInternal(obj)
+	if obj["image"] != "nginx" {
+		t.Fatalf("expected image to be embedded by the original, got %v", obj)
+	}
+}
@@ -0,0 +1,94 @@
+package mappers
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+
+	schemas "github.com/acorn-io/schemer"
+	"github.com/acorn-io/schemer/data"
+)
+
+// Encoded decodes the string field Field, which holds a JSON document, into a
+// structured value on FromInternal and encodes it again on ToInternal. If
+// Base64 is set the JSON document is additionally base64 encoded. Type is the
+// schema type exposed for the decoded value and defaults to "json". Values
+// are always encoded on ToInternal, so a string is stored as a JSON string.
+// Values that can't be decoded are passed through unchanged in both
+// directions.
+type Encoded struct {
+	Field  string
+	Type   string
+	Base64 bool
+}
+
+// undecoded marks a value FromInternal failed to decode, so ToInternal stores
+// it as is instead of encoding it as a JSON string.
+type undecoded string
+
+func (e Encoded) FromInternal(obj data.Object) {
+	str, ok := obj[e.Field].(string)
+	if !ok || str == "" {
+		return
+	}
+
+	content := []byte(str)
+	if e.Base64 {
+		decoded, err := base64.StdEncoding.DecodeString(str)
+		if err != nil {
+			obj[e.Field] = undecoded(str)
+			return
+		}
+		content = decoded
+	}
+
+	var value interface{}
+	dec := json.NewDecoder(bytes.NewReader(content))
+	dec.UseNumber()
+	if err := dec.Decode(&value); err != nil || dec.More() {
+		obj[e.Field] = undecoded(str)
+		return
+	}
+	obj[e.Field] = value
+}
+
+func (e Encoded) ToInternal(obj data.Object) error {
+	v, ok := obj[e.Field]
+	if !ok || data.IsNull(v) {
+		return nil
+	}
+	if raw, ok := v.(undecoded); ok {
+		obj[e.Field] = string(raw)
+		return nil
+	}
+
+	content, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Errorf("failed to encode field %s: %w", e.Field, err)
+	}
+	if e.Base64 {
+		obj[e.Field] = base64.StdEncoding.EncodeToString(content)
+	} else {
+		obj[e.Field] = string(content)
+	}
+	return nil
+}
+
+func (e Encoded) ModifySchema(schema *schemas.Schema, s *schemas.Schemas) error {
+	field, ok := schema.ResourceFields[e.Field]
+	if !ok {
+		return fmt.Errorf("failed to find field %s on schema %s", e.Field, schema.ID)
+	}
+
+	fieldType := e.Type
+	if fieldType == "" {
+		fieldType = "json"
+	} else if s.Schema(fieldType) == nil {
+		return fmt.Errorf("failed to find schema %s for field %s on schema %s", fieldType, e.Field, schema.ID)
+	}
+
+	field.Type = fieldType
+	schema.ResourceFields[e.Field] = field
+	return nil
+}
@@ -0,0 +1,67 @@
+package mappers
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/acorn-io/schemer/data"
+)
+
+func TestEncodedRoundTrip(t *testing.T) {
+	tests := []struct {
+		name    string
+		mapper  Encoded
+		stored  string
+		decoded interface{}
+	}{
+		{
+			name:    "object",
+			mapper:  Encoded{Field: "config"},
+			stored:  `{"a":1}`,
+			decoded: map[string]interface{}{"a": json.Number("1")},
+		},
+		{
+			name:    "string",
+			mapper:  Encoded{Field: "config"},
+			stored:  `"abc"`,
+			decoded: "abc",
+		},
+		{
+			name:    "base64 string",
+			mapper:  Encoded{Field: "config", Base64: true},
+			stored:  "ImFiYyI=",
+			decoded: "abc",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			obj := data.Object{"config": test.stored}
+			test.mapper.FromInternal(obj)
+			if !reflect.DeepEqual(obj["config"], test.decoded) {
+				t.Fatalf("expected %#v, got %#v", test.decoded, obj["config"])
+			}
+			if err := test.mapper.ToInternal(obj); err != nil {
+				t.Fatal(err)
+			}
+			if obj["config"] != test.stored {
+				t.Errorf("expected %s, got %v", test.stored, obj["config"])
+			}
+		})
+	}
+}
+
+func TestEncodedUndecodable(t *testing.T) {
+	for _, mapper := range []Encoded{{Field: "config"}, {Field: "config", Base64: true}} {
+		for _, stored := range []string{"abc", "{", `{"a":1} trailing`, "not base64!"} {
+			obj := data.Object{"config": stored}
+			mapper.FromInternal(obj)
+			if err := mapper.ToInternal(obj); err != nil {
+				t.Fatal(err)
+			}
+			if obj["config"] != stored {
+				t.Errorf("expected %q to be stored unchanged with base64=%v, got %#v", stored, mapper.Base64, obj["config"])
+			}
+		}
+	}
+}
@@ -0,0 +1,83 @@
+package mappers
+
+import (
+	"fmt"
+	"maps"
+	"strings"
+
+	schemas "github.com/acorn-io/schemer"
+	"github.com/acorn-io/schemer/data"
+)
+
+// FuzzyFields matches the keys of inbound data to the fields of the schema
+// ignoring case, "_" and "-", so "max_count", "MaxCount" and "max-count" are
+// all stored as "maxCount". Keys that match a field exactly or don't match
+// any field, or match more than one, are left alone. It only covers the
+// fields of its own schema, register it in PhasePost for every type that
+// should accept loosely named input.
+type FuzzyFields struct {
+	fields map[string]string
+}
+
+// CloneMapper returns a copy of f, see schemas.CloneableMapper.
+func (f *FuzzyFields) CloneMapper() schemas.Mapper {
+	return &FuzzyFields{
+		fields: maps.Clone(f.fields),
+	}
+}
+
+func (f *FuzzyFields) FromInternal(obj data.Object) {
+}
+
+func (f *FuzzyFields) ToInternal(obj data.Object) error {
+	if obj == nil {
+		return nil
+	}
+
+	var renames map[string]string
+	for key := range obj {
+		canonical, ok := f.fields[normalizeFieldName(key)]
+		if !ok || canonical == "" || canonical == key {
+			continue
+		}
+		if _, ok := obj[canonical]; ok {
+			return fmt.Errorf("field %s is set as both %s and %s", canonical, canonical, key)
+		}
+		if other, ok := renames[canonical]; ok {
+			return fmt.Errorf("field %s is set as both %s and %s", canonical, other, key)
+		}
+		if renames == nil {
+			renames = map[string]string{}
+		}
+		renames[canonical] = key
+	}
+
+	for canonical, key := range renames {
+		obj[canonical] = obj[key]
+		delete(obj, key)
+	}
+	return nil
+}
+
+func (f *FuzzyFields) ModifySchema(schema *schemas.Schema, s *schemas.Schemas) error {
+	f.fields = map[string]string{}
+	for name := range schema.ResourceFields {
+		key := normalizeFieldName(name)
+		if _, ok := f.fields[key]; ok {
+			// ambiguous, only exact matches are accepted for these fields
+			f.fields[key] = ""
+			continue
+		}
+		f.fields[key] = name
+	}
+	return nil
+}
+
+func normalizeFieldName(name string) string {
+	return strings.Map(func(r rune) rune {
+		if r == '_' || r == '-' {
+			return -1
+		}
+		return r
+	}, strings.ToLower(name))
+}
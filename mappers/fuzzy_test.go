@@ -0,0 +1,70 @@
+package mappers
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	schemas "github.com/acorn-io/schemer"
+	"github.com/acorn-io/schemer/data"
+)
+
+type fuzzyApp struct {
+	MaxCount int    `json:"maxCount"`
+	Name     string `json:"name"`
+}
+
+func TestFuzzyFields(t *testing.T) {
+	s := schemas.EmptySchemas().
+		AddMapperForTypeWithPhase(fuzzyApp{}, schemas.PhasePost, &FuzzyFields{})
+	schema, err := s.Import(fuzzyApp{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, key := range []string{"max_count", "MaxCount", "max-count", "maxCount"} {
+		obj := data.Object{key: 1, "NAME": "app", "other": true}
+		if err := schema.Mapper.ToInternal(obj); err != nil {
+			t.Fatal(err)
+		}
+		expected := data.Object{"maxCount": 1, "name": "app", "other": true}
+		if !reflect.DeepEqual(obj, expected) {
+			t.Errorf("expected %v for %s, got %v", expected, key, obj)
+		}
+	}
+
+	err = schema.Mapper.ToInternal(data.Object{"max_count": 1, "MAXCOUNT": 2})
+	if err == nil || !strings.HasPrefix(err.Error(), "field maxCount is set as both") {
+		t.Fatalf("expected a conflict, got %v", err)
+	}
+}
+
+func TestFuzzyFieldsClone(t *testing.T) {
+	fuzzy := &FuzzyFields{}
+	if err := fuzzy.ModifySchema(&schemas.Schema{ResourceFields: map[string]schemas.Field{"maxCount": {}}}, nil); err != nil {
+		t.Fatal(err)
+	}
+
+	clone := fuzzy.CloneMapper().(*FuzzyFields)
+	if err := clone.ModifySchema(&schemas.Schema{ResourceFields: map[string]schemas.Field{"minCount": {}}}, nil); err != nil {
+		t.Fatal(err)
+	}
+
+	obj := data.Object{"max_count": 1, "min_count": 2}
+	if err := fuzzy.ToInternal(obj); err != nil {
+		t.Fatal(err)
+	}
+	expected := data.Object{"maxCount": 1, "min_count": 2}
+	if !reflect.DeepEqual(obj, expected) {
+		t.Fatalf("expected the original to match maxCount only, got %v", obj)
+	}
+
+	obj = data.Object{"max_count": 1, "min_count": 2}
+	if err := clone.ToInternal(obj); err != nil {
+		t.Fatal(err)
+	}
+	expected = data.Object{"max_count": 1, "minCount": 2}
+	if !reflect.DeepEqual(obj, expected) {
+		t.Fatalf("expected the clone to match minCount only, got %v", obj)
+	}
+}
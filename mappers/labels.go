@@ -0,0 +1,89 @@
+package mappers
+
+import (
+	schemas "github.com/acorn-io/schemer"
+	"github.com/acorn-io/schemer/data"
+	"github.com/acorn-io/schemer/data/convert"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+// LabelsField exposes metadata.labels as the map[string] field Field, which
+// defaults to "labels". If SelectorField is set a read-only string field is
+// added that renders the labels as a label selector. ToInternal writes the
+// labels back into metadata.
+type LabelsField struct {
+	Field         string
+	SelectorField string
+}
+
+func (l LabelsField) field() string {
+	if l.Field == "" {
+		return "labels"
+	}
+	return l.Field
+}
+
+func (l LabelsField) FromInternal(obj data.Object) {
+	set := Labels(obj)
+	if len(set) > 0 {
+		values := map[string]interface{}{}
+		for k, v := range set {
+			values[k] = v
+		}
+		obj[l.field()] = values
+	}
+	if l.SelectorField != "" {
+		obj[l.SelectorField] = labels.SelectorFromSet(set).String()
+	}
+}
+
+func (l LabelsField) ToInternal(obj data.Object) error {
+	if l.SelectorField != "" {
+		delete(obj, l.SelectorField)
+	}
+
+	v, ok := obj[l.field()]
+	if !ok {
+		return nil
+	}
+	delete(obj, l.field())
+
+	values := map[string]interface{}{}
+	for k, v := range convert.ToMapInterface(v) {
+		values[k] = convert.ToString(v)
+	}
+	if len(values) == 0 {
+		data.RemoveValue(obj, "metadata", "labels")
+	} else {
+		data.PutValue(obj, values, "metadata", "labels")
+	}
+	return nil
+}
+
+func (l LabelsField) ModifySchema(schema *schemas.Schema, s *schemas.Schemas) error {
+	if err := addField(schema, l.field(), "map[string]"); err != nil {
+		return err
+	}
+	if l.SelectorField != "" {
+		return addReadOnlyField(schema, l.SelectorField, "string")
+	}
+	return nil
+}
+
+// Labels returns the labels from metadata.labels of obj.
+func Labels(obj data.Object) labels.Set {
+	result := labels.Set{}
+	for k, v := range obj.Map("metadata", "labels") {
+		result[k] = convert.ToString(v)
+	}
+	return result
+}
+
+// MatchesSelector returns true if the labels of obj match the label selector.
+func MatchesSelector(obj data.Object, selector string) (bool, error) {
+	sel, err := labels.Parse(selector)
+	if err != nil {
+		return false, err
+	}
+	return sel.Matches(Labels(obj)), nil
+}
@@ -0,0 +1,87 @@
+package mappers
+
+import (
+	"reflect"
+	"testing"
+
+	schemas "github.com/acorn-io/schemer"
+	"github.com/acorn-io/schemer/data"
+)
+
+type labelsType struct {
+	Metadata map[string]interface{} `json:"metadata"`
+}
+
+func TestLabelsField(t *testing.T) {
+	s := schemas.EmptySchemas().
+		AddMapperForType(labelsType{}, LabelsField{SelectorField: "selector"})
+	schema, err := s.Import(labelsType{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if field := schema.ResourceFields["selector"]; field.Type != "string" || field.Create || field.Update {
+		t.Fatalf("expected read-only selector field, got %+v", field)
+	}
+
+	obj := data.Object{
+		"metadata": map[string]interface{}{
+			"labels": map[string]interface{}{"app": "web", "tier": "frontend"},
+		},
+	}
+	schema.Mapper.FromInternal(obj)
+	if expected := map[string]interface{}{"app": "web", "tier": "frontend"}; !reflect.DeepEqual(obj["labels"], expected) {
+		t.Fatalf("expected labels %v, got %v", expected, obj["labels"])
+	}
+	if obj["selector"] != "app=web,tier=frontend" {
+		t.Fatalf("unexpected selector %v", obj["selector"])
+	}
+
+	obj["labels"] = map[string]interface{}{"app": "api"}
+	if err := schema.Mapper.ToInternal(obj); err != nil {
+		t.Fatal(err)
+	}
+	expected := data.Object{
+		"metadata": map[string]interface{}{
+			"labels": map[string]interface{}{"app": "api"},
+		},
+	}
+	if !reflect.DeepEqual(obj, expected) {
+		t.Fatalf("expected %v, got %v", expected, obj)
+	}
+
+	obj["labels"] = map[string]interface{}{}
+	if err := schema.Mapper.ToInternal(obj); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := obj.Map("metadata")["labels"]; ok {
+		t.Fatalf("expected empty labels to be removed, got %v", obj)
+	}
+}
+
+func TestMatchesSelector(t *testing.T) {
+	obj := data.Object{
+		"metadata": map[string]interface{}{
+			"labels": map[string]interface{}{"app": "web", "tier": "frontend"},
+		},
+	}
+
+	for selector, expected := range map[string]bool{
+		"app=web":               true,
+		"app=web,tier=frontend": true,
+		"app in (web,api)":      true,
+		"app!=web":              false,
+		"env":                   false,
+	} {
+		matches, err := MatchesSelector(obj, selector)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if matches != expected {
+			t.Errorf("expected %v for %q, got %v", expected, selector, matches)
+		}
+	}
+
+	if _, err := MatchesSelector(obj, "app in (web"); err == nil {
+		t.Fatal("expected error for an invalid selector")
+	}
+}
@@ -0,0 +1,60 @@
+package mappers
+
+import (
+	"fmt"
+
+	schemas "github.com/acorn-io/schemer"
+	"github.com/acorn-io/schemer/data"
+)
+
+// Move relocates the field at From to To on FromInternal and moves it back on
+// ToInternal. Nested paths are separated by "/". If CopyOnly is set the field
+// at From is left in place and its value is copied to To.
+type Move struct {
+	From, To string
+	CopyOnly bool
+}
+
+func (m Move) FromInternal(d data.Object) {
+	from, to := splitPath(m.From), splitPath(m.To)
+	if m.CopyOnly {
+		if v, ok := data.GetValue(d, from...); ok {
+			data.PutValue(d, v, to...)
+		}
+		return
+	}
+	if v, ok := data.RemoveValue(d, from...); ok {
+		data.PutValue(d, v, to...)
+	}
+}
+
+func (m Move) ToInternal(d data.Object) error {
+	if v, ok := data.RemoveValue(d, splitPath(m.To)...); ok {
+		data.PutValue(d, v, splitPath(m.From)...)
+	}
+	return nil
+}
+
+func (m Move) ModifySchema(schema *schemas.Schema, s *schemas.Schemas) error {
+	fromSchema, fromName, field, ok, err := getField(schema, s, m.From)
+	if err != nil {
+		return err
+	} else if !ok {
+		return fmt.Errorf("failed to find field %s on schema %s", m.From, schema.ID)
+	}
+
+	toSchema, toName, _, exists, err := getField(schema, s, m.To)
+	if err != nil {
+		return err
+	} else if toSchema == nil {
+		return fmt.Errorf("failed to find parent of field %s on schema %s", m.To, schema.ID)
+	} else if exists {
+		return fmt.Errorf("field %s already exists on schema %s", m.To, schema.ID)
+	}
+
+	if !m.CopyOnly {
+		delete(fromSchema.ResourceFields, fromName)
+	}
+	toSchema.ResourceFields[toName] = field
+	return nil
+}
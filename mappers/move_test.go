@@ -0,0 +1,93 @@
+package mappers
+
+import (
+	"reflect"
+	"testing"
+
+	schemas "github.com/acorn-io/schemer"
+	"github.com/acorn-io/schemer/data"
+)
+
+type moveSpec struct {
+	Replicas int `json:"replicas"`
+}
+
+type moveType struct {
+	Name string   `json:"name"`
+	Spec moveSpec `json:"spec"`
+}
+
+func TestMove(t *testing.T) {
+	s := schemas.EmptySchemas().
+		AddMapperForType(moveType{}, Move{From: "spec/replicas", To: "scale"})
+	schema, err := s.Import(moveType{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, ok := schema.ResourceFields["scale"]; !ok {
+		t.Fatal("expected scale field on schema")
+	}
+
+	internal := data.Object{
+		"name": "test",
+		"spec": map[string]interface{}{
+			"replicas": 3,
+		},
+	}
+	external := data.Object{
+		"name":  "test",
+		"spec":  map[string]interface{}{},
+		"scale": 3,
+	}
+
+	obj := data.Object{
+		"name": "test",
+		"spec": map[string]interface{}{
+			"replicas": 3,
+		},
+	}
+	schema.Mapper.FromInternal(obj)
+	if !reflect.DeepEqual(obj, external) {
+		t.Fatalf("expected %v, got %v", external, obj)
+	}
+
+	if err := schema.Mapper.ToInternal(obj); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(obj, internal) {
+		t.Fatalf("expected %v, got %v", internal, obj)
+	}
+}
+
+type moveOther struct {
+	Spec moveSpec `json:"spec"`
+}
+
+func TestMoveNestedDoesNotChangeSharedSchema(t *testing.T) {
+	s := schemas.EmptySchemas().
+		AddMapperForType(moveType{}, Move{From: "spec/replicas", To: "scale"})
+	schema, err := s.Import(moveType{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	other, err := s.Import(moveOther{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if spec := s.Schema(other.ResourceFields["spec"].Type); spec == nil || spec.ResourceFields["replicas"].Type == "" {
+		t.Fatalf("expected replicas on the spec of moveOther, got %v", spec)
+	}
+	if _, ok := s.Schema("moveSpec").ResourceFields["replicas"]; !ok {
+		t.Fatal("expected replicas on moveSpec")
+	}
+
+	spec := s.Schema(schema.ResourceFields["spec"].Type)
+	if spec == nil || spec.ID != "moveTypeSpec" {
+		t.Fatalf("expected the spec of moveType to be a copy, got %v", spec)
+	}
+	if _, ok := spec.ResourceFields["replicas"]; ok {
+		t.Fatal("expected replicas to be moved off the copy")
+	}
+}
@@ -0,0 +1,24 @@
+package mappers
+
+import (
+	schemas "github.com/acorn-io/schemer"
+	"github.com/acorn-io/schemer/data"
+)
+
+// Prune removes empty values from objects on FromInternal, see
+// data.Object.Prune. Inbound data is left as is.
+type Prune struct {
+	Options data.PruneOptions
+}
+
+func (p Prune) FromInternal(obj data.Object) {
+	obj.PruneWith(p.Options)
+}
+
+func (p Prune) ToInternal(obj data.Object) error {
+	return nil
+}
+
+func (p Prune) ModifySchema(schema *schemas.Schema, s *schemas.Schemas) error {
+	return nil
+}
@@ -0,0 +1,24 @@
+package mappers
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/acorn-io/schemer/data"
+)
+
+func TestPrune(t *testing.T) {
+	obj := data.Object{"name": "app", "labels": map[string]interface{}{}, "image": ""}
+	Prune{Options: data.PruneOptions{KeepEmptyStrings: true}}.FromInternal(obj)
+	if expected := (data.Object{"name": "app", "image": ""}); !reflect.DeepEqual(obj, expected) {
+		t.Fatalf("expected %v, got %v", expected, obj)
+	}
+
+	obj = data.Object{"labels": map[string]interface{}{}}
+	if err := (Prune{}).ToInternal(obj); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := obj["labels"]; !ok {
+		t.Fatal("expected ToInternal to leave the data untouched")
+	}
+}
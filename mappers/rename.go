@@ -0,0 +1,60 @@
+package mappers
+
+import (
+	"fmt"
+
+	schemas "github.com/acorn-io/schemer"
+	"github.com/acorn-io/schemer/data"
+)
+
+// Rename exposes the internal field From as To. On ToInternal the value of To,
+// or if To is not set the first of Aliases that is, is stored back in From.
+// All other accepted names are removed from the object.
+type Rename struct {
+	From, To string
+	Aliases  []string
+}
+
+func (r Rename) FromInternal(obj data.Object) {
+	if v, ok := obj[r.From]; ok {
+		delete(obj, r.From)
+		obj[r.To] = v
+	}
+}
+
+func (r Rename) ToInternal(obj data.Object) error {
+	var (
+		value interface{}
+		found bool
+	)
+
+	for _, name := range append([]string{r.To}, r.Aliases...) {
+		v, ok := obj[name]
+		if !ok {
+			continue
+		}
+		delete(obj, name)
+		if !found {
+			value, found = v, true
+		}
+	}
+
+	if found {
+		obj[r.From] = value
+	}
+	return nil
+}
+
+func (r Rename) ModifySchema(schema *schemas.Schema, s *schemas.Schemas) error {
+	field, ok := schema.ResourceFields[r.From]
+	if !ok {
+		return fmt.Errorf("failed to find field %s on schema %s", r.From, schema.ID)
+	}
+	if _, ok := schema.ResourceFields[r.To]; ok {
+		return fmt.Errorf("field %s already exists on schema %s", r.To, schema.ID)
+	}
+
+	delete(schema.ResourceFields, r.From)
+	schema.ResourceFields[r.To] = field
+	return nil
+}
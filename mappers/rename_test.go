@@ -0,0 +1,76 @@
+package mappers
+
+import (
+	"reflect"
+	"testing"
+
+	schemas "github.com/acorn-io/schemer"
+	"github.com/acorn-io/schemer/data"
+)
+
+type renameType struct {
+	Name  string `json:"name"`
+	Image string `json:"image"`
+}
+
+func TestRename(t *testing.T) {
+	s := schemas.EmptySchemas().
+		AddMapperForType(renameType{}, Rename{From: "image", To: "containerImage", Aliases: []string{"img"}})
+	schema, err := s.Import(renameType{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, ok := schema.ResourceFields["image"]; ok {
+		t.Fatal("expected image to be removed from the schema")
+	}
+	if _, ok := schema.ResourceFields["containerImage"]; !ok {
+		t.Fatal("expected containerImage on the schema")
+	}
+
+	internal := data.Object{"name": "test", "image": "nginx"}
+	obj := data.Object{"name": "test", "image": "nginx"}
+	schema.Mapper.FromInternal(obj)
+	if expected := (data.Object{"name": "test", "containerImage": "nginx"}); !reflect.DeepEqual(obj, expected) {
+		t.Fatalf("expected %v, got %v", expected, obj)
+	}
+	if err := schema.Mapper.ToInternal(obj); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(obj, internal) {
+		t.Fatalf("expected %v after the round trip, got %v", internal, obj)
+	}
+}
+
+func TestRenameAliases(t *testing.T) {
+	r := Rename{From: "image", To: "containerImage", Aliases: []string{"img"}}
+
+	obj := data.Object{"img": "nginx"}
+	if err := r.ToInternal(obj); err != nil {
+		t.Fatal(err)
+	}
+	if expected := (data.Object{"image": "nginx"}); !reflect.DeepEqual(obj, expected) {
+		t.Fatalf("expected %v, got %v", expected, obj)
+	}
+
+	// To wins over the aliases and the aliases are removed
+	obj = data.Object{"img": "busybox", "containerImage": "nginx"}
+	if err := r.ToInternal(obj); err != nil {
+		t.Fatal(err)
+	}
+	if expected := (data.Object{"image": "nginx"}); !reflect.DeepEqual(obj, expected) {
+		t.Fatalf("expected %v, got %v", expected, obj)
+	}
+}
+
+func TestRenameErrors(t *testing.T) {
+	for name, mapper := range map[string]Rename{
+		"missing field":  {From: "missing", To: "other"},
+		"existing field": {From: "image", To: "name"},
+	} {
+		s := schemas.EmptySchemas().AddMapperForType(renameType{}, mapper)
+		if _, err := s.Import(renameType{}); err == nil {
+			t.Errorf("%s: expected error", name)
+		}
+	}
+}
@@ -0,0 +1,89 @@
+package mappers
+
+import (
+	"fmt"
+	"sort"
+
+	schemas "github.com/acorn-io/schemer"
+	"github.com/acorn-io/schemer/data"
+	"github.com/acorn-io/schemer/data/convert"
+	"github.com/acorn-io/schemer/definition"
+)
+
+// SliceToMap exposes the array of objects Field as a map keyed by the value of
+// each object's Key field. The key is removed from the map values and added
+// back on ToInternal, where the slice is rebuilt in key order.
+type SliceToMap struct {
+	Field string
+	Key   string
+}
+
+func (m SliceToMap) FromInternal(obj data.Object) {
+	items, ok := obj[m.Field].([]interface{})
+	if !ok {
+		return
+	}
+
+	result := map[string]interface{}{}
+	for _, item := range items {
+		mapItem, ok := item.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		name := convert.ToString(mapItem[m.Key])
+		delete(mapItem, m.Key)
+		result[name] = mapItem
+	}
+
+	obj[m.Field] = result
+}
+
+func (m SliceToMap) ToInternal(obj data.Object) error {
+	items, ok := obj[m.Field].(map[string]interface{})
+	if !ok {
+		return nil
+	}
+
+	keys := make([]string, 0, len(items))
+	for key := range items {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	result := make([]interface{}, 0, len(items))
+	for _, key := range keys {
+		mapItem, ok := items[key].(map[string]interface{})
+		if !ok {
+			if items[key] != nil {
+				return fmt.Errorf("value of %s in field %s is not an object", key, m.Field)
+			}
+			mapItem = map[string]interface{}{}
+		}
+		mapItem[m.Key] = key
+		result = append(result, mapItem)
+	}
+
+	obj[m.Field] = result
+	return nil
+}
+
+func (m SliceToMap) ModifySchema(schema *schemas.Schema, s *schemas.Schemas) error {
+	field, ok := schema.ResourceFields[m.Field]
+	if !ok {
+		return fmt.Errorf("failed to find field %s on schema %s", m.Field, schema.ID)
+	}
+	if !definition.IsArrayType(field.Type) {
+		return fmt.Errorf("field %s on schema %s is not an array", m.Field, schema.ID)
+	}
+
+	subType := definition.SubType(field.Type)
+	if subSchema := s.Schema(subType); subSchema == nil {
+		return fmt.Errorf("failed to find schema %s for field %s on schema %s", subType, m.Field, schema.ID)
+	} else if _, ok := subSchema.ResourceFields[m.Key]; !ok {
+		return fmt.Errorf("failed to find key field %s on schema %s", m.Key, subType)
+	}
+
+	field.Type = fmt.Sprintf("map[%s]", subType)
+	schema.ResourceFields[m.Field] = field
+	return nil
+}
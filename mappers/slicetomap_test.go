@@ -0,0 +1,99 @@
+package mappers
+
+import (
+	"reflect"
+	"testing"
+
+	schemas "github.com/acorn-io/schemer"
+	"github.com/acorn-io/schemer/data"
+)
+
+type sliceToMapPort struct {
+	Name string `json:"name"`
+	Port int    `json:"port"`
+}
+
+type sliceToMapType struct {
+	Name  string           `json:"name"`
+	Ports []sliceToMapPort `json:"ports"`
+}
+
+func TestSliceToMap(t *testing.T) {
+	s := schemas.EmptySchemas().
+		AddMapperForType(sliceToMapType{}, SliceToMap{Field: "ports", Key: "name"})
+	schema, err := s.Import(sliceToMapType{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if schema.ResourceFields["ports"].Type != "map[sliceToMapPort]" {
+		t.Fatalf("expected a map of ports, got %s", schema.ResourceFields["ports"].Type)
+	}
+
+	internal := data.Object{
+		"name": "test",
+		"ports": []interface{}{
+			map[string]interface{}{"name": "http", "port": 80},
+			map[string]interface{}{"name": "admin", "port": 8080},
+		},
+	}
+	obj := data.Object{
+		"name": "test",
+		"ports": []interface{}{
+			map[string]interface{}{"name": "http", "port": 80},
+			map[string]interface{}{"name": "admin", "port": 8080},
+		},
+	}
+	schema.Mapper.FromInternal(obj)
+	external := data.Object{
+		"name": "test",
+		"ports": map[string]interface{}{
+			"http":  map[string]interface{}{"port": 80},
+			"admin": map[string]interface{}{"port": 8080},
+		},
+	}
+	if !reflect.DeepEqual(obj, external) {
+		t.Fatalf("expected %v, got %v", external, obj)
+	}
+
+	if err := schema.Mapper.ToInternal(obj); err != nil {
+		t.Fatal(err)
+	}
+	// the slice is rebuilt in key order
+	internal["ports"] = []interface{}{
+		map[string]interface{}{"name": "admin", "port": 8080},
+		map[string]interface{}{"name": "http", "port": 80},
+	}
+	if !reflect.DeepEqual(obj, internal) {
+		t.Fatalf("expected %v, got %v", internal, obj)
+	}
+}
+
+func TestSliceToMapToInternal(t *testing.T) {
+	m := SliceToMap{Field: "ports", Key: "name"}
+
+	obj := data.Object{"ports": map[string]interface{}{"http": nil}}
+	if err := m.ToInternal(obj); err != nil {
+		t.Fatal(err)
+	}
+	if expected := []interface{}{map[string]interface{}{"name": "http"}}; !reflect.DeepEqual(obj["ports"], expected) {
+		t.Fatalf("expected %v, got %v", expected, obj["ports"])
+	}
+
+	obj = data.Object{"ports": map[string]interface{}{"http": "80"}}
+	if err := m.ToInternal(obj); err == nil {
+		t.Fatal("expected error for a value that is not an object")
+	}
+}
+
+func TestSliceToMapErrors(t *testing.T) {
+	for name, mapper := range map[string]SliceToMap{
+		"missing field": {Field: "missing", Key: "name"},
+		"not an array":  {Field: "name", Key: "name"},
+		"missing key":   {Field: "ports", Key: "missing"},
+	} {
+		s := schemas.EmptySchemas().AddMapperForType(sliceToMapType{}, mapper)
+		if _, err := s.Import(sliceToMapType{}); err == nil {
+			t.Errorf("%s: expected error", name)
+		}
+	}
+}
@@ -0,0 +1,86 @@
+package mappers
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	schemas "github.com/acorn-io/schemer"
+	"github.com/acorn-io/schemer/data"
+)
+
+var (
+	// conditions that indicate an error when True
+	errorTrueConditions = []string{"Failed", "Stalled", "ReplicaFailure"}
+	// conditions that indicate the object is not ready yet when False
+	readyConditions = []string{"Ready", "Available", "Established", "Initialized"}
+	// conditions that indicate the object is being changed when True
+	transitioningConditions = []string{"Progressing", "Reconciling"}
+)
+
+// Status summarizes Kubernetes style status.conditions into the read-only
+// fields state, transitioning and transitioningMessage. The state is taken
+// from status.phase if set and is "error" if an error condition is found.
+// transitioning is one of "yes", "no" or "error". ToInternal is a no-op.
+type Status struct{}
+
+func (s Status) FromInternal(obj data.Object) {
+	var (
+		isError, isTransitioning bool
+		messages                 []string
+	)
+
+	for _, cond := range obj.Slice("status", "conditions") {
+		condType := cond.String("type")
+		status := cond.String("status")
+		message := cond.String("message")
+		if message == "" {
+			message = cond.String("reason")
+		}
+
+		switch {
+		case slices.Contains(errorTrueConditions, condType) && status == "True":
+			isError = true
+		case slices.Contains(readyConditions, condType) && status == "False":
+			isTransitioning = true
+		case slices.Contains(transitioningConditions, condType) && status == "True":
+			isTransitioning = true
+		default:
+			continue
+		}
+
+		if message != "" {
+			messages = append(messages, fmt.Sprintf("[%s] %s", condType, message))
+		}
+	}
+
+	state := strings.ToLower(obj.String("status", "phase"))
+	if state == "" {
+		state = "active"
+	}
+
+	transitioning := "no"
+	if isError {
+		state = "error"
+		transitioning = "error"
+	} else if isTransitioning {
+		transitioning = "yes"
+	}
+
+	obj["state"] = state
+	obj["transitioning"] = transitioning
+	obj["transitioningMessage"] = strings.Join(messages, "; ")
+}
+
+func (s Status) ToInternal(obj data.Object) error {
+	return nil
+}
+
+func (s Status) ModifySchema(schema *schemas.Schema, _ *schemas.Schemas) error {
+	for _, name := range []string{"state", "transitioning", "transitioningMessage"} {
+		if err := addReadOnlyField(schema, name, "string"); err != nil {
+			return err
+		}
+	}
+	return nil
+}
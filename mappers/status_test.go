@@ -0,0 +1,90 @@
+package mappers
+
+import (
+	"testing"
+
+	schemas "github.com/acorn-io/schemer"
+	"github.com/acorn-io/schemer/data"
+)
+
+type statusType struct {
+	Status map[string]interface{} `json:"status"`
+}
+
+func condition(condType, status, message string) map[string]interface{} {
+	return map[string]interface{}{"type": condType, "status": status, "message": message}
+}
+
+func TestStatus(t *testing.T) {
+	tests := []struct {
+		name                          string
+		status                        map[string]interface{}
+		state, transitioning, message string
+	}{
+		{
+			name:          "no status",
+			state:         "active",
+			transitioning: "no",
+		},
+		{
+			name: "ready",
+			status: map[string]interface{}{
+				"phase":      "Running",
+				"conditions": []interface{}{condition("Ready", "True", "")},
+			},
+			state:         "running",
+			transitioning: "no",
+		},
+		{
+			name: "not ready",
+			status: map[string]interface{}{
+				"conditions": []interface{}{
+					condition("Available", "False", "waiting for replicas"),
+					condition("Progressing", "True", "scaling"),
+				},
+			},
+			state:         "active",
+			transitioning: "yes",
+			message:       "[Available] waiting for replicas; [Progressing] scaling",
+		},
+		{
+			name: "error",
+			status: map[string]interface{}{
+				"phase": "Running",
+				"conditions": []interface{}{
+					map[string]interface{}{"type": "Failed", "status": "True", "reason": "CrashLoop"},
+				},
+			},
+			state:         "error",
+			transitioning: "error",
+			message:       "[Failed] CrashLoop",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			obj := data.Object{}
+			if tt.status != nil {
+				obj["status"] = tt.status
+			}
+			Status{}.FromInternal(obj)
+			if obj["state"] != tt.state || obj["transitioning"] != tt.transitioning || obj["transitioningMessage"] != tt.message {
+				t.Fatalf("expected %s/%s/%q, got %v/%v/%q", tt.state, tt.transitioning, tt.message,
+					obj["state"], obj["transitioning"], obj["transitioningMessage"])
+			}
+		})
+	}
+}
+
+func TestStatusSchema(t *testing.T) {
+	s := schemas.EmptySchemas().AddMapperForType(statusType{}, Status{})
+	schema, err := s.Import(statusType{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, name := range []string{"state", "transitioning", "transitioningMessage"} {
+		if field, ok := schema.ResourceFields[name]; !ok || field.Create || field.Update {
+			t.Errorf("expected read-only field %s, got %+v", name, field)
+		}
+	}
+}
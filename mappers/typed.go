@@ -0,0 +1,90 @@
+package mappers
+
+import (
+	schemas "github.com/acorn-io/schemer"
+	"github.com/acorn-io/schemer/data"
+	"github.com/acorn-io/schemer/data/convert"
+	"github.com/sirupsen/logrus"
+)
+
+type typed[T any] struct {
+	from func(*T)
+	to   func(*T) error
+}
+
+// Typed returns a mapper that decodes the object into a T, calls from on
+// FromInternal or to on ToInternal and encodes the result back into the
+// object. Keys T doesn't have a field for are kept. Either function may be
+// nil.
+func Typed[T any](from func(*T), to func(*T) error) schemas.Mapper {
+	return typed[T]{
+		from: from,
+		to:   to,
+	}
+}
+
+func (t typed[T]) FromInternal(obj data.Object) {
+	if t.from == nil || obj == nil {
+		return
+	}
+	if err := t.apply(obj, func(v *T) error {
+		t.from(v)
+		return nil
+	}); err != nil {
+		logrus.Errorf("Failed to map %T from internal: %v", new(T), err)
+	}
+}
+
+func (t typed[T]) ToInternal(obj data.Object) error {
+	if t.to == nil || obj == nil {
+		return nil
+	}
+	return t.apply(obj, t.to)
+}
+
+func (t typed[T]) ModifySchema(schema *schemas.Schema, s *schemas.Schemas) error {
+	return nil
+}
+
+func (t typed[T]) apply(obj data.Object, f func(*T) error) error {
+	var v T
+	if err := convert.ToObj(map[string]interface{}(obj), &v); err != nil {
+		return err
+	}
+	// the keys T knows about are the ones that survive the round trip
+	before, err := convert.EncodeToMap(&v)
+	if err != nil {
+		return err
+	}
+	if err := f(&v); err != nil {
+		return err
+	}
+
+	after, err := convert.EncodeToMap(&v)
+	if err != nil {
+		return err
+	}
+
+	mergeTyped(obj, before, after)
+	return nil
+}
+
+// mergeTyped applies the changes between before and after to obj, leaving the
+// keys of obj that are in neither alone.
+func mergeTyped(obj, before, after map[string]interface{}) {
+	for k := range before {
+		if _, ok := after[k]; !ok {
+			delete(obj, k)
+		}
+	}
+	for k, v := range after {
+		objMap, objIsMap := obj[k].(map[string]interface{})
+		beforeMap, beforeIsMap := before[k].(map[string]interface{})
+		afterMap, afterIsMap := v.(map[string]interface{})
+		if objIsMap && beforeIsMap && afterIsMap {
+			mergeTyped(objMap, beforeMap, afterMap)
+			continue
+		}
+		obj[k] = v
+	}
+}
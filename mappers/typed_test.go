@@ -0,0 +1,66 @@
+package mappers
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/acorn-io/schemer/data"
+)
+
+type typedSpec struct {
+	Image  string            `json:"image,omitempty"`
+	Labels map[string]string `json:"labels,omitempty"`
+}
+
+type typedApp struct {
+	Name string    `json:"name"`
+	Spec typedSpec `json:"spec"`
+}
+
+func TestTyped(t *testing.T) {
+	mapper := Typed(func(app *typedApp) {
+		app.Name = "external-" + app.Name
+		delete(app.Spec.Labels, "internal")
+		app.Spec.Image = ""
+	}, func(app *typedApp) error {
+		if app.Name == "" {
+			return errors.New("name is required")
+		}
+		app.Spec.Image = "nginx"
+		return nil
+	})
+
+	obj := data.Object{
+		"name":    "app",
+		"unknown": "kept",
+		"spec": map[string]interface{}{
+			"image":   "redis",
+			"labels":  map[string]interface{}{"internal": "true", "app": "web"},
+			"unknown": "kept",
+		},
+	}
+	mapper.FromInternal(obj)
+	expected := data.Object{
+		"name":    "external-app",
+		"unknown": "kept",
+		"spec": map[string]interface{}{
+			"labels":  map[string]interface{}{"app": "web"},
+			"unknown": "kept",
+		},
+	}
+	if !reflect.DeepEqual(obj, expected) {
+		t.Fatalf("expected %v, got %v", expected, obj)
+	}
+
+	if err := mapper.ToInternal(obj); err != nil {
+		t.Fatal(err)
+	}
+	if data.GetValueN(obj, "spec", "image") != "nginx" || obj["unknown"] != "kept" {
+		t.Fatalf("unexpected object %v", obj)
+	}
+
+	if err := mapper.ToInternal(data.Object{"other": 1}); err == nil || err.Error() != "name is required" {
+		t.Fatalf("expected an error, got %v", err)
+	}
+}
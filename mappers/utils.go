@@ -0,0 +1,114 @@
+package mappers
+
+import (
+	"fmt"
+	"strings"
+
+	schemas "github.com/acorn-io/schemer"
+	"github.com/acorn-io/schemer/data"
+	"github.com/acorn-io/schemer/data/convert"
+)
+
+func splitPath(path string) []string {
+	return strings.Split(path, "/")
+}
+
+// getField walks the "/" separated path through the sub-schemas of schema and
+// returns the schema that owns the last path element. The returned schema is
+// nil if one of the parents along the path does not exist. Sub-schemas along
+// the path are replaced by copies owned by schema, see ownedSchema, so the
+// returned schema can be modified.
+func getField(schema *schemas.Schema, s *schemas.Schemas, path string) (*schemas.Schema, string, schemas.Field, bool, error) {
+	parts := splitPath(path)
+	for _, part := range parts[:len(parts)-1] {
+		field, ok := schema.ResourceFields[part]
+		if !ok {
+			return nil, "", schemas.Field{}, false, nil
+		}
+		subSchema, err := ownedSchema(schema, s, part, field)
+		if err != nil {
+			return nil, "", schemas.Field{}, false, err
+		}
+		schema = subSchema
+	}
+
+	name := parts[len(parts)-1]
+	field, ok := schema.ResourceFields[name]
+	return schema, name, field, ok, nil
+}
+
+// ownedSchema returns the schema of the field name of parent. Sub-schemas are
+// shared by all types that use them, so the first time it's called for a
+// field the schema is copied, registered under the ID of the parent followed
+// by the capitalized field name and the field is changed to the copy.
+func ownedSchema(parent *schemas.Schema, s *schemas.Schemas, name string, field schemas.Field) (*schemas.Schema, error) {
+	id := parent.ID + convert.Capitalize(name)
+	if field.Type == id {
+		if owned := s.Schema(id); owned != nil {
+			return owned, nil
+		}
+	}
+
+	subSchema := s.Schema(field.Type)
+	if subSchema == nil {
+		return nil, fmt.Errorf("field %s on schema %s is not an object", name, parent.ID)
+	}
+	if s.Schema(id) != nil {
+		return nil, fmt.Errorf("can not copy schema %s of field %s on schema %s, schema %s already exists", field.Type, name, parent.ID, id)
+	}
+
+	owned := subSchema.DeepCopy()
+	owned.ID = id
+	owned.CodeName = convert.Capitalize(id)
+	owned.CodeNamePlural = ""
+	owned.PluralName = ""
+	if err := s.AddSchema(*owned); err != nil {
+		return nil, err
+	}
+
+	field.Type = id
+	parent.ResourceFields[name] = field
+	return s.Schema(id), nil
+}
+
+func isEmpty(v interface{}) bool {
+	switch t := v.(type) {
+	case nil:
+		return true
+	case string:
+		return t == ""
+	case map[string]interface{}:
+		return len(t) == 0
+	case data.Object:
+		return len(t) == 0
+	case []interface{}:
+		return len(t) == 0
+	case []string:
+		return len(t) == 0
+	}
+	return false
+}
+
+func addField(schema *schemas.Schema, name, fieldType string) error {
+	if _, ok := schema.ResourceFields[name]; ok {
+		return fmt.Errorf("field %s already exists on schema %s", name, schema.ID)
+	}
+	schema.ResourceFields[name] = schemas.Field{
+		Type:     fieldType,
+		Nullable: true,
+		Create:   true,
+		Update:   true,
+	}
+	return nil
+}
+
+func addReadOnlyField(schema *schemas.Schema, name, fieldType string) error {
+	if err := addField(schema, name, fieldType); err != nil {
+		return err
+	}
+	field := schema.ResourceFields[name]
+	field.Create = false
+	field.Update = false
+	schema.ResourceFields[name] = field
+	return nil
+}
@@ -0,0 +1,70 @@
+package schemas
+
+import (
+	"context"
+	"slices"
+)
+
+const (
+	MethodGet    = "GET"
+	MethodPost   = "POST"
+	MethodPut    = "PUT"
+	MethodDelete = "DELETE"
+)
+
+// MethodFilter restricts the collection and resource methods of a schema for
+// the caller identified by ctx. Filters must only remove methods.
+type MethodFilter func(ctx context.Context, schema *Schema, collectionMethods, resourceMethods []string) ([]string, []string)
+
+func (s *Schema) CanList() bool {
+	return slices.Contains(s.CollectionMethods, MethodGet)
+}
+
+func (s *Schema) CanCreate() bool {
+	return slices.Contains(s.CollectionMethods, MethodPost)
+}
+
+func (s *Schema) CanGet() bool {
+	return slices.Contains(s.ResourceMethods, MethodGet)
+}
+
+func (s *Schema) CanUpdate() bool {
+	return slices.Contains(s.ResourceMethods, MethodPut)
+}
+
+func (s *Schema) CanDelete() bool {
+	return slices.Contains(s.ResourceMethods, MethodDelete)
+}
+
+// AddMethodFilter registers a filter applied by AllowedMethods.
+func (s *Schemas) AddMethodFilter(filter MethodFilter) *Schemas {
+	s.Lock()
+	defer s.Unlock()
+	s.methodFilters = append(s.methodFilters, filter)
+	return s
+}
+
+// AllowedMethods returns the collection and resource methods of schema that
+// the caller identified by ctx may use.
+func (s *Schemas) AllowedMethods(ctx context.Context, schema *Schema) (collectionMethods []string, resourceMethods []string) {
+	s.RLock()
+	filters := slices.Clone(s.methodFilters)
+	s.RUnlock()
+
+	collectionMethods = slices.Clone(schema.CollectionMethods)
+	resourceMethods = slices.Clone(schema.ResourceMethods)
+	for _, filter := range filters {
+		collectionMethods, resourceMethods = filter(ctx, schema, collectionMethods, resourceMethods)
+	}
+	return collectionMethods, resourceMethods
+}
+
+// Allowed returns true if the caller identified by ctx may use method on the
+// collection, or on a single resource if collection is false.
+func (s *Schemas) Allowed(ctx context.Context, schema *Schema, method string, collection bool) bool {
+	collectionMethods, resourceMethods := s.AllowedMethods(ctx, schema)
+	if collection {
+		return slices.Contains(collectionMethods, method)
+	}
+	return slices.Contains(resourceMethods, method)
+}
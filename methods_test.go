@@ -0,0 +1,61 @@
+package schemas
+
+import (
+	"context"
+	"reflect"
+	"slices"
+	"testing"
+)
+
+type roleKey struct{}
+
+func TestMethods(t *testing.T) {
+	schema := &Schema{
+		CollectionMethods: []string{MethodGet, MethodPost},
+		ResourceMethods:   []string{MethodGet, MethodPut, MethodDelete},
+	}
+	if !schema.CanList() || !schema.CanCreate() || !schema.CanGet() || !schema.CanUpdate() || !schema.CanDelete() {
+		t.Fatal("expected all methods to be allowed")
+	}
+
+	schema = &Schema{ResourceMethods: []string{MethodGet}}
+	if schema.CanList() || schema.CanCreate() || !schema.CanGet() || schema.CanUpdate() || schema.CanDelete() {
+		t.Fatal("expected only get to be allowed")
+	}
+}
+
+func TestAllowedMethods(t *testing.T) {
+	s := EmptySchemas()
+	s.AddMethodFilter(func(ctx context.Context, schema *Schema, collectionMethods, resourceMethods []string) ([]string, []string) {
+		if ctx.Value(roleKey{}) == "admin" {
+			return collectionMethods, resourceMethods
+		}
+		readOnly := func(method string) bool { return method != MethodGet }
+		return slices.DeleteFunc(collectionMethods, readOnly), slices.DeleteFunc(resourceMethods, readOnly)
+	})
+
+	schema := &Schema{
+		CollectionMethods: []string{MethodGet, MethodPost},
+		ResourceMethods:   []string{MethodGet, MethodPut, MethodDelete},
+	}
+
+	collectionMethods, resourceMethods := s.AllowedMethods(context.Background(), schema)
+	if !reflect.DeepEqual(collectionMethods, []string{MethodGet}) || !reflect.DeepEqual(resourceMethods, []string{MethodGet}) {
+		t.Fatalf("expected read-only methods, got %v %v", collectionMethods, resourceMethods)
+	}
+	// the filter must not change the schema
+	if len(schema.CollectionMethods) != 2 || len(schema.ResourceMethods) != 3 {
+		t.Fatalf("expected the schema to be unchanged, got %v %v", schema.CollectionMethods, schema.ResourceMethods)
+	}
+
+	admin := context.WithValue(context.Background(), roleKey{}, "admin")
+	if !s.Allowed(admin, schema, MethodPost, true) || !s.Allowed(admin, schema, MethodDelete, false) {
+		t.Fatal("expected admin to be allowed everything")
+	}
+	if s.Allowed(context.Background(), schema, MethodPost, true) || s.Allowed(context.Background(), schema, MethodDelete, false) {
+		t.Fatal("expected writes to be filtered")
+	}
+	if !s.Allowed(context.Background(), schema, MethodGet, false) {
+		t.Fatal("expected get to be allowed")
+	}
+}
@@ -4,9 +4,11 @@ import (
 	"encoding/json"
 	"fmt"
 	"sort"
+	"strconv"
 	"strings"
 
 	types "github.com/acorn-io/schemer"
+	"github.com/acorn-io/schemer/data/convert"
 	"github.com/acorn-io/schemer/definition"
 	v1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 )
@@ -40,9 +42,12 @@ func ToOpenAPI(name string, schemas *types.Schemas) (*v1.JSONSchemaProps, error)
 
 func populateField(fieldJSP *v1.JSONSchemaProps, f *types.Field) error {
 	fieldJSP.Description = f.Description
+	if fieldJSP.Description == "" {
+		fieldJSP.Description = referenceDescription(f.Type)
+	}
 	// don't reset this to not nullable
-	if f.Nullable {
-		fieldJSP.Nullable = f.Nullable
+	if f.IsNullable() {
+		fieldJSP.Nullable = true
 	}
 	fieldJSP.MinLength = f.MinLength
 	fieldJSP.MaxLength = f.MaxLength
@@ -67,6 +72,10 @@ func populateField(fieldJSP *v1.JSONSchemaProps, f *types.Field) error {
 		fieldJSP.Pattern = fmt.Sprintf("^[%s]*$", f.ValidChars)
 	}
 
+	if f.Pattern != "" {
+		fieldJSP.Pattern = f.Pattern
+	}
+
 	if f.Min != nil {
 		fl := float64(*f.Min)
 		fieldJSP.Minimum = &fl
@@ -90,7 +99,32 @@ func populateField(fieldJSP *v1.JSONSchemaProps, f *types.Field) error {
 	return nil
 }
 
+// referenceDescription describes the target of reference fields, which are
+// plain strings in OpenAPI.
+func referenceDescription(fieldType string) string {
+	t, err := definition.Parse(fieldType)
+	if err != nil {
+		return ""
+	}
+	t, _ = t.Unwrap()
+	if !t.IsReference() {
+		return ""
+	}
+	gvk := t.GroupVersionKind()
+	if t.IsQualified() {
+		return fmt.Sprintf("Reference to a %s in %s", gvk.Kind, gvk.GroupVersion())
+	}
+	return fmt.Sprintf("Reference to a %s", gvk.Kind)
+}
+
 func typeToProps(typeName string, schemas *types.Schemas, inflight map[string]bool) (*v1.JSONSchemaProps, error) {
+	if jsp, ok, err := constraintProps(typeName, schemas, inflight); ok || err != nil {
+		return jsp, err
+	}
+	if jsp, ok, err := constructorProps(typeName, schemas, inflight); ok || err != nil {
+		return jsp, err
+	}
+
 	t, subType, schema, err := typeAndSchema(typeName, schemas)
 	if err != nil {
 		return nil, err
@@ -129,6 +163,10 @@ func typeToProps(typeName string, schemas *types.Schemas, inflight map[string]bo
 	case "string":
 		jsp.Type = t
 		jsp.Nullable = true
+	case "quantity":
+		jsp.AnyOf = []v1.JSONSchemaProps{{Type: "integer"}, {Type: "string"}}
+		jsp.Pattern = convert.QuantityPattern
+		jsp.XIntOrString = true
 	default:
 		jsp.Type = t
 	}
@@ -140,6 +178,71 @@ func typeToProps(typeName string, schemas *types.Schemas, inflight map[string]bo
 	return jsp, nil
 }
 
+// constraintProps returns the schema of types with constraints, like
+// "string{maxLength=63}".
+func constraintProps(typeName string, schemas *types.Schemas, inflight map[string]bool) (*v1.JSONSchemaProps, bool, error) {
+	t, err := definition.Parse(typeName)
+	if err != nil || len(t.Constraints) == 0 {
+		return nil, false, nil
+	}
+
+	jsp, err := typeToProps(t.WithoutConstraints().String(), schemas, inflight)
+	if err != nil {
+		return nil, true, err
+	}
+	result := *jsp
+	for _, c := range t.Constraints {
+		switch c.Key {
+		case "min", "max":
+			n, err := strconv.ParseFloat(c.Value, 64)
+			if err != nil {
+				return nil, true, err
+			}
+			if c.Key == "min" {
+				result.Minimum = &n
+			} else {
+				result.Maximum = &n
+			}
+		case "minLength", "maxLength":
+			n, err := strconv.ParseInt(c.Value, 10, 64)
+			if err != nil {
+				return nil, true, err
+			}
+			if c.Key == "minLength" {
+				result.MinLength = &n
+			} else {
+				result.MaxLength = &n
+			}
+		case "pattern":
+			result.Pattern = c.Value
+		}
+	}
+	return &result, true, nil
+}
+
+// constructorProps returns the schema of types registered in the definition
+// package with an OpenAPI function.
+func constructorProps(typeName string, schemas *types.Schemas, inflight map[string]bool) (*v1.JSONSchemaProps, bool, error) {
+	t, err := definition.Parse(typeName)
+	if err != nil {
+		return nil, false, nil
+	}
+	c, ok := definition.Lookup(t.Name)
+	if !ok || c.OpenAPI == nil {
+		return nil, false, nil
+	}
+
+	var elem *v1.JSONSchemaProps
+	if t.Elem != nil && !t.IsReference() {
+		elem, err = typeToProps(t.Elem.String(), schemas, inflight)
+		if err != nil {
+			return nil, true, err
+		}
+	}
+	jsp, err := c.OpenAPI(t, elem)
+	return jsp, true, err
+}
+
 func schemaToProps(schema *types.Schema, schemas *types.Schemas, inflight map[string]bool) (*v1.JSONSchemaProps, error) {
 	jsp := &v1.JSONSchemaProps{
 		Description: schema.Description,
@@ -155,7 +258,8 @@ func schemaToProps(schema *types.Schema, schemas *types.Schemas, inflight map[st
 
 	jsp.Properties = map[string]v1.JSONSchemaProps{}
 
-	for name, f := range schema.ResourceFields {
+	for _, name := range schema.OrderedFieldNames() {
+		f := schema.ResourceFields[name]
 		fieldJSP, err := typeToProps(f.Type, schemas, inflight)
 		if err != nil {
 			return nil, err
@@ -207,6 +311,8 @@ func typeAndSchema(typeName string, schemas *types.Schemas) (string, string, *ty
 		return "string", "", nil, nil
 	case "password":
 		return "string", "", nil, nil
+	case "quantity":
+		return "quantity", "", nil, nil
 	case "hostname":
 		return "string", "", nil, nil
 	case "boolean":
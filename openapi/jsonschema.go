@@ -0,0 +1,57 @@
+package openapi
+
+import (
+	"strings"
+
+	"github.com/acorn-io/schemer/data/convert"
+	v1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+)
+
+// JSONSchemaDraft is the $schema of the documents returned by ToJSONSchema.
+const JSONSchemaDraft = "https://json-schema.org/draft/2020-12/schema"
+
+// ToJSONSchema converts an OpenAPI schema to JSON Schema. Nullable types get
+// "null" as an additional type and Kubernetes extensions are dropped. The
+// result has no $schema so it can be embedded in other documents.
+func ToJSONSchema(props *v1.JSONSchemaProps) (map[string]interface{}, error) {
+	m, err := convert.EncodeToMap(props)
+	if err != nil {
+		return nil, err
+	}
+	return toJSONSchema(m), nil
+}
+
+func toJSONSchema(props map[string]interface{}) map[string]interface{} {
+	result := map[string]interface{}{}
+	for k, v := range props {
+		if strings.HasPrefix(k, "x-kubernetes-") || k == "nullable" {
+			continue
+		}
+		switch k {
+		case "properties", "patternProperties", "definitions":
+			nested := map[string]interface{}{}
+			for name, p := range convert.ToMapInterface(v) {
+				nested[name] = toJSONSchema(convert.ToMapInterface(p))
+			}
+			v = nested
+		case "items", "additionalProperties", "not":
+			if m, ok := v.(map[string]interface{}); ok {
+				v = toJSONSchema(m)
+			}
+		case "allOf", "anyOf", "oneOf":
+			var nested []interface{}
+			for _, p := range convert.ToInterfaceSlice(v) {
+				nested = append(nested, toJSONSchema(convert.ToMapInterface(p)))
+			}
+			v = nested
+		}
+		result[k] = v
+	}
+
+	if nullable, _ := props["nullable"].(bool); nullable {
+		if t, ok := result["type"].(string); ok {
+			result["type"] = []interface{}{t, "null"}
+		}
+	}
+	return result
+}
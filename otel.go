@@ -0,0 +1,35 @@
+package schemas
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/acorn-io/schemer/internal/tracing"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// InstrumentationName is the name of the OpenTelemetry tracer used for the
+// spans of imports and mappers. Spans are only recorded if the application
+// configures a tracer provider with otel.SetTracerProvider.
+const InstrumentationName = "github.com/acorn-io/schemer"
+
+const (
+	AttributeSchemaID  = attribute.Key("schemer.schema.id")
+	AttributeType      = attribute.Key("schemer.type")
+	AttributeMapper    = attribute.Key("schemer.mapper")
+	AttributeDirection = attribute.Key("schemer.direction")
+)
+
+// startMapperSpan starts a span for a single mapper invocation. Mappers run for
+// every object, so a span is only started if the caller is already recording
+// one, for example the span of an HTTP request.
+func startMapperSpan(ctx context.Context, mapper Mapper, direction string) (context.Context, trace.Span) {
+	if !trace.SpanFromContext(ctx).IsRecording() {
+		return ctx, nil
+	}
+	return tracing.Start(ctx, InstrumentationName, "Mapper."+direction,
+		AttributeMapper.String(fmt.Sprintf("%T", mapper)),
+		AttributeDirection.String(direction),
+	)
+}
@@ -0,0 +1,71 @@
+package schemas
+
+import (
+	"context"
+	"testing"
+
+	"go.opentelemetry.io/otel"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func TestSpans(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+	previous := otel.GetTracerProvider()
+	otel.SetTracerProvider(provider)
+	defer otel.SetTracerProvider(previous)
+
+	ctx, parent := provider.Tracer("test").Start(context.Background(), "request")
+
+	s := EmptySchemas()
+	s.AddMapperForType(benchApp{}, benchNoop{})
+	schema, err := s.ImportContext(ctx, benchApp{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	FromInternalContext(ctx, schema.Mapper, benchObject())
+	// without a recording span mappers are not traced
+	FromInternalContext(context.Background(), schema.Mapper, benchObject())
+	parent.End()
+
+	spans := map[string]sdktrace.ReadOnlySpan{}
+	for _, span := range recorder.Ended() {
+		if _, ok := spans[span.Name()]; ok {
+			t.Errorf("span %s was recorded twice", span.Name())
+		}
+		spans[span.Name()] = span
+	}
+
+	for name, attrs := range map[string]map[string]string{
+		"Schemas.Import": {
+			"schemer.type":      "schemas.benchApp",
+			"schemer.schema.id": "benchApp",
+		},
+		"Mapper.FromInternal": {
+			"schemer.mapper":    "schemas.benchNoop",
+			"schemer.direction": "FromInternal",
+		},
+	} {
+		span, ok := spans[name]
+		if !ok {
+			t.Errorf("expected span %s, got %v", name, spans)
+			continue
+		}
+		if span.Parent().SpanID() != parent.SpanContext().SpanID() {
+			t.Errorf("expected span %s to be a child of the request", name)
+		}
+		if span.InstrumentationScope().Name != InstrumentationName {
+			t.Errorf("unexpected instrumentation scope %s", span.InstrumentationScope().Name)
+		}
+		for _, attr := range span.Attributes() {
+			if expected, ok := attrs[string(attr.Key)]; ok && attr.Value.AsString() != expected {
+				t.Errorf("expected %s=%s on span %s, got %s", attr.Key, expected, name, attr.Value.AsString())
+			}
+			delete(attrs, string(attr.Key))
+		}
+		if len(attrs) > 0 {
+			t.Errorf("missing attributes %v on span %s", attrs, name)
+		}
+	}
+}
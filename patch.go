@@ -0,0 +1,63 @@
+package schemas
+
+import (
+	"github.com/acorn-io/schemer/data"
+	"github.com/acorn-io/schemer/definition"
+)
+
+type patchSchema struct {
+	schema  *Schema
+	schemas *Schemas
+}
+
+// PatchSchema returns the strategic merge metadata of the schema's fields for
+// use with data.MergePatch. Pass the InternalSchema to merge internal data.
+func (s *Schema) PatchSchema(schemas *Schemas) data.MergeSchema {
+	return patchSchema{
+		schema:  s,
+		schemas: schemas,
+	}
+}
+
+func (p patchSchema) MergeStrategy(field string) (string, string) {
+	f := p.schema.ResourceFields[field]
+	return f.PatchStrategy, f.PatchMergeKey
+}
+
+func (p patchSchema) FieldSchema(field string) data.MergeSchema {
+	f, ok := p.schema.ResourceFields[field]
+	if !ok {
+		return nil
+	}
+
+	fieldType := definition.NonNullableType(f.Type)
+	isMap := definition.IsMapType(fieldType)
+	if isMap || definition.IsArrayType(fieldType) {
+		fieldType = definition.SubType(fieldType)
+	}
+
+	schema := p.schemas.Schema(fieldType)
+	if schema == nil {
+		return nil
+	}
+
+	result := schema.PatchSchema(p.schemas)
+	if isMap {
+		return mapPatchSchema{values: result}
+	}
+	return result
+}
+
+// mapPatchSchema describes map fields, every key of the map has the schema
+// of the map's values.
+type mapPatchSchema struct {
+	values data.MergeSchema
+}
+
+func (m mapPatchSchema) MergeStrategy(field string) (string, string) {
+	return "", ""
+}
+
+func (m mapPatchSchema) FieldSchema(field string) data.MergeSchema {
+	return m.values
+}
@@ -0,0 +1,103 @@
+package schemas
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/acorn-io/schemer/data"
+	"github.com/acorn-io/schemer/data/convert"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// ReferenceResolver verifies the IDs stored in reference[type] fields during
+// ToInternal. The returned ID replaces the input so resolvers can also
+// normalize references.
+type ReferenceResolver interface {
+	ResolveReference(ctx context.Context, schema *Schema, id string) (string, error)
+}
+
+// QualifiedReferenceResolver is implemented by ReferenceResolvers that
+// resolve references qualified with an API group and version, like
+// reference[apps/v1/deployment], which can target types that are not in
+// Schemas.
+type QualifiedReferenceResolver interface {
+	ResolveQualifiedReference(ctx context.Context, gvk schema.GroupVersionKind, id string) (string, error)
+}
+
+type reference struct {
+	targetType string
+	// gvk is set for references qualified with a group and version
+	gvk schema.GroupVersionKind
+	// containers are the arrays and maps the references are nested in
+	containers []fieldKind
+}
+
+func toReference(fieldType string) (reference, bool) {
+	elem, containers, err := parseFieldType(fieldType)
+	if err != nil || !elem.IsReference() {
+		return reference{}, false
+	}
+	ref := reference{
+		targetType: elem.Target(),
+		containers: containers,
+	}
+	if elem.IsQualified() {
+		ref.gvk = elem.GroupVersionKind()
+	}
+	return ref, true
+}
+
+func (r reference) qualified() bool {
+	return r.gvk.Version != ""
+}
+
+func (t *typeMapper) resolveReferences(ctx context.Context, obj data.Object) error {
+	if t.schemas == nil || t.schemas.ReferenceResolver == nil || len(t.references) == 0 {
+		return nil
+	}
+
+	resolver := t.schemas.ReferenceResolver
+	qualifiedResolver, _ := resolver.(QualifiedReferenceResolver)
+	resolve := func(fieldName string, ref reference, targetSchema *Schema, value interface{}) (string, error) {
+		var (
+			id  string
+			err error
+		)
+		if ref.qualified() && qualifiedResolver != nil {
+			id, err = qualifiedResolver.ResolveQualifiedReference(ctx, ref.gvk, convert.ToString(value))
+		} else {
+			id, err = resolver.ResolveReference(ctx, targetSchema, convert.ToString(value))
+		}
+		if err != nil {
+			return "", fmt.Errorf("invalid reference in field %s: %w", fieldName, err)
+		}
+		return id, nil
+	}
+
+	for fieldName, ref := range t.references {
+		value, ok := obj[fieldName]
+		if !ok || value == nil {
+			continue
+		}
+
+		targetSchema := t.schemas.Schema(ref.targetType)
+		if targetSchema == nil && ref.qualified() {
+			if qualifiedResolver == nil {
+				// the target is in another API group
+				continue
+			}
+		} else if targetSchema == nil {
+			return fmt.Errorf("failed to find schema %s referenced by field %s", ref.targetType, fieldName)
+		}
+
+		result, err := mapNested(value, ref.containers, func(value interface{}) (interface{}, error) {
+			return resolve(fieldName, ref, targetSchema, value)
+		})
+		if err != nil {
+			return err
+		}
+		obj[fieldName] = result
+	}
+
+	return nil
+}
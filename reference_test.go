@@ -0,0 +1,72 @@
+package schemas
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/acorn-io/schemer/data"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// lowerResolver lower-cases references and rejects "missing".
+type lowerResolver struct{}
+
+func (lowerResolver) ResolveReference(ctx context.Context, schema *Schema, id string) (string, error) {
+	if id == "missing" {
+		return "", errors.New(schema.ID + " missing not found")
+	}
+	return strings.ToLower(id), nil
+}
+
+func (lowerResolver) ResolveQualifiedReference(ctx context.Context, gvk schema.GroupVersionKind, id string) (string, error) {
+	return gvk.Group + "/" + id, nil
+}
+
+func referenceSchema() *Schema {
+	s := EmptySchemas()
+	s.ReferenceResolver = lowerResolver{}
+	s.MustAddSchema(Schema{ID: "user"})
+	s.MustAddSchema(Schema{
+		ID: "team",
+		ResourceFields: map[string]Field{
+			"owner":      {Type: "reference[user]"},
+			"members":    {Type: "array[reference[user]]"},
+			"deployment": {Type: "reference[apps/v1/deployment]"},
+		},
+	})
+	return s.Schema("team")
+}
+
+func TestResolveReferences(t *testing.T) {
+	schema := referenceSchema()
+
+	obj := data.Object{
+		"owner":      "Alice",
+		"members":    []interface{}{"Bob", "Carol"},
+		"deployment": "web",
+	}
+	if err := schema.Mapper.ToInternal(obj); err != nil {
+		t.Fatal(err)
+	}
+	expected := data.Object{
+		"owner":      "alice",
+		"members":    []interface{}{"bob", "carol"},
+		"deployment": "apps/web",
+	}
+	if !reflect.DeepEqual(obj, expected) {
+		t.Fatalf("expected %v, got %v", expected, obj)
+	}
+}
+
+func TestResolveReferencesError(t *testing.T) {
+	schema := referenceSchema()
+
+	obj := data.Object{"members": []interface{}{"bob", "missing"}}
+	err := schema.Mapper.ToInternal(obj)
+	if err == nil || !strings.Contains(err.Error(), "invalid reference in field members: user missing not found") {
+		t.Fatalf("expected invalid reference error, got %v", err)
+	}
+}
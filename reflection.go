@@ -1,13 +1,14 @@
 package schemas
 
 import (
+	"context"
 	"fmt"
 	"reflect"
-	"slices"
 	"strconv"
 	"strings"
 
 	"github.com/acorn-io/schemer/data/convert"
+	"github.com/acorn-io/schemer/internal/tracing"
 	"github.com/sirupsen/logrus"
 )
 
@@ -19,11 +20,15 @@ var (
 )
 
 func (s *Schemas) TypeName(name string, obj interface{}) *Schemas {
+	s.Lock()
+	defer s.Unlock()
 	s.typeNames[reflect.TypeOf(obj)] = name
 	return s
 }
 
 func (s *Schemas) getTypeName(t reflect.Type) string {
+	s.RLock()
+	defer s.RUnlock()
 	if name, ok := s.typeNames[t]; ok {
 		return name
 	}
@@ -36,6 +41,10 @@ func (s *Schemas) SchemaFor(t reflect.Type) *Schema {
 }
 
 func (s *Schemas) AddMapperForType(obj interface{}, mapper ...Mapper) *Schemas {
+	return s.AddMapperForTypeWithPhase(obj, PhaseMain, mapper...)
+}
+
+func (s *Schemas) AddMapperForTypeWithPhase(obj interface{}, phase MapperPhase, mapper ...Mapper) *Schemas {
 	if len(mapper) == 0 {
 		return s
 	}
@@ -43,9 +52,9 @@ func (s *Schemas) AddMapperForType(obj interface{}, mapper ...Mapper) *Schemas {
 	t := reflect.TypeOf(obj)
 	typeName := s.getTypeName(t)
 	if len(mapper) == 1 {
-		return s.AddMapper(typeName, mapper[0])
+		return s.AddMapperWithPhase(typeName, phase, mapper[0])
 	}
-	return s.AddMapper(typeName, Mappers(mapper))
+	return s.AddMapperWithPhase(typeName, phase, Mappers(mapper))
 }
 
 func (s *Schemas) MustImport(obj interface{}, externalOverrides ...interface{}) *Schemas {
@@ -77,12 +86,46 @@ func getType(obj interface{}) reflect.Type {
 }
 
 func (s *Schemas) Import(obj interface{}, externalOverrides ...interface{}) (*Schema, error) {
+	return s.ImportContext(context.Background(), obj, externalOverrides...)
+}
+
+// ImportContext is Import with a context, the span of the import is a child
+// of the span of ctx.
+func (s *Schemas) ImportContext(ctx context.Context, obj interface{}, externalOverrides ...interface{}) (*Schema, error) {
+	return s.ImportWithOptionsContext(ctx, obj, ImportOptions{}, externalOverrides...)
+}
+
+// ImportWithOptions imports obj like Import but uses opts to generate the IDs
+// of obj and all types imported along with it.
+func (s *Schemas) ImportWithOptions(obj interface{}, opts ImportOptions, externalOverrides ...interface{}) (*Schema, error) {
+	return s.ImportWithOptionsContext(context.Background(), obj, opts, externalOverrides...)
+}
+
+// ImportWithOptionsContext is ImportWithOptions with a context, see
+// ImportContext.
+func (s *Schemas) ImportWithOptionsContext(ctx context.Context, obj interface{}, opts ImportOptions, externalOverrides ...interface{}) (schema *Schema, err error) {
 	var types []reflect.Type
 	for _, override := range externalOverrides {
 		types = append(types, getType(override))
 	}
 
 	t := getType(obj)
+	_, span := tracing.Start(ctx, InstrumentationName, "Schemas.Import", AttributeType.String(t.String()))
+	defer func() {
+		if schema != nil {
+			span.SetAttributes(AttributeSchemaID.String(schema.ID))
+		}
+		tracing.End(span, err)
+	}()
+
+	s.importLock.Lock()
+	defer s.importLock.Unlock()
+
+	s.importOptions = opts
+	defer func() {
+		s.importOptions = ImportOptions{}
+	}()
+
 	return s.importType(t, types...)
 }
 
@@ -97,6 +140,10 @@ func (s *Schemas) newSchemaFromType(t reflect.Type, typeName string) (*Schema, e
 		Attributes:        map[string]interface{}{},
 	}
 
+	if s.importOptions.Descriptions && t.PkgPath() != "" {
+		schema.Description = loadPackageDocs(t.PkgPath(), s.importOptions.SourceDirs).types[t.Name()]
+	}
+
 	s.processingTypes[t] = schema
 	defer delete(s.processingTypes, t)
 
@@ -124,7 +171,12 @@ func (s *Schemas) assignMappers(schema *Schema) error {
 		return nil
 	}
 
-	mappers := s.mapper(schema.ID)
+	embeddedMappers, err := s.applyEmbeds(schema)
+	if err != nil {
+		return err
+	}
+
+	mappers := append(embeddedMappers, s.mapper(schema.ID)...)
 	if canList(schema) {
 		if s.DefaultMapper != nil {
 			mappers = append([]Mapper{s.DefaultMapper()}, mappers...)
@@ -151,12 +203,46 @@ func (s *Schemas) assignMappers(schema *Schema) error {
 	return nil
 }
 
+// applyEmbeds merges the fields of the schemas listed in schema.Embeds into
+// schema. Fields already defined on schema take precedence. The mappers
+// registered for the embedded schemas are returned so they can be run as part
+// of the mapper chain of schema.
+func (s *Schemas) applyEmbeds(schema *Schema) ([]Mapper, error) {
+	var mappers []Mapper
+	for _, id := range schema.Embeds {
+		embedded := s.Schema(id)
+		if embedded == nil {
+			return nil, fmt.Errorf("failed to find schema %s embedded in schema %s", id, schema.ID)
+		}
+
+		fields := embedded.ResourceFields
+		if embedded.InternalSchema != nil {
+			fields = embedded.InternalSchema.ResourceFields
+		}
+
+		if schema.ResourceFields == nil {
+			schema.ResourceFields = map[string]Field{}
+		}
+		for name, field := range fields {
+			if _, ok := schema.ResourceFields[name]; !ok {
+				schema.ResourceFields[name] = *field.DeepCopy()
+			}
+		}
+
+		mappers = append(mappers, s.mapper(embedded.ID)...)
+	}
+	return mappers, nil
+}
+
 func canList(schema *Schema) bool {
-	return slices.Contains(schema.CollectionMethods, "GET")
+	return schema.CanList()
 }
 
 func (s *Schemas) importType(t reflect.Type, overrides ...reflect.Type) (*Schema, error) {
-	typeName := s.getTypeName(t)
+	typeName, err := s.importTypeName(t)
+	if err != nil {
+		return nil, err
+	}
 
 	existing := s.Schema(typeName)
 	if existing != nil {
@@ -175,6 +261,10 @@ func (s *Schemas) importType(t reflect.Type, overrides ...reflect.Type) (*Schema
 		return nil, err
 	}
 
+	if s.importOptions.PluralFunc != nil {
+		schema.PluralName = s.importOptions.PluralFunc(schema.ID)
+	}
+
 	for _, override := range overrides {
 		if err := s.readFields(schema, override); err != nil {
 			return nil, err
@@ -189,8 +279,8 @@ func (s *Schemas) importType(t reflect.Type, overrides ...reflect.Type) (*Schema
 	return s.Schema(schema.ID), err
 }
 
-func jsonName(f reflect.StructField) string {
-	return strings.SplitN(f.Tag.Get("json"), ",", 2)[0]
+func (s *Schemas) parseTag(f reflect.StructField) convert.Tag {
+	return convert.ParseTag(f, s.importOptions.TagNames...)
 }
 
 func k8sType(field reflect.StructField) bool {
@@ -204,6 +294,13 @@ func k8sObject(field reflect.StructField) bool {
 }
 
 func (s *Schemas) readFields(schema *Schema, t reflect.Type) error {
+	return s.readStructFields(schema, t, map[string]reflect.Type{})
+}
+
+// readStructFields reads the fields of t into schema. seen tracks the struct
+// each field name was read from so collisions between embedded structs can be
+// reported.
+func (s *Schemas) readStructFields(schema *Schema, t reflect.Type, seen map[string]reflect.Type) error {
 	hasType := false
 	hasMeta := false
 
@@ -215,10 +312,24 @@ func (s *Schemas) readFields(schema *Schema, t reflect.Type) error {
 			continue
 		}
 
-		jsonName := jsonName(field)
-		if jsonName == "-" {
+		tag := s.parseTag(field)
+		if tag.Skip {
 			continue
 		}
+		jsonName := tag.Name
+
+		if tag.Inline {
+			t := field.Type
+			if t.Kind() == reflect.Ptr {
+				t = t.Elem()
+			}
+			if t.Kind() == reflect.Struct {
+				if err := s.readStructFields(schema, t, seen); err != nil {
+					return err
+				}
+				continue
+			}
+		}
 
 		if field.Anonymous && jsonName == "" && k8sType(field) {
 			hasType = true
@@ -233,20 +344,25 @@ func (s *Schemas) readFields(schema *Schema, t reflect.Type) error {
 			if t.Kind() == reflect.Ptr {
 				t = t.Elem()
 			}
-			if t.Kind() == reflect.Struct {
-				if err := s.readFields(schema, t); err != nil {
+			if t.Kind() != reflect.Struct {
+				continue
+			}
+			// TypeMeta is always squashed so kubernetes types are detected
+			if k8sType(field) || s.importOptions.Embedded == EmbedSquash {
+				if err := s.readStructFields(schema, t, seen); err != nil {
 					return err
 				}
+				continue
 			}
-			continue
+			if s.importOptions.Embedded == EmbedSkip {
+				continue
+			}
+			// EmbedSubObject reads the struct as a regular field below
 		}
 
 		fieldName := jsonName
 		if fieldName == "" {
-			fieldName = convert.LowerTitle(field.Name)
-			if strings.HasSuffix(fieldName, "ID") {
-				fieldName = strings.TrimSuffix(fieldName, "ID") + "Id"
-			}
+			fieldName = s.fieldName(field.Name)
 		}
 
 		if skippedNames[fieldName] {
@@ -257,9 +373,11 @@ func (s *Schemas) readFields(schema *Schema, t reflect.Type) error {
 		logrus.Tracef("Inspecting field %s.%s for %v", schema.ID, fieldName, field)
 
 		schemaField := Field{
-			CodeName: field.Name,
-			Create:   true,
-			Update:   true,
+			CodeName:      field.Name,
+			Create:        true,
+			Update:        true,
+			PatchStrategy: field.Tag.Get("patchStrategy"),
+			PatchMergeKey: field.Tag.Get("patchMergeKey"),
 		}
 
 		fieldType := field.Type
@@ -280,6 +398,10 @@ func (s *Schemas) readFields(schema *Schema, t reflect.Type) error {
 			schemaField.Nullable = false
 		}
 
+		if s.importOptions.Descriptions && t.PkgPath() != "" {
+			schemaField.Description = loadPackageDocs(t.PkgPath(), s.importOptions.SourceDirs).fields[t.Name()+"."+field.Name]
+		}
+
 		if err := applyTag(&field, &schemaField); err != nil {
 			return err
 		}
@@ -311,13 +433,22 @@ func (s *Schemas) readFields(schema *Schema, t reflect.Type) error {
 			}
 		}
 
-		if s.fieldMappers != nil {
-			if err := s.processFieldsMappers(t, fieldName, schema, field); err != nil {
-				return err
+		if err := s.processFieldsMappers(t, fieldName, schema, field); err != nil {
+			return err
+		}
+
+		if prev, ok := seen[fieldName]; ok {
+			if s.importOptions.ErrorOnFieldCollision {
+				return fmt.Errorf("field %s.%s of %v collides with field of %v", schema.ID, fieldName, t, prev)
 			}
+			logrus.Debugf("Field %s.%s of %v replaces field of %v", schema.ID, fieldName, t, prev)
 		}
+		seen[fieldName] = t
 
 		logrus.Tracef("Setting field %s.%s: %#v", schema.ID, fieldName, schemaField)
+		if _, ok := schema.ResourceFields[fieldName]; !ok {
+			schema.FieldOrder = append(schema.FieldOrder, fieldName)
+		}
 		schema.ResourceFields[fieldName] = schemaField
 	}
 
@@ -325,8 +456,8 @@ func (s *Schemas) readFields(schema *Schema, t reflect.Type) error {
 		delete(schema.ResourceFields, "kind")
 		delete(schema.ResourceFields, "apiVersion")
 		delete(schema.ResourceFields, "metadata")
-		schema.CollectionMethods = []string{"GET", "POST"}
-		schema.ResourceMethods = []string{"GET", "PUT", "DELETE"}
+		schema.CollectionMethods = []string{MethodGet, MethodPost}
+		schema.ResourceMethods = []string{MethodGet, MethodPut, MethodDelete}
 	}
 
 	return nil
@@ -350,12 +481,17 @@ func (s *Schemas) processFieldsMappers(t reflect.Type, fieldName string, schema
 			}
 		}
 
-		factory, ok := s.fieldMappers[name]
-		if !ok {
+		if _, ok := s.fieldMapper(name); !ok {
 			return fmt.Errorf("failed to find field mapper [%s] for type [%v]", name, t)
 		}
 
-		s.AddMapper(schema.ID, factory(fieldName, opts...))
+		if err := s.addFieldMapper(schema.ID, mapperRef{
+			Name:  name,
+			Field: fieldName,
+			Args:  opts,
+		}); err != nil {
+			return err
+		}
 	}
 
 	return nil
@@ -363,7 +499,7 @@ func (s *Schemas) processFieldsMappers(t reflect.Type, fieldName string, schema
 
 func applyTag(structField *reflect.StructField, field *Field) error {
 	t := structField.Tag.Get("wrangler")
-	for _, part := range strings.Split(t, ",") {
+	for _, part := range splitTag(t) {
 		if part == "" {
 			continue
 		}
@@ -388,6 +524,11 @@ func applyTag(structField *reflect.StructField, field *Field) error {
 			field.Create = false
 		case "writeOnly":
 			field.WriteOnly = true
+		case "writeOnce":
+			field.WriteOnce = true
+		case "readOnly":
+			field.Create = false
+			field.Update = false
 		case "required":
 			field.Required = true
 		case "update":
@@ -445,6 +586,32 @@ func split(input string) []string {
 	return result
 }
 
+// splitTag splits a tag on commas that are not inside brackets or braces, so
+// types like "string{maxLength=63,pattern=^[a-z]{1,3}$}" are kept whole.
+func splitTag(tag string) []string {
+	var (
+		parts []string
+		depth int
+		start int
+	)
+	for i := 0; i < len(tag); i++ {
+		switch tag[i] {
+		case '[', '{':
+			depth++
+		case ']', '}':
+			if depth > 0 {
+				depth--
+			}
+		case ',':
+			if depth == 0 {
+				parts = append(parts, tag[start:i])
+				start = i + 1
+			}
+		}
+	}
+	return append(parts, tag[start:])
+}
+
 func getKeyValue(input string) (string, string) {
 	var (
 		key, value string
@@ -502,7 +669,7 @@ func (s *Schemas) determineSchemaType(t reflect.Type) (string, error) {
 			return "intOrString", nil
 		}
 		if t.Name() == "Quantity" {
-			return "string", nil
+			return "quantity", nil
 		}
 		schema, err := s.importType(t)
 		if err != nil {
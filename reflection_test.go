@@ -0,0 +1,69 @@
+package schemas
+
+import (
+	"testing"
+
+	"github.com/acorn-io/schemer/data"
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+func TestEmbeds(t *testing.T) {
+	s := EmptySchemas()
+	s.AddMapper("meta", prefixMapper{field: "name", prefix: "meta-"})
+	s.MustAddSchema(Schema{
+		ID: "meta",
+		ResourceFields: map[string]Field{
+			"name":   {Type: "string"},
+			"labels": {Type: "map[string]"},
+		},
+	})
+	s.MustAddSchema(Schema{
+		ID:     "app",
+		Embeds: []string{"meta"},
+		ResourceFields: map[string]Field{
+			"labels": {Type: "array[string]"},
+			"image":  {Type: "string"},
+		},
+	})
+
+	schema := s.Schema("app")
+	for name, fieldType := range map[string]string{
+		"name":   "string",
+		"labels": "array[string]",
+		"image":  "string",
+	} {
+		if field, ok := schema.ResourceFields[name]; !ok || field.Type != fieldType {
+			t.Errorf("expected field %s of type %s, got %+v", name, fieldType, field)
+		}
+	}
+
+	obj := data.Object{"name": "test"}
+	schema.Mapper.FromInternal(obj)
+	if obj["name"] != "meta-test" {
+		t.Fatalf("expected the mapper of the embedded schema to run, got %v", obj["name"])
+	}
+}
+
+func TestEmbedsMissingSchema(t *testing.T) {
+	s := EmptySchemas()
+	if err := s.AddSchema(Schema{ID: "app", Embeds: []string{"missing"}}); err == nil {
+		t.Fatal("expected error for a missing embedded schema")
+	}
+}
+
+func TestImportQuantity(t *testing.T) {
+	type resources struct {
+		CPU    resource.Quantity  `json:"cpu"`
+		Memory *resource.Quantity `json:"memory"`
+	}
+
+	schema, err := EmptySchemas().Import(resources{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, name := range []string{"cpu", "memory"} {
+		if field := schema.ResourceFields[name]; field.Type != "quantity" {
+			t.Errorf("expected %s to be a quantity, got %q", name, field.Type)
+		}
+	}
+}
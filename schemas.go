@@ -3,12 +3,15 @@ package schemas
 import (
 	"errors"
 	"fmt"
+	"maps"
 	"reflect"
+	"slices"
 	"strings"
 	"sync"
 
 	"github.com/acorn-io/schemer/data/convert"
 	"github.com/acorn-io/schemer/name"
+	"github.com/sirupsen/logrus"
 )
 
 type SchemasInitFunc func(*Schemas) *Schemas
@@ -18,18 +21,30 @@ type MapperFactory func() Mapper
 type FieldMapperFactory func(fieldName string, args ...string) Mapper
 
 type Schemas struct {
-	sync.Mutex
+	sync.RWMutex
+	importLock        sync.Mutex
+	importOptions     ImportOptions
 	processingTypes   map[reflect.Type]*Schema
 	typeNames         map[reflect.Type]string
 	schemasByID       map[string]*Schema
-	mappers           map[string][]Mapper
+	mappers           map[string][]phasedMapper
 	embedded          map[string]*Schema
 	fieldMappers      map[string]FieldMapperFactory
 	DefaultMapper     MapperFactory
 	DefaultPostMapper MapperFactory
+	ReferenceResolver ReferenceResolver
 	schemas           []*Schema
+	loaders           []SchemaLoader
+	loading           map[string]bool
+	onAdd             []SchemaHook
+	onRemove          []SchemaHook
+	methodFilters     []MethodFilter
+	subscribers       []*subscriber
 }
 
+// SchemaHook is called after a schema is added to or removed from a registry.
+type SchemaHook func(schema *Schema)
+
 func EmptySchemas() *Schemas {
 	s, _ := NewSchemas()
 	return s
@@ -40,13 +55,8 @@ func NewSchemas(schemas ...*Schemas) (*Schemas, error) {
 		errs []error
 	)
 
-	s := &Schemas{
-		processingTypes: map[reflect.Type]*Schema{},
-		typeNames:       map[reflect.Type]string{},
-		schemasByID:     map[string]*Schema{},
-		mappers:         map[string][]Mapper{},
-		embedded:        map[string]*Schema{},
-	}
+	s := &Schemas{}
+	s.initMaps()
 
 	for _, schemas := range schemas {
 		if _, err := s.AddSchemas(schemas); err != nil {
@@ -57,6 +67,24 @@ func NewSchemas(schemas ...*Schemas) (*Schemas, error) {
 	return s, errors.Join(errs...)
 }
 
+func (s *Schemas) initMaps() {
+	if s.processingTypes == nil {
+		s.processingTypes = map[reflect.Type]*Schema{}
+	}
+	if s.typeNames == nil {
+		s.typeNames = map[reflect.Type]string{}
+	}
+	if s.schemasByID == nil {
+		s.schemasByID = map[string]*Schema{}
+	}
+	if s.mappers == nil {
+		s.mappers = map[string][]phasedMapper{}
+	}
+	if s.embedded == nil {
+		s.embedded = map[string]*Schema{}
+	}
+}
+
 func (s *Schemas) Init(initFunc SchemasInitFunc) *Schemas {
 	return initFunc(s)
 }
@@ -81,12 +109,31 @@ func (s *Schemas) AddSchemas(schema *Schemas) (*Schemas, error) {
 
 func (s *Schemas) RemoveSchema(schema Schema) *Schemas {
 	s.Lock()
-	defer s.Unlock()
-	return s.doRemoveSchema(schema)
+	removed := s.schemasByID[schema.ID]
+	s.doRemoveSchema(schema)
+	if removed != nil {
+		s.publish(Event{Type: SchemaRemoved, SchemaID: removed.ID, Schema: removed})
+	}
+	hooks := slices.Clone(s.onRemove)
+	s.Unlock()
+
+	if removed != nil {
+		for _, hook := range hooks {
+			hook(removed)
+		}
+	}
+	return s
 }
 
 func (s *Schemas) doRemoveSchema(schema Schema) *Schemas {
+	removed, ok := s.schemasByID[schema.ID]
+	if !ok {
+		return s
+	}
 	delete(s.schemasByID, schema.ID)
+	s.schemas = slices.DeleteFunc(s.schemas, func(check *Schema) bool {
+		return check == removed
+	})
 	return s
 }
 
@@ -99,22 +146,55 @@ func (s *Schemas) MustAddSchema(schema Schema) *Schemas {
 }
 
 func (s *Schemas) AddSchema(schema Schema) error {
+	// mappers may look up other schemas in ModifySchema so defaults are
+	// assigned before taking the lock
+	if err := s.setupDefaults(&schema); err != nil {
+		return err
+	}
+
+	s.Lock()
+	if err := s.doAddSchema(schema); err != nil {
+		s.Unlock()
+		return err
+	}
+	added := s.schemasByID[schema.ID]
+	hooks := slices.Clone(s.onAdd)
+	s.Unlock()
+
+	for _, hook := range hooks {
+		hook(added)
+	}
+	return nil
+}
+
+// OnAdd registers a hook that is called every time a schema is added to or
+// replaced in the registry. Hooks are called without holding the registry
+// lock, so they may look up other schemas.
+func (s *Schemas) OnAdd(hook SchemaHook) *Schemas {
 	s.Lock()
 	defer s.Unlock()
-	return s.doAddSchema(schema)
+	s.onAdd = append(s.onAdd, hook)
+	return s
 }
 
-func (s *Schemas) doAddSchema(schema Schema) error {
-	if err := s.setupDefaults(&schema); err != nil {
-		return err
-	}
+// OnRemove registers a hook that is called every time a schema is removed
+// from the registry.
+func (s *Schemas) OnRemove(hook SchemaHook) *Schemas {
+	s.Lock()
+	defer s.Unlock()
+	s.onRemove = append(s.onRemove, hook)
+	return s
+}
 
+func (s *Schemas) doAddSchema(schema Schema) error {
 	existing, ok := s.schemasByID[schema.ID]
 	if ok {
 		*existing = schema
+		s.publish(Event{Type: SchemaReplaced, SchemaID: schema.ID, Schema: existing})
 	} else {
 		s.schemasByID[schema.ID] = &schema
 		s.schemas = append(s.schemas, &schema)
+		s.publish(Event{Type: SchemaAdded, SchemaID: schema.ID, Schema: &schema})
 	}
 
 	return nil
@@ -141,6 +221,8 @@ func (s *Schemas) setupDefaults(schema *Schema) (err error) {
 }
 
 func (s *Schemas) AddFieldMapper(name string, factory FieldMapperFactory) *Schemas {
+	s.Lock()
+	defer s.Unlock()
 	if s.fieldMappers == nil {
 		s.fieldMappers = map[string]FieldMapperFactory{}
 	}
@@ -148,35 +230,182 @@ func (s *Schemas) AddFieldMapper(name string, factory FieldMapperFactory) *Schem
 	return s
 }
 
+func (s *Schemas) fieldMapper(name string) (FieldMapperFactory, bool) {
+	s.RLock()
+	defer s.RUnlock()
+	factory, ok := s.fieldMappers[name]
+	return factory, ok
+}
+
 func (s *Schemas) AddMapper(schemaID string, mapper Mapper) *Schemas {
+	return s.AddMapperWithPhase(schemaID, PhaseMain, mapper)
+}
+
+// AddMapperWithPhase registers a mapper for the schema in the given phase.
+// Mappers run in phase order and in registration order within a phase.
+func (s *Schemas) AddMapperWithPhase(schemaID string, phase MapperPhase, mapper Mapper) *Schemas {
+	return s.addMapper(schemaID, phasedMapper{
+		phase:  phase,
+		mapper: mapper,
+	})
+}
+
+// addFieldMapper creates and registers a mapper from a named field mapper
+// factory. The name and arguments are remembered so the mapper can be
+// recreated when the registry is serialized.
+func (s *Schemas) addFieldMapper(schemaID string, ref mapperRef) error {
+	factory, ok := s.fieldMapper(ref.Name)
+	if !ok {
+		return fmt.Errorf("failed to find field mapper [%s] for schema [%s]", ref.Name, schemaID)
+	}
+	s.addMapper(schemaID, phasedMapper{
+		phase:  PhaseMain,
+		mapper: factory(ref.Field, ref.Args...),
+		ref:    &ref,
+	})
+	return nil
+}
+
+func (s *Schemas) addMapper(schemaID string, mapper phasedMapper) *Schemas {
+	s.Lock()
+	defer s.Unlock()
 	s.mappers[schemaID] = append(s.mappers[schemaID], mapper)
+	s.publish(Event{Type: MappersModified, SchemaID: schemaID})
 	return s
 }
 
 func (s *Schemas) Schemas() []*Schema {
-	return s.schemas
+	s.RLock()
+	defer s.RUnlock()
+	return slices.Clone(s.schemas)
 }
 
 func (s *Schemas) SchemasByID() map[string]*Schema {
-	return s.schemasByID
+	s.RLock()
+	defer s.RUnlock()
+	return maps.Clone(s.schemasByID)
+}
+
+// Snapshot returns a point-in-time copy of the registry. Schemas added to or
+// removed from s afterwards are not visible in the snapshot, so read-heavy
+// callers can do lookups against it without contending on s. The snapshot is
+// a Clone, so mapping with it never touches the state of s.
+func (s *Schemas) Snapshot() *Schemas {
+	return s.Clone()
 }
 
 func (s *Schemas) mapper(schemaID string) []Mapper {
-	return s.mappers[schemaID]
+	s.RLock()
+	defer s.RUnlock()
+
+	mappers := slices.Clone(s.mappers[schemaID])
+	slices.SortStableFunc(mappers, func(a, b phasedMapper) int {
+		return int(a.phase) - int(b.phase)
+	})
+
+	result := make([]Mapper, 0, len(mappers))
+	for _, m := range mappers {
+		result = append(result, m.mapper)
+	}
+	return result
 }
 
 func (s *Schemas) Schema(name string) *Schema {
-	return s.doSchema(name, true)
+	schema, err := s.LoadSchema(name)
+	if err != nil {
+		logrus.Errorf("Failed to load schema %s: %v", name, err)
+	}
+	return schema
+}
+
+// Clone returns a deep copy of the registry. Every schema is copied and the
+// type mappers are rebuilt to point at the copies, so schemas in the clone can
+// be customized without affecting s. Mappers registered with AddMapper are
+// shared between s and the clone, unless they implement CloneableMapper.
+func (s *Schemas) Clone() *Schemas {
+	s.RLock()
+	defer s.RUnlock()
+
+	result := &Schemas{
+		processingTypes:   map[reflect.Type]*Schema{},
+		typeNames:         maps.Clone(s.typeNames),
+		schemasByID:       make(map[string]*Schema, len(s.schemasByID)),
+		mappers:           map[string][]phasedMapper{},
+		embedded:          map[string]*Schema{},
+		fieldMappers:      maps.Clone(s.fieldMappers),
+		DefaultMapper:     s.DefaultMapper,
+		DefaultPostMapper: s.DefaultPostMapper,
+		ReferenceResolver: s.ReferenceResolver,
+		schemas:           make([]*Schema, 0, len(s.schemas)),
+		loaders:           slices.Clone(s.loaders),
+		methodFilters:     slices.Clone(s.methodFilters),
+	}
+
+	cloner := mapperCloner{}
+	for id, mappers := range s.mappers {
+		mappers = slices.Clone(mappers)
+		for i := range mappers {
+			mappers[i].mapper = cloner.clone(mappers[i].mapper)
+		}
+		result.mappers[id] = mappers
+	}
+
+	copies := map[*Schema]*Schema{}
+	cloneSchema := func(schema *Schema) *Schema {
+		if schemaCopy, ok := copies[schema]; ok {
+			return schemaCopy
+		}
+		schemaCopy := schema.DeepCopy()
+		copies[schema] = schemaCopy
+		return schemaCopy
+	}
+
+	for _, schema := range s.schemas {
+		result.schemas = append(result.schemas, cloneSchema(schema))
+	}
+	for id, schema := range s.schemasByID {
+		result.schemasByID[id] = cloneSchema(schema)
+	}
+	for id, schema := range s.embedded {
+		result.embedded[id] = cloneSchema(schema)
+	}
+
+	for _, schema := range copies {
+		if t, ok := schema.Mapper.(*typeMapper); ok {
+			schema.Mapper = t.deepCopy(result, copies, cloner.clone)
+		}
+	}
+
+	return result
+}
+
+// mapperCloner copies the CloneableMappers of a registry. A mapper can be
+// registered and used by a type mapper at the same time, both get the same
+// copy.
+type mapperCloner map[Mapper]Mapper
+
+func (c mapperCloner) clone(mapper Mapper) Mapper {
+	cloneable, ok := mapper.(CloneableMapper)
+	if !ok {
+		return mapper
+	}
+	if !reflect.TypeOf(mapper).Comparable() {
+		return cloneable.CloneMapper()
+	}
+	if mapperCopy, ok := c[mapper]; ok {
+		return mapperCopy
+	}
+	mapperCopy := cloneable.CloneMapper()
+	c[mapper] = mapperCopy
+	return mapperCopy
 }
 
 func (s *Schemas) doSchema(name string, lock bool) *Schema {
 	if lock {
-		s.Lock()
+		s.RLock()
+		defer s.RUnlock()
 	}
 	schema, ok := s.schemasByID[name]
-	if lock {
-		s.Unlock()
-	}
 	if ok {
 		return schema
 	}
@@ -0,0 +1,166 @@
+package schemas
+
+import (
+	"fmt"
+	"reflect"
+	"slices"
+	"sync"
+	"testing"
+
+	"github.com/acorn-io/schemer/data"
+)
+
+func TestCloneCopiesDefaults(t *testing.T) {
+	s := EmptySchemas()
+	s.MustAddSchema(Schema{
+		ID: "app",
+		ResourceFields: map[string]Field{
+			"labels": {Type: "map[string]", Default: map[string]interface{}{"app": "web"}},
+			"args":   {Type: "array[string]", Default: []interface{}{"run"}},
+		},
+	})
+
+	clone := s.Clone()
+	fields := clone.Schema("app").ResourceFields
+	fields["labels"].Default.(map[string]interface{})["app"] = "changed"
+	fields["args"].Default.([]interface{})[0] = "changed"
+
+	fields = s.Schema("app").ResourceFields
+	if !reflect.DeepEqual(fields["labels"].Default, map[string]interface{}{"app": "web"}) {
+		t.Fatalf("default of labels was changed by the clone: %v", fields["labels"].Default)
+	}
+	if !reflect.DeepEqual(fields["args"].Default, []interface{}{"run"}) {
+		t.Fatalf("default of args was changed by the clone: %v", fields["args"].Default)
+	}
+}
+
+// countingMapper keeps state from ModifySchema like mappers.Embed.
+type countingMapper struct {
+	count int
+}
+
+func (*countingMapper) FromInternal(data.Object)               {}
+func (*countingMapper) ToInternal(data.Object) error           { return nil }
+func (m *countingMapper) ModifySchema(*Schema, *Schemas) error { m.count++; return nil }
+
+func (m *countingMapper) CloneMapper() Mapper {
+	return &countingMapper{count: m.count}
+}
+
+func TestCloneCloneableMapper(t *testing.T) {
+	mapper := &countingMapper{}
+	s := EmptySchemas().AddMapperForType(benchApp{}, mapper)
+	if _, err := s.Import(benchApp{}); err != nil {
+		t.Fatal(err)
+	}
+
+	clone := s.Clone()
+	registered := clone.mapper("benchApp")
+	typeMapper := clone.Schema("benchApp").Mapper.(*typeMapper)
+	if len(registered) != 1 || registered[0] == Mapper(mapper) {
+		t.Fatalf("expected the registered mapper to be cloned, got %v", registered)
+	}
+	if !slices.Contains(typeMapper.Mappers, registered[0]) {
+		t.Fatal("expected the type mapper to use the cloned registered mapper")
+	}
+
+	if err := registered[0].ModifySchema(nil, clone); err != nil {
+		t.Fatal(err)
+	}
+	if mapper.count != 1 || registered[0].(*countingMapper).count != 2 {
+		t.Fatalf("expected counts 1 and 2, got %d and %d", mapper.count, registered[0].(*countingMapper).count)
+	}
+}
+
+func TestRemoveSchema(t *testing.T) {
+	var added, removed []string
+	s := EmptySchemas().OnAdd(func(schema *Schema) {
+		added = append(added, schema.ID)
+	})
+	s.MustAddSchema(Schema{ID: "foo"})
+	s.MustAddSchema(Schema{ID: "bar"})
+	if !reflect.DeepEqual(added, []string{"foo", "bar"}) {
+		t.Fatalf("expected hooks for foo and bar, got %v", added)
+	}
+
+	s.OnRemove(func(schema *Schema) {
+		removed = append(removed, schema.ID)
+	})
+	s.RemoveSchema(Schema{ID: "foo"})
+	s.RemoveSchema(Schema{ID: "missing"})
+
+	if !reflect.DeepEqual(removed, []string{"foo"}) {
+		t.Fatalf("expected hooks for foo only, got %v", removed)
+	}
+	if s.Schema("foo") != nil || s.Schema("Foo") != nil {
+		t.Fatal("expected foo to be removed")
+	}
+	if schemas := s.Schemas(); len(schemas) != 1 || schemas[0].ID != "bar" {
+		t.Fatalf("expected only bar, got %v", schemas)
+	}
+	if byID := s.SchemasByID(); len(byID) != 1 || byID["bar"] == nil {
+		t.Fatalf("expected only bar, got %v", byID)
+	}
+
+	// adding it again works like a new schema
+	s.MustAddSchema(Schema{ID: "foo"})
+	if len(s.Schemas()) != 2 || s.Schema("foo") == nil {
+		t.Fatalf("expected foo to be added again, got %v", s.Schemas())
+	}
+}
+
+func TestSnapshot(t *testing.T) {
+	s := EmptySchemas()
+	if _, err := s.Import(benchApp{}); err != nil {
+		t.Fatal(err)
+	}
+
+	snapshot := s.Snapshot()
+	s.MustAddSchema(Schema{ID: "later"})
+
+	if snapshot.Schema("later") != nil {
+		t.Fatal("expected schemas added later to be missing from the snapshot")
+	}
+	schema := snapshot.Schema("benchApp")
+	if schema == nil || schema == s.Schema("benchApp") {
+		t.Fatal("expected a copy of benchApp in the snapshot")
+	}
+	if mapper, ok := schema.Mapper.(*typeMapper); !ok || mapper.schemas != snapshot {
+		t.Fatal("expected the type mapper of the snapshot to use the snapshot")
+	}
+}
+
+func TestConcurrentAccess(t *testing.T) {
+	s := EmptySchemas()
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			if _, err := s.Import(benchApp{}); err != nil {
+				t.Error(err)
+			}
+			s.MustAddSchema(Schema{ID: fmt.Sprintf("schema%d", i)})
+			s.AddMapper("benchApp", benchNoop{})
+			_ = s.Schema("benchApp")
+			_ = s.Schemas()
+			_ = s.SchemasByID()
+			_ = s.Snapshot()
+		}(i)
+	}
+	wg.Wait()
+
+	byID := s.SchemasByID()
+	for i := 0; i < 10; i++ {
+		if byID[fmt.Sprintf("schema%d", i)] == nil {
+			t.Errorf("expected schema%d to be added", i)
+		}
+	}
+	if s.Schema("benchApp") == nil || s.Schema("benchPort") == nil {
+		t.Fatal("expected imported schemas")
+	}
+	if len(s.mapper("benchApp")) != 10 {
+		t.Fatalf("expected 10 mappers, got %d", len(s.mapper("benchApp")))
+	}
+}
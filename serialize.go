@@ -0,0 +1,135 @@
+package schemas
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+// mapperRef identifies a mapper created by a named field mapper factory, see
+// AddFieldMapper.
+type mapperRef struct {
+	Name  string   `json:"name"`
+	Field string   `json:"field"`
+	Args  []string `json:"args,omitempty"`
+}
+
+type serializedSchemas struct {
+	Schemas []serializedSchema `json:"schemas"`
+}
+
+type serializedSchema struct {
+	ID             string            `json:"id"`
+	CodeName       string            `json:"codeName,omitempty"`
+	CodeNamePlural string            `json:"codeNamePlural,omitempty"`
+	PkgName        string            `json:"pkgName,omitempty"`
+	Embeds         []string          `json:"embeds,omitempty"`
+	FieldCodeNames map[string]string `json:"fieldCodeNames,omitempty"`
+	Mappers        []mapperRef       `json:"mappers,omitempty"`
+	Schema         *Schema           `json:"schema"`
+}
+
+// MarshalJSON serializes all schemas in the registry. Schemas are written as
+// they were before any mappers modified them. Only mappers created from named
+// field mappers can be serialized, it fails for schemas with mappers added
+// with AddMapper. The default mappers have to be set again on the registry
+// the schemas are loaded into.
+func (s *Schemas) MarshalJSON() ([]byte, error) {
+	s.RLock()
+	defer s.RUnlock()
+
+	result := serializedSchemas{
+		Schemas: []serializedSchema{},
+	}
+
+	for _, schema := range s.schemas {
+		if s.schemasByID[schema.ID] != schema {
+			continue
+		}
+
+		source := *schema
+		if schema.InternalSchema != nil {
+			source = *schema.InternalSchema
+			source.PluralName = schema.PluralName
+		}
+
+		serialized := serializedSchema{
+			ID:             schema.ID,
+			CodeName:       schema.CodeName,
+			CodeNamePlural: schema.CodeNamePlural,
+			PkgName:        schema.PkgName,
+			Embeds:         schema.Embeds,
+			FieldCodeNames: map[string]string{},
+			Schema:         &source,
+		}
+
+		for name, field := range source.ResourceFields {
+			if field.CodeName != "" {
+				serialized.FieldCodeNames[name] = field.CodeName
+			}
+		}
+
+		for _, mapper := range s.mappers[schema.ID] {
+			if mapper.ref == nil {
+				return nil, fmt.Errorf("mapper %T of schema %s can not be serialized, only field mappers can", mapper.mapper, schema.ID)
+			}
+			serialized.Mappers = append(serialized.Mappers, *mapper.ref)
+		}
+
+		result.Schemas = append(result.Schemas, serialized)
+	}
+
+	return json.Marshal(result)
+}
+
+// UnmarshalJSON adds the serialized schemas to the registry, replacing
+// schemas with the same ID and their mappers. The field mappers referenced by
+// the schemas must already be registered with AddFieldMapper.
+func (s *Schemas) UnmarshalJSON(data []byte) error {
+	var input serializedSchemas
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	if err := dec.Decode(&input); err != nil {
+		return err
+	}
+
+	s.Lock()
+	s.initMaps()
+	for _, serialized := range input.Schemas {
+		delete(s.mappers, serialized.ID)
+	}
+	s.Unlock()
+
+	var errs []error
+	for _, serialized := range input.Schemas {
+		schema := Schema{}
+		if serialized.Schema != nil {
+			schema = *serialized.Schema
+		}
+		schema.ID = serialized.ID
+		schema.CodeName = serialized.CodeName
+		schema.CodeNamePlural = serialized.CodeNamePlural
+		schema.PkgName = serialized.PkgName
+		schema.Embeds = serialized.Embeds
+
+		for name, codeName := range serialized.FieldCodeNames {
+			if field, ok := schema.ResourceFields[name]; ok {
+				field.CodeName = codeName
+				schema.ResourceFields[name] = field
+			}
+		}
+
+		for _, ref := range serialized.Mappers {
+			if err := s.addFieldMapper(schema.ID, ref); err != nil {
+				errs = append(errs, err)
+			}
+		}
+
+		if err := s.AddSchema(schema); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	return errors.Join(errs...)
+}
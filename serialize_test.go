@@ -0,0 +1,91 @@
+package schemas
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/acorn-io/schemer/data"
+)
+
+// prefixMapper adds a prefix to a string field in the external form.
+type prefixMapper struct {
+	field, prefix string
+}
+
+func (p prefixMapper) FromInternal(obj data.Object) {
+	if v, ok := obj[p.field].(string); ok {
+		obj[p.field] = p.prefix + v
+	}
+}
+
+func (p prefixMapper) ToInternal(obj data.Object) error {
+	if v, ok := obj[p.field].(string); ok {
+		obj[p.field] = strings.TrimPrefix(v, p.prefix)
+	}
+	return nil
+}
+
+func (p prefixMapper) ModifySchema(*Schema, *Schemas) error {
+	return nil
+}
+
+type serializedApp struct {
+	Name  string `json:"name" mapper:"prefix=app-"`
+	Image string `json:"image"`
+}
+
+func newSerializeSchemas() *Schemas {
+	return EmptySchemas().AddFieldMapper("prefix", func(field string, args ...string) Mapper {
+		return prefixMapper{field: field, prefix: strings.Join(args, "")}
+	})
+}
+
+func TestSchemasJSONRoundTrip(t *testing.T) {
+	s := newSerializeSchemas()
+	if _, err := s.Import(serializedApp{}); err != nil {
+		t.Fatal(err)
+	}
+	content, err := json.Marshal(s)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	loaded := newSerializeSchemas()
+	if err := json.Unmarshal(content, loaded); err != nil {
+		t.Fatal(err)
+	}
+	// loading again replaces the schema and its mappers
+	if err := json.Unmarshal(content, loaded); err != nil {
+		t.Fatal(err)
+	}
+
+	schema := loaded.Schema("serializedApp")
+	if schema == nil {
+		t.Fatal("schema was not loaded")
+	}
+	if _, ok := schema.ResourceFields["image"]; !ok {
+		t.Errorf("expected field image, got %v", schema.ResourceFields)
+	}
+	if got := len(loaded.mapper("serializedApp")); got != 1 {
+		t.Errorf("expected 1 mapper, got %d", got)
+	}
+
+	obj := data.Object{"name": "web"}
+	schema.Mapper.FromInternal(obj)
+	if obj["name"] != "app-web" {
+		t.Errorf("expected the field mapper to be applied once, got %v", obj["name"])
+	}
+}
+
+func TestSchemasMarshalJSONUnserializableMapper(t *testing.T) {
+	s := newSerializeSchemas()
+	if _, err := s.Import(serializedApp{}); err != nil {
+		t.Fatal(err)
+	}
+	s.AddMapper("serializedApp", prefixMapper{field: "image"})
+
+	if _, err := json.Marshal(s); err == nil || !strings.Contains(err.Error(), "mapper schemas.prefixMapper of schema serializedApp can not be serialized") {
+		t.Errorf("expected an error for the unserializable mapper, got %v", err)
+	}
+}
@@ -0,0 +1,64 @@
+package schemas
+
+import (
+	"context"
+
+	"github.com/acorn-io/schemer/data"
+)
+
+// Store persists the objects of a schema. Objects passed to and returned by
+// a Store are in the external form of the schema, implementations run the
+// schema's Mapper to convert them to and from the stored form.
+type Store interface {
+	List(ctx context.Context, schema *Schema, opts ListOptions) (*ObjectList, error)
+	Get(ctx context.Context, schema *Schema, namespace, name string) (data.Object, error)
+	Create(ctx context.Context, schema *Schema, obj data.Object) (data.Object, error)
+	Update(ctx context.Context, schema *Schema, obj data.Object) (data.Object, error)
+	Delete(ctx context.Context, schema *Schema, namespace, name string) error
+	// Watch returns the changes to the objects of schema. The channel is
+	// closed once ctx is done or the store stops the watch.
+	Watch(ctx context.Context, schema *Schema, opts WatchOptions) (<-chan WatchEvent, error)
+}
+
+type ListOptions struct {
+	// Namespace restricts the list to a namespace, all namespaces are
+	// listed if it's empty.
+	Namespace     string
+	LabelSelector string
+	// Limit is the maximum number of objects to return, Continue is the
+	// token of the ObjectList returned by the previous call.
+	Limit    int64
+	Continue string
+}
+
+type ObjectList struct {
+	Objects data.List
+	// Continue is set if there are more objects to list.
+	Continue string
+	// Revision is the resource version of the list, watches started with it
+	// return the changes made after the list.
+	Revision string
+}
+
+type WatchOptions struct {
+	Namespace     string
+	LabelSelector string
+	// Revision is the resource version to start the watch from.
+	Revision string
+}
+
+type WatchEventType string
+
+const (
+	WatchAdded    WatchEventType = "added"
+	WatchModified WatchEventType = "modified"
+	WatchDeleted  WatchEventType = "deleted"
+	WatchError    WatchEventType = "error"
+)
+
+// WatchEvent is a change to an object. Object is the error status returned
+// by the store for WatchError events.
+type WatchEvent struct {
+	Type   WatchEventType
+	Object data.Object
+}
@@ -0,0 +1,63 @@
+package schemas
+
+import (
+	"context"
+	"errors"
+	"io"
+
+	"github.com/acorn-io/schemer/data"
+)
+
+// MapList applies the mappers of schema to each object returned by next and
+// passes the result to emit, so lists can be mapped without holding them in
+// memory. next returns io.EOF when there are no more objects.
+func (s *Schemas) MapList(ctx context.Context, schema *Schema, next func() (data.Object, error), emit func(data.Object) error) error {
+	for {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		obj, err := next()
+		if errors.Is(err, io.EOF) {
+			return nil
+		} else if err != nil {
+			return err
+		}
+
+		if schema.Mapper != nil {
+			FromInternalContext(ctx, schema.Mapper, obj)
+		}
+		if err := emit(obj); err != nil {
+			return err
+		}
+	}
+}
+
+// MapListChan applies the mappers of schema to each object received from in
+// and sends the result to the returned channel. The returned channel is
+// closed once in is closed or ctx is done.
+func (s *Schemas) MapListChan(ctx context.Context, schema *Schema, in <-chan data.Object) <-chan data.Object {
+	out := make(chan data.Object)
+	go func() {
+		defer close(out)
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case obj, ok := <-in:
+				if !ok {
+					return
+				}
+				if schema.Mapper != nil {
+					FromInternalContext(ctx, schema.Mapper, obj)
+				}
+				select {
+				case out <- obj:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}
+	}()
+	return out
+}
@@ -0,0 +1,109 @@
+package schemas
+
+import (
+	"context"
+	"errors"
+	"io"
+	"reflect"
+	"testing"
+
+	"github.com/acorn-io/schemer/data"
+)
+
+func streamSchema() (*Schemas, *Schema) {
+	s := EmptySchemas()
+	s.AddMapper("item", prefixMapper{field: "name", prefix: "item-"})
+	s.MustAddSchema(Schema{ID: "item", ResourceFields: map[string]Field{"name": {Type: "string"}}})
+	return s, s.Schema("item")
+}
+
+func streamItems(names ...string) []data.Object {
+	result := make([]data.Object, 0, len(names))
+	for _, name := range names {
+		result = append(result, data.Object{"name": name})
+	}
+	return result
+}
+
+func TestMapList(t *testing.T) {
+	s, schema := streamSchema()
+
+	items := streamItems("a", "b", "c")
+	next := func() (data.Object, error) {
+		if len(items) == 0 {
+			return nil, io.EOF
+		}
+		item := items[0]
+		items = items[1:]
+		return item, nil
+	}
+
+	var names []string
+	err := s.MapList(context.Background(), schema, next, func(obj data.Object) error {
+		names = append(names, obj.String("name"))
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if expected := []string{"item-a", "item-b", "item-c"}; !reflect.DeepEqual(names, expected) {
+		t.Fatalf("expected %v, got %v", expected, names)
+	}
+}
+
+func TestMapListErrors(t *testing.T) {
+	s, schema := streamSchema()
+	failed := errors.New("failed")
+
+	err := s.MapList(context.Background(), schema, func() (data.Object, error) {
+		return nil, failed
+	}, func(data.Object) error { return nil })
+	if !errors.Is(err, failed) {
+		t.Fatalf("expected the error of next, got %v", err)
+	}
+
+	err = s.MapList(context.Background(), schema, func() (data.Object, error) {
+		return data.Object{}, nil
+	}, func(data.Object) error { return failed })
+	if !errors.Is(err, failed) {
+		t.Fatalf("expected the error of emit, got %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	err = s.MapList(ctx, schema, func() (data.Object, error) {
+		t.Fatal("next called with a canceled context")
+		return nil, nil
+	}, func(data.Object) error { return nil })
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected context.Canceled, got %v", err)
+	}
+}
+
+func TestMapListChan(t *testing.T) {
+	s, schema := streamSchema()
+
+	in := make(chan data.Object)
+	go func() {
+		defer close(in)
+		for _, item := range streamItems("a", "b") {
+			in <- item
+		}
+	}()
+
+	var names []string
+	for obj := range s.MapListChan(context.Background(), schema, in) {
+		names = append(names, obj.String("name"))
+	}
+	if expected := []string{"item-a", "item-b"}; !reflect.DeepEqual(names, expected) {
+		t.Fatalf("expected %v, got %v", expected, names)
+	}
+
+	// the output is closed when the context is done, even if in is not
+	ctx, cancel := context.WithCancel(context.Background())
+	out := s.MapListChan(ctx, schema, make(chan data.Object))
+	cancel()
+	if _, ok := <-out; ok {
+		t.Fatal("expected the output to be closed")
+	}
+}
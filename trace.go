@@ -0,0 +1,194 @@
+package schemas
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sort"
+	"sync"
+
+	"github.com/acorn-io/schemer/data"
+	"github.com/acorn-io/schemer/internal/tracing"
+)
+
+const (
+	defaultTraceEntries = 100
+	defaultTraceChanges = 20
+)
+
+// Trace records the changes every mapper makes to an object. Attach it to a
+// request with WithTrace, tracing is disabled unless a Trace is present.
+type Trace struct {
+	// MaxEntries caps the number of recorded mapper invocations, it defaults
+	// to 100.
+	MaxEntries int
+	// MaxChanges caps the number of changes recorded per invocation, it
+	// defaults to 20.
+	MaxChanges int
+
+	lock    sync.Mutex
+	entries []TraceEntry
+	dropped int
+}
+
+type TraceEntry struct {
+	Mapper    string
+	Direction string
+	// Changes lists the modified paths prefixed with "+" for added, "-" for
+	// removed and "~" for changed values.
+	Changes []string
+	// Truncated is set if more than MaxChanges changes were made.
+	Truncated bool
+}
+
+type traceKey struct{}
+
+func WithTrace(ctx context.Context, trace *Trace) context.Context {
+	return context.WithValue(ctx, traceKey{}, trace)
+}
+
+func TraceFrom(ctx context.Context) *Trace {
+	trace, _ := ctx.Value(traceKey{}).(*Trace)
+	return trace
+}
+
+// Entries returns the recorded invocations and the number of invocations that
+// were not recorded because MaxEntries was reached.
+func (t *Trace) Entries() ([]TraceEntry, int) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	return append([]TraceEntry(nil), t.entries...), t.dropped
+}
+
+func (t *Trace) record(mapper Mapper, direction string, before, after data.Object) {
+	maxEntries, maxChanges := t.MaxEntries, t.MaxChanges
+	if maxEntries <= 0 {
+		maxEntries = defaultTraceEntries
+	}
+	if maxChanges <= 0 {
+		maxChanges = defaultTraceChanges
+	}
+
+	var changes []string
+	diffValues("", before, after, &changes)
+	if len(changes) == 0 {
+		return
+	}
+	sort.Strings(changes)
+
+	entry := TraceEntry{
+		Mapper:    fmt.Sprintf("%T", mapper),
+		Direction: direction,
+		Changes:   changes,
+	}
+	if len(changes) > maxChanges {
+		entry.Changes = changes[:maxChanges]
+		entry.Truncated = true
+	}
+
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	if len(t.entries) >= maxEntries {
+		t.dropped++
+		return
+	}
+	t.entries = append(t.entries, entry)
+}
+
+func traceFromInternal(ctx context.Context, mapper Mapper, obj data.Object) {
+	ctx, span := startMapperSpan(ctx, mapper, "FromInternal")
+	defer tracing.End(span, nil)
+
+	trace := TraceFrom(ctx)
+	if trace == nil {
+		FromInternalContext(ctx, mapper, obj)
+		return
+	}
+	before := copyValue(map[string]interface{}(obj))
+	FromInternalContext(ctx, mapper, obj)
+	trace.record(mapper, "FromInternal", convertObject(before), obj)
+}
+
+func traceToInternal(ctx context.Context, mapper Mapper, obj data.Object) (err error) {
+	ctx, span := startMapperSpan(ctx, mapper, "ToInternal")
+	defer func() {
+		tracing.End(span, err)
+	}()
+
+	trace := TraceFrom(ctx)
+	if trace == nil {
+		return ToInternalContext(ctx, mapper, obj)
+	}
+	before := copyValue(map[string]interface{}(obj))
+	err = ToInternalContext(ctx, mapper, obj)
+	trace.record(mapper, "ToInternal", convertObject(before), obj)
+	return err
+}
+
+func convertObject(v interface{}) data.Object {
+	m, _ := v.(map[string]interface{})
+	return m
+}
+
+func copyValue(v interface{}) interface{} {
+	switch t := v.(type) {
+	case map[string]interface{}:
+		if t == nil {
+			return t
+		}
+		result := make(map[string]interface{}, len(t))
+		for k, v := range t {
+			result[k] = copyValue(v)
+		}
+		return result
+	case []interface{}:
+		if t == nil {
+			return t
+		}
+		result := make([]interface{}, len(t))
+		for i, v := range t {
+			result[i] = copyValue(v)
+		}
+		return result
+	}
+	return v
+}
+
+func diffValues(path string, before, after interface{}, changes *[]string) {
+	beforeMap, beforeIsMap := before.(map[string]interface{})
+	if o, ok := before.(data.Object); ok {
+		beforeMap, beforeIsMap = o, true
+	}
+	afterMap, afterIsMap := after.(map[string]interface{})
+	if o, ok := after.(data.Object); ok {
+		afterMap, afterIsMap = o, true
+	}
+
+	if !beforeIsMap || !afterIsMap {
+		if !reflect.DeepEqual(before, after) {
+			*changes = append(*changes, "~"+path)
+		}
+		return
+	}
+
+	for k, v := range beforeMap {
+		childPath := joinPath(path, k)
+		if newV, ok := afterMap[k]; ok {
+			diffValues(childPath, v, newV, changes)
+		} else {
+			*changes = append(*changes, "-"+childPath)
+		}
+	}
+	for k := range afterMap {
+		if _, ok := beforeMap[k]; !ok {
+			*changes = append(*changes, "+"+joinPath(path, k))
+		}
+	}
+}
+
+func joinPath(path, key string) string {
+	if path == "" {
+		return key
+	}
+	return path + "." + key
+}
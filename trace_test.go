@@ -0,0 +1,95 @@
+package schemas
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/acorn-io/schemer/data"
+)
+
+// rewriteMapper adds, removes and changes keys of the nested object spec.
+type rewriteMapper struct{}
+
+func (rewriteMapper) FromInternal(obj data.Object) {
+	spec := obj.Map("spec")
+	spec["added"] = true
+	delete(spec, "removed")
+	spec["changed"] = "new"
+}
+
+func (rewriteMapper) ToInternal(obj data.Object) error {
+	obj["name"] = "internal"
+	return nil
+}
+
+func (rewriteMapper) ModifySchema(*Schema, *Schemas) error {
+	return nil
+}
+
+func traceObject() data.Object {
+	return data.Object{
+		"name": "app",
+		"spec": map[string]interface{}{
+			"removed": 1,
+			"changed": "old",
+		},
+	}
+}
+
+func TestTrace(t *testing.T) {
+	trace := &Trace{}
+	ctx := WithTrace(context.Background(), trace)
+	mappers := Mappers{benchNoop{}, rewriteMapper{}}
+
+	mappers.FromInternalContext(ctx, traceObject())
+	if err := mappers.ToInternalContext(ctx, traceObject()); err != nil {
+		t.Fatal(err)
+	}
+
+	entries, dropped := trace.Entries()
+	expected := []TraceEntry{
+		{
+			Mapper:    "schemas.rewriteMapper",
+			Direction: "FromInternal",
+			Changes:   []string{"+spec.added", "-spec.removed", "~spec.changed"},
+		},
+		{
+			Mapper:    "schemas.rewriteMapper",
+			Direction: "ToInternal",
+			Changes:   []string{"~name"},
+		},
+	}
+	if dropped != 0 || !reflect.DeepEqual(entries, expected) {
+		t.Fatalf("expected %+v, got %+v and %d dropped", expected, entries, dropped)
+	}
+}
+
+func TestTraceLimits(t *testing.T) {
+	trace := &Trace{MaxEntries: 1, MaxChanges: 2}
+	ctx := WithTrace(context.Background(), trace)
+
+	for i := 0; i < 3; i++ {
+		Mappers{rewriteMapper{}}.FromInternalContext(ctx, traceObject())
+	}
+
+	entries, dropped := trace.Entries()
+	if len(entries) != 1 || dropped != 2 {
+		t.Fatalf("expected 1 entry and 2 dropped, got %d and %d", len(entries), dropped)
+	}
+	if !entries[0].Truncated || !reflect.DeepEqual(entries[0].Changes, []string{"+spec.added", "-spec.removed"}) {
+		t.Fatalf("expected truncated changes, got %+v", entries[0])
+	}
+}
+
+func TestTraceDisabled(t *testing.T) {
+	if TraceFrom(context.Background()) != nil {
+		t.Fatal("expected no trace")
+	}
+
+	obj := traceObject()
+	Mappers{rewriteMapper{}}.FromInternalContext(context.Background(), obj)
+	if obj.Map("spec")["added"] != true {
+		t.Fatalf("expected the mapper to run without a trace, got %v", obj)
+	}
+}
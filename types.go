@@ -1,5 +1,13 @@
 package schemas
 
+import (
+	"slices"
+	"sort"
+
+	"github.com/acorn-io/schemer/data"
+	"github.com/acorn-io/schemer/definition"
+)
+
 type Schema struct {
 	ID                string                 `json:"-"`
 	Description       string                 `json:"description,omitempty"`
@@ -13,7 +21,10 @@ type Schema struct {
 	CollectionMethods []string               `json:"collectionMethods,omitempty"`
 	CollectionFields  map[string]Field       `json:"collectionFields,omitempty"`
 	CollectionActions map[string]Action      `json:"collectionActions,omitempty"`
+	Links             map[string]string      `json:"links,omitempty"`
 	Attributes        map[string]interface{} `json:"attributes,omitempty"`
+	Embeds            []string               `json:"-"`
+	FieldOrder        []string               `json:"fieldOrder,omitempty"`
 
 	InternalSchema *Schema `json:"-"`
 	Mapper         Mapper  `json:"-"`
@@ -22,10 +33,15 @@ type Schema struct {
 func (s *Schema) DeepCopy() *Schema {
 	r := *s
 
+	r.ResourceMethods = slices.Clone(s.ResourceMethods)
+	r.CollectionMethods = slices.Clone(s.CollectionMethods)
+	r.Embeds = slices.Clone(s.Embeds)
+	r.FieldOrder = slices.Clone(s.FieldOrder)
+
 	if s.ResourceFields != nil {
 		r.ResourceFields = map[string]Field{}
 		for k, v := range s.ResourceFields {
-			r.ResourceFields[k] = v
+			r.ResourceFields[k] = *v.DeepCopy()
 		}
 	}
 
@@ -39,7 +55,7 @@ func (s *Schema) DeepCopy() *Schema {
 	if s.CollectionFields != nil {
 		r.CollectionFields = map[string]Field{}
 		for k, v := range s.CollectionFields {
-			r.CollectionFields[k] = v
+			r.CollectionFields[k] = *v.DeepCopy()
 		}
 	}
 
@@ -50,6 +66,13 @@ func (s *Schema) DeepCopy() *Schema {
 		}
 	}
 
+	if s.Links != nil {
+		r.Links = map[string]string{}
+		for k, v := range s.Links {
+			r.Links[k] = v
+		}
+	}
+
 	if s.Attributes != nil {
 		r.Attributes = map[string]interface{}{}
 		for k, v := range s.Attributes {
@@ -64,23 +87,82 @@ func (s *Schema) DeepCopy() *Schema {
 	return &r
 }
 
+// OrderedFieldNames returns the names of the resource fields in the order
+// they were declared. Fields not listed in FieldOrder, such as fields added by
+// mappers, follow in alphabetical order.
+func (s *Schema) OrderedFieldNames() []string {
+	result := make([]string, 0, len(s.ResourceFields))
+	seen := map[string]bool{}
+	for _, name := range s.FieldOrder {
+		if _, ok := s.ResourceFields[name]; ok && !seen[name] {
+			seen[name] = true
+			result = append(result, name)
+		}
+	}
+
+	var rest []string
+	for name := range s.ResourceFields {
+		if !seen[name] {
+			rest = append(rest, name)
+		}
+	}
+	sort.Strings(rest)
+
+	return append(result, rest...)
+}
+
 type Field struct {
-	Type         string      `json:"type,omitempty"`
-	Default      interface{} `json:"default,omitempty"`
-	Nullable     bool        `json:"nullable,omitempty"`
-	Create       bool        `json:"create"`
-	WriteOnly    bool        `json:"writeOnly,omitempty"`
-	Required     bool        `json:"required,omitempty"`
-	Update       bool        `json:"update"`
-	MinLength    *int64      `json:"minLength,omitempty"`
-	MaxLength    *int64      `json:"maxLength,omitempty"`
-	Min          *int64      `json:"min,omitempty"`
-	Max          *int64      `json:"max,omitempty"`
-	Options      []string    `json:"options,omitempty"`
-	ValidChars   string      `json:"validChars,omitempty"`
-	InvalidChars string      `json:"invalidChars,omitempty"`
-	Description  string      `json:"description,omitempty"`
-	CodeName     string      `json:"-"`
+	Type          string      `json:"type,omitempty"`
+	Default       interface{} `json:"default,omitempty"`
+	Nullable      bool        `json:"nullable,omitempty"`
+	Create        bool        `json:"create"`
+	WriteOnly     bool        `json:"writeOnly,omitempty"`
+	WriteOnce     bool        `json:"writeOnce,omitempty"`
+	Required      bool        `json:"required,omitempty"`
+	Update        bool        `json:"update"`
+	MinLength     *int64      `json:"minLength,omitempty"`
+	MaxLength     *int64      `json:"maxLength,omitempty"`
+	Min           *int64      `json:"min,omitempty"`
+	Max           *int64      `json:"max,omitempty"`
+	Options       []string    `json:"options,omitempty"`
+	Pattern       string      `json:"pattern,omitempty"`
+	ValidChars    string      `json:"validChars,omitempty"`
+	InvalidChars  string      `json:"invalidChars,omitempty"`
+	Description   string      `json:"description,omitempty"`
+	PatchStrategy string      `json:"patchStrategy,omitempty"`
+	PatchMergeKey string      `json:"patchMergeKey,omitempty"`
+	CodeName      string      `json:"-"`
+}
+
+// IsNullable returns true if the field is marked nullable or has a type like
+// "nullable[string]".
+func (f *Field) IsNullable() bool {
+	return f.Nullable || definition.IsNullableType(f.Type)
+}
+
+// ReadOnly returns true if the field can neither be set on create nor
+// changed on update.
+func (f *Field) ReadOnly() bool {
+	return !f.Create && !f.Update && !f.WriteOnce
+}
+
+func (f *Field) DeepCopy() *Field {
+	r := *f
+	r.MinLength = copyInt(f.MinLength)
+	r.MaxLength = copyInt(f.MaxLength)
+	r.Min = copyInt(f.Min)
+	r.Max = copyInt(f.Max)
+	r.Options = slices.Clone(f.Options)
+	r.Default = data.DeepCopyValue(f.Default)
+	return &r
+}
+
+func copyInt(i *int64) *int64 {
+	if i == nil {
+		return nil
+	}
+	r := *i
+	return &r
 }
 
 type Action struct {
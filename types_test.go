@@ -0,0 +1,33 @@
+package schemas
+
+import (
+	"reflect"
+	"testing"
+)
+
+type orderedType struct {
+	Zeta  string `json:"zeta"`
+	Alpha string `json:"alpha"`
+	Mid   string `json:"mid"`
+}
+
+func TestOrderedFieldNames(t *testing.T) {
+	s := EmptySchemas()
+	schema, err := s.Import(orderedType{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if expected := []string{"zeta", "alpha", "mid"}; !reflect.DeepEqual(schema.OrderedFieldNames(), expected) {
+		t.Fatalf("expected %v, got %v", expected, schema.OrderedFieldNames())
+	}
+
+	// fields that are not declared follow in alphabetical order and removed
+	// fields are skipped
+	schema = schema.DeepCopy()
+	schema.ResourceFields["b"] = Field{Type: "string"}
+	schema.ResourceFields["a"] = Field{Type: "string"}
+	delete(schema.ResourceFields, "alpha")
+	if expected := []string{"zeta", "mid", "a", "b"}; !reflect.DeepEqual(schema.OrderedFieldNames(), expected) {
+		t.Fatalf("expected %v, got %v", expected, schema.OrderedFieldNames())
+	}
+}
@@ -0,0 +1,26 @@
+package schemas
+
+import (
+	"context"
+
+	"github.com/acorn-io/schemer/data"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+// FromInternalUnstructured applies mapper to the content of u in place, so
+// objects read with controller-runtime clients can be mapped without
+// conversion.
+func FromInternalUnstructured(ctx context.Context, mapper Mapper, u *unstructured.Unstructured) {
+	obj := data.FromUnstructured(u)
+	FromInternalContext(ctx, mapper, obj)
+	data.Normalize(obj)
+}
+
+// ToInternalUnstructured applies mapper to the content of u in place, see
+// FromInternalUnstructured.
+func ToInternalUnstructured(ctx context.Context, mapper Mapper, u *unstructured.Unstructured) error {
+	obj := data.FromUnstructured(u)
+	err := ToInternalContext(ctx, mapper, obj)
+	data.Normalize(obj)
+	return err
+}
@@ -0,0 +1,44 @@
+package schemas
+
+import (
+	"context"
+	"testing"
+
+	"github.com/acorn-io/schemer/data"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+// replicasMapper sets an int, which the unstructured package can't handle.
+type replicasMapper struct{}
+
+func (replicasMapper) FromInternal(obj data.Object) {
+	obj["replicas"] = 1
+}
+
+func (replicasMapper) ToInternal(obj data.Object) error {
+	obj["replicas"] = 2
+	return nil
+}
+
+func (replicasMapper) ModifySchema(*Schema, *Schemas) error {
+	return nil
+}
+
+func TestUnstructuredMapping(t *testing.T) {
+	mapper := Mappers{prefixMapper{field: "name", prefix: "app-"}, replicasMapper{}}
+	u := &unstructured.Unstructured{Object: map[string]interface{}{"name": "web"}}
+
+	FromInternalUnstructured(context.Background(), mapper, u)
+	if u.Object["name"] != "app-web" || u.Object["replicas"] != int64(1) {
+		t.Fatalf("unexpected object %v", u.Object)
+	}
+	// DeepCopy panics if a value was not normalized
+	u = u.DeepCopy()
+
+	if err := ToInternalUnstructured(context.Background(), mapper, u); err != nil {
+		t.Fatal(err)
+	}
+	if u.Object["name"] != "web" || u.Object["replicas"] != int64(2) {
+		t.Fatalf("unexpected object %v", u.Object)
+	}
+}
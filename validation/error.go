@@ -30,6 +30,7 @@ var (
 	InvalidType        = ErrorCode{"InvalidType", 422}
 	ActionNotAvailable = ErrorCode{"ActionNotAvailable", 404}
 	InvalidState       = ErrorCode{"InvalidState", 422}
+	FieldNotWritable   = ErrorCode{"FieldNotWritable", 422}
 
 	ServerError        = ErrorCode{"ServerError", 500}
 	ClusterUnavailable = ErrorCode{"ClusterUnavailable", 503}
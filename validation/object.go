@@ -0,0 +1,152 @@
+package validation
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/acorn-io/schemer"
+	"github.com/acorn-io/schemer/definition"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+// ValidateObject checks obj against the fields of schema and the schemas of
+// nested objects and returns an error for every invalid field, with paths like
+// "spec.containers[0].image". Fields that are missing or empty are only
+// reported if they are required, fields that are not in the schema are
+// ignored.
+func ValidateObject(s *schemas.Schemas, schema *schemas.Schema, obj map[string]interface{}) field.ErrorList {
+	return validateObject(s, schema, obj, nil)
+}
+
+func validateObject(s *schemas.Schemas, schema *schemas.Schema, obj map[string]interface{}, path *field.Path) field.ErrorList {
+	var errs field.ErrorList
+	for _, name := range schema.OrderedFieldNames() {
+		f := schema.ResourceFields[name]
+		fieldPath := path.Child(name)
+
+		value, ok := obj[name]
+		switch {
+		case value == nil && f.Required && f.Default == nil:
+			errs = append(errs, field.Required(fieldPath, ""))
+		case value == nil && ok && !f.IsNullable() && f.Default == nil:
+			errs = append(errs, field.Invalid(fieldPath, nil, "must not be null"))
+		case value != nil:
+			errs = append(errs, validateValue(s, f, f.Type, value, fieldPath)...)
+		}
+	}
+	return errs
+}
+
+func validateValue(s *schemas.Schemas, f schemas.Field, fieldType string, value interface{}, path *field.Path) field.ErrorList {
+	t, err := definition.Parse(fieldType)
+	if err != nil {
+		return field.ErrorList{field.InternalError(path, err)}
+	}
+	t = t.NonNullable()
+
+	switch {
+	case value == nil:
+		return nil
+	case t.IsArray():
+		items, ok := value.([]interface{})
+		if !ok {
+			return field.ErrorList{field.Invalid(path, value, "must be an array")}
+		}
+		var errs field.ErrorList
+		for i, item := range items {
+			errs = append(errs, validateValue(s, f, t.Elem.String(), item, path.Index(i))...)
+		}
+		return errs
+	case t.IsMap():
+		m, ok := value.(map[string]interface{})
+		if !ok {
+			return field.ErrorList{field.Invalid(path, value, "must be an object")}
+		}
+		var errs field.ErrorList
+		for _, key := range sortedKeys(m) {
+			errs = append(errs, validateValue(s, f, t.Elem.String(), m[key], path.Key(key))...)
+		}
+		return errs
+	}
+
+	converted, err := ConvertSimple(t.String(), value)
+	if errors.Is(err, ErrComplexType) {
+		nested := s.Schema(t.Name)
+		if nested == nil {
+			return nil
+		}
+		m, ok := value.(map[string]interface{})
+		if !ok {
+			return field.ErrorList{field.Invalid(path, value, "must be an object")}
+		}
+		return validateObject(s, nested, m, path)
+	} else if err != nil {
+		return field.ErrorList{field.Invalid(path, value, describe(err, f))}
+	}
+
+	if converted == "" {
+		if f.Required {
+			return field.ErrorList{field.Required(path, "")}
+		}
+		return nil
+	}
+	if err := CheckFieldCriteria(path.String(), f, converted); err != nil {
+		return field.ErrorList{field.Invalid(path, value, describe(err, f))}
+	}
+	return nil
+}
+
+// describe returns the message of a validation error of a field.
+func describe(err error, f schemas.Field) string {
+	switch err {
+	case NotNullable:
+		return "must not be empty"
+	case MinLimitExceeded:
+		if f.Min != nil {
+			return fmt.Sprintf("must be greater than or equal to %d", *f.Min)
+		}
+		return "is less than the minimum"
+	case MaxLimitExceeded:
+		if f.Max != nil {
+			return fmt.Sprintf("must be less than or equal to %d", *f.Max)
+		}
+		return "is greater than the maximum"
+	case MinLengthExceeded:
+		if f.MinLength != nil {
+			return fmt.Sprintf("must be at least %d characters long", *f.MinLength)
+		}
+		return "is shorter than the minimum length"
+	case MaxLengthExceeded:
+		if f.MaxLength != nil {
+			return fmt.Sprintf("must be at most %d characters long", *f.MaxLength)
+		}
+		return "is longer than the maximum length"
+	case InvalidOption:
+		if len(f.Options) > 0 {
+			return fmt.Sprintf("must be one of %v", f.Options)
+		}
+		return "is not a valid option"
+	case InvalidCharacters:
+		return "contains invalid characters"
+	case InvalidFormat:
+		if f.Pattern != "" {
+			return fmt.Sprintf("must match %s", f.Pattern)
+		}
+		return "has an invalid format"
+	}
+	var code ErrorCode
+	if errors.As(err, &code) {
+		return code.Code
+	}
+	return err.Error()
+}
+
+func sortedKeys(m map[string]interface{}) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	slices.Sort(keys)
+	return keys
+}
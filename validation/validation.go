@@ -3,10 +3,14 @@ package validation
 import (
 	"errors"
 	"fmt"
+	"regexp"
+	"slices"
+	"strconv"
 	"strings"
 
 	"github.com/acorn-io/schemer"
 	"github.com/acorn-io/schemer/data/convert"
+	"github.com/acorn-io/schemer/definition"
 	"k8s.io/apimachinery/pkg/util/validation"
 )
 
@@ -28,7 +32,7 @@ func CheckFieldCriteria(fieldName string, field schemas.Field, value interface{}
 		strVal = fmt.Sprint(value)
 	}
 
-	if (value == nil || value == "") && !field.Nullable {
+	if (value == nil || value == "") && !field.IsNullable() {
 		if field.Default == nil {
 			return NotNullable
 		}
@@ -53,7 +57,7 @@ func CheckFieldCriteria(fieldName string, field schemas.Field, value interface{}
 	}
 
 	if len(field.Options) > 0 {
-		if hasStrVal || !field.Nullable {
+		if hasStrVal || !field.IsNullable() {
 			found := false
 			for _, option := range field.Options {
 				if strVal == option {
@@ -83,6 +87,12 @@ func CheckFieldCriteria(fieldName string, field schemas.Field, value interface{}
 		}
 	}
 
+	if field.Pattern != "" && hasStrVal {
+		if matched, err := regexp.MatchString(field.Pattern, strVal); err != nil || !matched {
+			return InvalidFormat
+		}
+	}
+
 	return nil
 }
 
@@ -90,6 +100,27 @@ func ConvertSimple(fieldType string, value interface{}) (interface{}, error) {
 	if value == nil {
 		return value, nil
 	}
+	fieldType = definition.NonNullableType(fieldType)
+	if t, err := definition.Parse(fieldType); err == nil && len(t.Constraints) > 0 {
+		result, err := ConvertSimple(t.WithoutConstraints().String(), value)
+		if err != nil {
+			return result, err
+		}
+		return result, checkConstraints(t, result)
+	}
+	if t, err := definition.Parse(fieldType); err == nil && t.Name == "enum" && len(t.Values) > 0 {
+		str := convert.ToString(value)
+		if !slices.Contains(t.Values, str) {
+			return value, InvalidOption
+		}
+		return str, nil
+	}
+
+	if t, err := definition.Parse(fieldType); err == nil {
+		if c, ok := definition.Lookup(t.Name); ok && c.Convert != nil {
+			return c.Convert(t, value)
+		}
+	}
 
 	switch fieldType {
 	case "json":
@@ -147,9 +178,57 @@ func ConvertSimple(fieldType string, value interface{}) (interface{}, error) {
 		return convert.ToString(value), nil
 	case "base64":
 		return convert.ToString(value), nil
+	case "quantity":
+		if convert.ToString(value) == "" {
+			return "", nil
+		}
+		str, err := convert.FormatQuantity(value)
+		if err != nil {
+			return value, InvalidFormat
+		}
+		return str, nil
 	case "reference":
 		return convert.ToString(value), nil
 	}
 
 	return nil, ErrComplexType
 }
+
+// checkConstraints checks a converted value against the constraints of types
+// like "int{min=1}".
+func checkConstraints(t *definition.Type, value interface{}) error {
+	for _, c := range t.Constraints {
+		limit, _ := strconv.ParseInt(c.Value, 10, 64)
+		switch c.Key {
+		case "min":
+			if n, ok := value.(int64); ok && n < limit {
+				return MinLimitExceeded
+			}
+			if f, ok := value.(float64); ok && f < float64(limit) {
+				return MinLimitExceeded
+			}
+		case "max":
+			if n, ok := value.(int64); ok && n > limit {
+				return MaxLimitExceeded
+			}
+			if f, ok := value.(float64); ok && f > float64(limit) {
+				return MaxLimitExceeded
+			}
+		case "minLength":
+			if s, ok := value.(string); ok && int64(len(s)) < limit {
+				return MinLengthExceeded
+			}
+		case "maxLength":
+			if s, ok := value.(string); ok && int64(len(s)) > limit {
+				return MaxLengthExceeded
+			}
+		case "pattern":
+			if s, ok := value.(string); ok {
+				if matched, err := regexp.MatchString(c.Value, s); err != nil || !matched {
+					return InvalidFormat
+				}
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,202 @@
+package watchstream
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"path"
+	"strings"
+	"time"
+
+	schemas "github.com/acorn-io/schemer"
+	"github.com/acorn-io/schemer/data"
+	"golang.org/x/net/websocket"
+)
+
+// Message is the JSON encoding of an event sent to clients. Over SSE the type
+// and resource version are also sent as the event name and id.
+type Message struct {
+	Type            schemas.WatchEventType `json:"type"`
+	ResourceVersion string                 `json:"resourceVersion,omitempty"`
+	Object          data.Object            `json:"object,omitempty"`
+}
+
+// Handler streams the changes to the objects of the schema named by the last
+// element of the request path, so it's usually mounted like
+// mux.Handle("/v1/watch/", handler). The query parameters namespace,
+// labelSelector and resourceVersion restrict the watch, SSE clients resume
+// with the Last-Event-ID header. Requests with an Upgrade: websocket header
+// are served over a WebSocket, all others as server-sent events.
+type Handler struct {
+	Store   schemas.Store
+	Schemas *schemas.Schemas
+	// Heartbeat is the interval of keep-alive messages on idle streams, it
+	// defaults to 30 seconds.
+	Heartbeat time.Duration
+	// CheckOrigin accepts or rejects the Origin of WebSocket requests. By
+	// default the host of the Origin must match the host of the request.
+	CheckOrigin func(req *http.Request) bool
+}
+
+func (h *Handler) ServeHTTP(rw http.ResponseWriter, req *http.Request) {
+	if req.Method != http.MethodGet {
+		rw.Header().Set("Allow", "GET")
+		http.Error(rw, fmt.Sprintf("method %s is not allowed", req.Method), http.StatusMethodNotAllowed)
+		return
+	}
+
+	id, err := url.PathUnescape(path.Base(req.URL.Path))
+	if err != nil {
+		http.Error(rw, err.Error(), http.StatusBadRequest)
+		return
+	}
+	schema := h.Schemas.SchemasByID()[id]
+	if schema == nil || !h.Schemas.Allowed(req.Context(), schema, schemas.MethodGet, true) {
+		http.Error(rw, fmt.Sprintf("schema %s not found", id), http.StatusNotFound)
+		return
+	}
+
+	query := req.URL.Query()
+	opts := schemas.WatchOptions{
+		Namespace:     query.Get("namespace"),
+		LabelSelector: query.Get("labelSelector"),
+		Revision:      query.Get("resourceVersion"),
+	}
+	if lastEventID := req.Header.Get("Last-Event-ID"); lastEventID != "" {
+		opts.Revision = lastEventID
+	}
+
+	if strings.EqualFold(req.Header.Get("Upgrade"), "websocket") {
+		h.serveWebSocket(rw, req, schema, opts)
+		return
+	}
+	h.serveSSE(rw, req, schema, opts)
+}
+
+func (h *Handler) heartbeat() time.Duration {
+	if h.Heartbeat > 0 {
+		return h.Heartbeat
+	}
+	return 30 * time.Second
+}
+
+func (h *Handler) serveSSE(rw http.ResponseWriter, req *http.Request, schema *schemas.Schema, opts schemas.WatchOptions) {
+	flusher, ok := rw.(http.Flusher)
+	if !ok {
+		http.Error(rw, "streaming is not supported", http.StatusInternalServerError)
+		return
+	}
+
+	rw.Header().Set("Content-Type", "text/event-stream")
+	rw.Header().Set("Cache-Control", "no-cache")
+	rw.WriteHeader(http.StatusOK)
+	flusher.Flush()
+
+	h.stream(req.Context(), schema, opts, func(msg *Message) error {
+		if msg == nil {
+			_, err := fmt.Fprint(rw, ": ping\n\n")
+			flusher.Flush()
+			return err
+		}
+		b, err := json.Marshal(msg)
+		if err != nil {
+			return err
+		}
+		if msg.ResourceVersion != "" {
+			if _, err := fmt.Fprintf(rw, "id: %s\n", msg.ResourceVersion); err != nil {
+				return err
+			}
+		}
+		_, err = fmt.Fprintf(rw, "event: %s\ndata: %s\n\n", msg.Type, b)
+		flusher.Flush()
+		return err
+	})
+}
+
+func (h *Handler) serveWebSocket(rw http.ResponseWriter, req *http.Request, schema *schemas.Schema, opts schemas.WatchOptions) {
+	server := websocket.Server{
+		Handshake: func(config *websocket.Config, req *http.Request) error {
+			if h.CheckOrigin != nil {
+				if !h.CheckOrigin(req) {
+					return fmt.Errorf("origin %s is not allowed", req.Header.Get("Origin"))
+				}
+				return nil
+			}
+			return sameOrigin(req)
+		},
+		Handler: func(conn *websocket.Conn) {
+			ctx, cancel := context.WithCancel(req.Context())
+			defer cancel()
+
+			// the client doesn't send messages, reading only detects that
+			// the connection was closed
+			go func() {
+				defer cancel()
+				var discard string
+				for websocket.Message.Receive(conn, &discard) == nil {
+				}
+			}()
+
+			h.stream(ctx, schema, opts, func(msg *Message) error {
+				if msg == nil {
+					return websocket.Message.Send(conn, `{"type":"ping"}`)
+				}
+				return websocket.JSON.Send(conn, msg)
+			})
+		},
+	}
+	server.ServeHTTP(rw, req)
+}
+
+// stream passes the events of the watch to write until ctx is done, the watch
+// ends or write fails. write is called with nil to send a heartbeat.
+func (h *Handler) stream(ctx context.Context, schema *schemas.Schema, opts schemas.WatchOptions, write func(msg *Message) error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	ticker := time.NewTicker(h.heartbeat())
+	defer ticker.Stop()
+
+	events := Watch(ctx, h.Store, schema, opts)
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := write(nil); err != nil {
+				return
+			}
+		case event, ok := <-events:
+			if !ok {
+				return
+			}
+			msg := &Message{
+				Type:   event.Type,
+				Object: event.Object,
+			}
+			if event.Type != schemas.WatchError {
+				msg.ResourceVersion = ResourceVersion(event.Object)
+			}
+			if err := write(msg); err != nil {
+				return
+			}
+		}
+	}
+}
+
+func sameOrigin(req *http.Request) error {
+	origin := req.Header.Get("Origin")
+	if origin == "" {
+		return nil
+	}
+	u, err := url.Parse(origin)
+	if err != nil {
+		return err
+	}
+	if !strings.EqualFold(u.Host, req.Host) {
+		return fmt.Errorf("origin %s is not allowed", origin)
+	}
+	return nil
+}
@@ -0,0 +1,86 @@
+// Package watchstream streams the changes to the objects of a schema to HTTP
+// clients as server-sent events or over a WebSocket. Watches are resumed from
+// the last seen resource version if the store ends them, and clients can
+// resume a stream by passing the resource version of the last event they
+// received.
+package watchstream
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	schemas "github.com/acorn-io/schemer"
+	"github.com/acorn-io/schemer/data"
+)
+
+// retryDelay is the time to wait before restarting a watch the store ended.
+var retryDelay = time.Second
+
+// Watch returns the changes to the objects of schema like store.Watch, but
+// restarts the watch from the last seen resource version when the store ends
+// it. The channel is closed once ctx is done or after a WatchError event, for
+// example because the resource version expired and the client has to list
+// the objects again.
+func Watch(ctx context.Context, store schemas.Store, schema *schemas.Schema, opts schemas.WatchOptions) <-chan schemas.WatchEvent {
+	result := make(chan schemas.WatchEvent)
+	go func() {
+		defer close(result)
+		for {
+			events, err := store.Watch(ctx, schema, opts)
+			if err != nil {
+				if ctx.Err() == nil {
+					send(ctx, result, errorEvent(err))
+				}
+				return
+			}
+
+			for event := range events {
+				if event.Type == schemas.WatchError {
+					send(ctx, result, event)
+					return
+				}
+				if revision := ResourceVersion(event.Object); revision != "" {
+					opts.Revision = revision
+				}
+				if !send(ctx, result, event) {
+					return
+				}
+			}
+
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(retryDelay):
+			}
+		}
+	}()
+	return result
+}
+
+// ResourceVersion returns metadata.resourceVersion of obj.
+func ResourceVersion(obj data.Object) string {
+	return obj.String("metadata", "resourceVersion")
+}
+
+func send(ctx context.Context, c chan<- schemas.WatchEvent, event schemas.WatchEvent) bool {
+	select {
+	case c <- event:
+		return true
+	case <-ctx.Done():
+		return false
+	}
+}
+
+// errorEvent returns a WatchError event with a status object for err.
+func errorEvent(err error) schemas.WatchEvent {
+	return schemas.WatchEvent{
+		Type: schemas.WatchError,
+		Object: data.Object{
+			"kind":    "Status",
+			"status":  "Failure",
+			"message": err.Error(),
+			"code":    http.StatusInternalServerError,
+		},
+	}
+}
@@ -0,0 +1,93 @@
+package watchstream
+
+import (
+	"bufio"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	schemas "github.com/acorn-io/schemer"
+	"github.com/acorn-io/schemer/data"
+)
+
+type fakeStore struct {
+	schemas.Store
+
+	lock      sync.Mutex
+	revisions []string
+}
+
+func (f *fakeStore) Watch(ctx context.Context, schema *schemas.Schema, opts schemas.WatchOptions) (<-chan schemas.WatchEvent, error) {
+	f.lock.Lock()
+	f.revisions = append(f.revisions, opts.Revision)
+	calls := len(f.revisions)
+	f.lock.Unlock()
+
+	result := make(chan schemas.WatchEvent, 1)
+	if calls == 1 {
+		result <- schemas.WatchEvent{
+			Type: schemas.WatchAdded,
+			Object: data.Object{
+				"metadata": map[string]interface{}{"name": "a", "resourceVersion": "5"},
+			},
+		}
+	} else {
+		result <- schemas.WatchEvent{
+			Type:   schemas.WatchError,
+			Object: data.Object{"code": 410, "message": "too old"},
+		}
+	}
+	close(result)
+	return result, nil
+}
+
+func TestWatchResumes(t *testing.T) {
+	retryDelay = 0
+	store := &fakeStore{}
+
+	var events []schemas.WatchEvent
+	for event := range Watch(context.Background(), store, &schemas.Schema{ID: "app"}, schemas.WatchOptions{Revision: "1"}) {
+		events = append(events, event)
+	}
+
+	if len(events) != 2 || events[0].Type != schemas.WatchAdded || events[1].Type != schemas.WatchError {
+		t.Fatalf("expected added and error event, got %v", events)
+	}
+	if strings.Join(store.revisions, ",") != "1,5" {
+		t.Fatalf("expected watches from revisions 1 and 5, got %v", store.revisions)
+	}
+}
+
+func TestHandlerSSE(t *testing.T) {
+	retryDelay = 0
+	s := schemas.EmptySchemas()
+	s.MustAddSchema(schemas.Schema{
+		ID:                "app",
+		CollectionMethods: []string{schemas.MethodGet},
+	})
+
+	server := httptest.NewServer(&Handler{Store: &fakeStore{}, Schemas: s})
+	defer server.Close()
+
+	resp, err := http.Get(server.URL + "/v1/watch/app")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	if resp.Header.Get("Content-Type") != "text/event-stream" {
+		t.Fatalf("unexpected content type %s", resp.Header.Get("Content-Type"))
+	}
+
+	var lines []string
+	scanner := bufio.NewScanner(resp.Body)
+	for scanner.Scan() {
+		lines = append(lines, scanner.Text())
+	}
+	body := strings.Join(lines, "\n")
+	if !strings.Contains(body, "id: 5\nevent: added\n") || !strings.Contains(body, "event: error\n") {
+		t.Fatalf("unexpected stream:\n%s", body)
+	}
+}